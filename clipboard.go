@@ -1,5 +1,7 @@
 package gui
 
+import "sync"
+
 // ClipboardProvider abstracts system clipboard access.
 // Implement this interface with platform-specific clipboard APIs.
 //
@@ -25,42 +27,84 @@ type ClipboardProvider interface {
 	SetText(text string)
 }
 
+// MemoryClipboard is an in-process clipboard.
+// It is used when no ClipboardProvider is registered, so copy/paste still
+// works within the application in tests and headless runs.
+// Safe for concurrent use.
+type MemoryClipboard struct {
+	mu   sync.Mutex
+	text string
+}
+
+// GetText returns the stored text.
+func (c *MemoryClipboard) GetText() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.text
+}
+
+// SetText stores text.
+func (c *MemoryClipboard) SetText(text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.text = text
+}
+
+// defaultClipboard is the process-global fallback clipboard.
+var defaultClipboard = &MemoryClipboard{}
+
 // Global clipboard provider (set by application during initialization).
-var clipboardProvider ClipboardProvider
+// nil means the in-memory defaultClipboard is used. clipboardMu guards it,
+// so the provider may be swapped while other goroutines copy and paste.
+var (
+	clipboardProvider ClipboardProvider
+	clipboardMu       sync.RWMutex
+)
 
 // SetClipboardProvider sets the global clipboard provider.
 // Call this during application initialization with a platform-specific implementation.
+// Passing nil reverts to the built-in in-memory clipboard.
 //
 // Example with GLFW:
 //
 //	gui.SetClipboardProvider(&GLFWClipboard{window: window})
 func SetClipboardProvider(cp ClipboardProvider) {
+	clipboardMu.Lock()
 	clipboardProvider = cp
+	clipboardMu.Unlock()
 }
 
 // GetClipboardProvider returns the current clipboard provider, or nil if not set.
+// Use EffectiveClipboardProvider for the clipboard actually in use.
 func GetClipboardProvider() ClipboardProvider {
+	clipboardMu.RLock()
+	defer clipboardMu.RUnlock()
 	return clipboardProvider
 }
 
+// EffectiveClipboardProvider returns the clipboard in use: the registered
+// provider, or the in-memory default if none is set. It is never nil.
+func EffectiveClipboardProvider() ClipboardProvider {
+	if cp := GetClipboardProvider(); cp != nil {
+		return cp
+	}
+	return defaultClipboard
+}
+
 // ClipboardGetText retrieves text from the clipboard.
-// Returns empty string if no clipboard provider is set or clipboard is empty.
+// Uses the in-memory clipboard if no provider is set.
 func ClipboardGetText() string {
-	if clipboardProvider != nil {
-		return clipboardProvider.GetText()
-	}
-	return ""
+	return EffectiveClipboardProvider().GetText()
 }
 
 // ClipboardSetText copies text to the clipboard.
-// Does nothing if no clipboard provider is set.
+// Uses the in-memory clipboard if no provider is set.
 func ClipboardSetText(text string) {
-	if clipboardProvider != nil {
-		clipboardProvider.SetText(text)
-	}
+	EffectiveClipboardProvider().SetText(text)
 }
 
-// ClipboardAvailable returns true if a clipboard provider is configured.
+// ClipboardAvailable returns true if a system clipboard provider is configured.
+// Copy/paste still works without one, but only within this process.
 func ClipboardAvailable() bool {
-	return clipboardProvider != nil
+	return GetClipboardProvider() != nil
 }
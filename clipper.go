@@ -1,5 +1,7 @@
 package gui
 
+import "sort"
+
 // ListClipper helps virtualize large lists by calculating the visible item range.
 // This is critical for performance with large datasets (1000+ items) where
 // rendering all items every frame would cause significant slowdown.
@@ -16,6 +18,11 @@ type ListClipper struct {
 	EndIdx     int     // Last visible item index (exclusive)
 	ItemHeight float32 // Height of each item
 	TotalItems int     // Total number of items in the list
+
+	// Offsets holds item tops for variable-height lists (see
+	// NewListClipperOffsets): item i spans Offsets[i] to Offsets[i+1].
+	// nil when every item is ItemHeight tall.
+	Offsets []float32
 }
 
 // NewListClipper calculates the visible item range for a scrollable list.
@@ -63,6 +70,41 @@ func NewListClipper(totalItems int, itemHeight, visibleHeight, scrollY float32)
 	}
 }
 
+// NewListClipperOffsets is NewListClipper for items of varying height.
+// offsets[i] is the top of item i relative to the list's top edge and
+// offsets[len(offsets)-1] is the total content height, so a list of n items
+// passes n+1 offsets in increasing order.
+func NewListClipperOffsets(offsets []float32, visibleHeight, scrollY float32) *ListClipper {
+	n := max(len(offsets)-1, 0)
+	c := &ListClipper{TotalItems: n, Offsets: offsets}
+	if n == 0 {
+		return c
+	}
+
+	// First item whose bottom is below the scroll position, then every item
+	// starting above the visible area's bottom edge (+1 for partial visibility)
+	c.StartIdx = sort.Search(n, func(i int) bool { return offsets[i+1] > scrollY })
+	c.EndIdx = min(sort.Search(n, func(i int) bool { return offsets[i] >= scrollY+visibleHeight })+1, n)
+	c.StartIdx = min(c.StartIdx, c.EndIdx)
+	return c
+}
+
+// ItemTop returns the top of an item relative to the list's top edge.
+func (c *ListClipper) ItemTop(idx int) float32 {
+	if c.Offsets != nil {
+		return c.Offsets[max(0, min(idx, len(c.Offsets)-1))]
+	}
+	return float32(idx) * c.ItemHeight
+}
+
+// ItemSize returns the height of an item.
+func (c *ListClipper) ItemSize(idx int) float32 {
+	if c.Offsets != nil {
+		return c.ItemTop(idx+1) - c.ItemTop(idx)
+	}
+	return c.ItemHeight
+}
+
 // ShouldRender returns true if the item at the given index should be rendered.
 // Use this when iterating through all items to skip invisible ones.
 func (c *ListClipper) ShouldRender(idx int) bool {
@@ -78,7 +120,7 @@ func (c *ListClipper) ShouldRender(idx int) bool {
 //
 // Returns the Y position where the item should be drawn.
 func (c *ListClipper) ItemY(idx int, baseY, scrollY float32) float32 {
-	return baseY + float32(idx)*c.ItemHeight - scrollY
+	return baseY + c.ItemTop(idx) - scrollY
 }
 
 // VisibleCount returns the number of items that should be rendered.
@@ -88,7 +130,7 @@ func (c *ListClipper) VisibleCount() int {
 
 // ContentHeight returns the total content height (for scrollbar calculations).
 func (c *ListClipper) ContentHeight() float32 {
-	return float32(c.TotalItems) * c.ItemHeight
+	return c.ItemTop(c.TotalItems)
 }
 
 // MaxScroll returns the maximum valid scroll offset.
@@ -107,8 +149,8 @@ func (c *ListClipper) ScrollToItem(idx int, currentScroll, visibleHeight float32
 		return currentScroll
 	}
 
-	itemTop := float32(idx) * c.ItemHeight
-	itemBottom := itemTop + c.ItemHeight
+	itemTop := c.ItemTop(idx)
+	itemBottom := itemTop + c.ItemSize(idx)
 
 	// If item is above visible area, scroll up to it
 	if itemTop < currentScroll {
@@ -103,10 +103,17 @@ var BuiltinComponents = struct {
 	InputText   string // component_input_text
 	Slider      string // component_slider
 	SliderInt   string // component_slider_int
+	SliderRange string // component_slider_range
 	NumberInput string // component_number_input
 	Checkbox    string // component_checkbox
+	CheckboxTri string // component_checkbox_tristate
 	RadioButton string // component_radio_button
+	RadioGroup  string // component_radio_group
 	ComboBox    string // component_combobox
+	Rating      string // component_rating
+	ColorPicker string // component_color_picker
+	Knob        string // component_knob
+	DatePicker  string // component_date_picker
 
 	// Layout components
 	Panel      string // component_panel
@@ -125,6 +132,7 @@ var BuiltinComponents = struct {
 	Separator        string // component_separator
 	CollapsingHeader string // component_collapsing_header
 	TreeNode         string // component_tree_node
+	Splitter         string // component_splitter
 }{
 	Text:        "component_text",
 	TextWrapped: "component_text_wrapped",
@@ -136,10 +144,17 @@ var BuiltinComponents = struct {
 	InputText:   "component_input_text",
 	Slider:      "component_slider",
 	SliderInt:   "component_slider_int",
+	SliderRange: "component_slider_range",
 	NumberInput: "component_number_input",
 	Checkbox:    "component_checkbox",
+	CheckboxTri: "component_checkbox_tristate",
 	RadioButton: "component_radio_button",
+	RadioGroup:  "component_radio_group",
 	ComboBox:    "component_combobox",
+	Rating:      "component_rating",
+	ColorPicker: "component_color_picker",
+	Knob:        "component_knob",
+	DatePicker:  "component_date_picker",
 
 	Panel:      "component_panel",
 	ListBox:    "component_listbox",
@@ -155,6 +170,7 @@ var BuiltinComponents = struct {
 	Separator:        "component_separator",
 	CollapsingHeader: "component_collapsing_header",
 	TreeNode:         "component_tree_node",
+	Splitter:         "component_splitter",
 }
 
 // ComponentTextWrapper wraps the Text widget as a Component.
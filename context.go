@@ -3,6 +3,8 @@ package gui
 import (
 	"log/slog"
 	"os"
+	"runtime"
+	"strings"
 )
 
 // guiLogger is the logger for GUI context debugging.
@@ -19,18 +21,25 @@ type Context struct {
 	ForegroundDrawList *DrawList // For popups, dropdowns, tooltips (drawn on top)
 
 	// Styling
-	style      Style
+	style      Style   // Effective style widgets draw with
+	baseStyle  Style   // Style as set, before Style.Effective
 	styleStack []Style // For PushStyle/PopStyle
 
 	// Layout
-	cursor      Vec2
-	layoutStack []*Layout
+	cursor          Vec2
+	layoutStack     []*Layout
+	labelWidthStack []float32 // For PushLabelWidth/PopLabelWidth
+	fillLayout      *Layout   // LabeledRow control column; its widgets fill the width
 
 	// Input (read-only during frame)
 	Input *InputState
 
 	// Widget state (persisted between frames)
-	stateStore StateStore
+	stateStore    StateStore
+	stores        *storeSpace   // This context's share of every FrameStore
+	scrollableIDs map[string]ID // Scrollable names to IDs, for GetScrollableState
+
+	changeObserver ChangeObserver // Set by SetChangeObserver
 
 	// IDs
 	idStack   []ID
@@ -43,6 +52,7 @@ type Context struct {
 	// Frame info
 	FrameCount uint64
 	DeltaTime  float32
+	Time       float32 // Seconds since the GUI started (or the GUI.SetTime clock)
 
 	// Focus/Active/Hover tracking
 	focusedID ID // Widget with keyboard focus
@@ -66,6 +76,11 @@ type Context struct {
 	// These tell the application whether GUI wants to consume input.
 	WantCaptureMouse    bool // True if mouse is over any GUI element
 	WantCaptureKeyboard bool // True if a text input has focus
+	EscapeUnhandled     bool // True after End if Escape was pressed and no GUI element used it
+
+	// tabConsumed is set when a widget used this frame's Tab press (e.g. to
+	// accept a completion), so Tab focus cycling leaves it alone
+	tabConsumed bool
 
 	// Panel focus tracking (for Ctrl+Tab cycling)
 	// These are set by the panel registry each frame.
@@ -75,14 +90,20 @@ type Context struct {
 	// Only one panel can be dragged at a time.
 	activeDragPanel *DraggablePanel
 
+	// Drag-and-drop between BeginDragDropSource and BeginDragDropTarget items
+	dragDrop dragDropState
+
 	// Performance optimization: pre-allocated glyph buffer for text rendering.
 	// Reused between addText() calls to avoid per-call allocations.
 	glyphBuffer []GlyphQuad
 
 	// Performance optimization: text measurement cache.
 	// Avoids redundant MeasureText calls for the same text within a frame.
-	// Key format: "text\x00scale" to differentiate scales.
-	textMeasureCache map[string]Vec2
+	// Keyed by text and FontScale, so PushFontScale blocks don't flush it;
+	// InvalidateTextCache clears it when the font or other text metrics
+	// change.
+	textMeasureCache map[textMeasureKey]Vec2
+	textCacheGen     uint64 // Bumped by InvalidateTextCache, so widget caches of measured widths notice
 
 	// Scroll focus tracking - widgets can register their focus Y position
 	// and parent Scrollable will auto-scroll to keep it visible.
@@ -97,6 +118,30 @@ type Context struct {
 	// Section stack - tracks indent depths for BeginSection/EndSection API
 	sectionStack []float32
 
+	// Clip stack - intersected clip rects pushed by clipping containers.
+	// Mirrors the DrawList clip stack so hover tests can reject clipped widgets.
+	clipStack []Rect
+
+	// Occlusion tracking for hover tests. Overlays are usually drawn after the
+	// widgets they cover, so hover tests consult the previous frame's rects.
+	popupRects     []popupRect   // Open popups registered this frame
+	prevPopupRects []popupRect   // Open popups from the previous frame
+	panelRects     []Rect        // Panel bounds this frame, in draw order
+	prevPanelRects []Rect        // Panel bounds from the previous frame
+	panelStack     []int         // Indices into panelRects of the panels being drawn
+	inPopup        bool          // Drawing popup content (skips clip and occlusion tests)
+	popupStack     []*popupFrame // Popups and menus between their Begin and End
+	menuBar        *menuBarFrame // Menu bar between BeginMenuBar and EndMenuBar
+
+	// Bounds of the last item passed to AdvanceCursor, for context menus
+	// and tooltips
+	lastItemRect Rect
+	tooltipDelay float32 // Hover time before a Tooltip shows (SetTooltipDelay)
+
+	// GUI-owned screen regions for MouseOverGUI (besides panels and popups)
+	inputRegions     []Rect // Regions registered this frame
+	inputRegionStack []Rect // Open PushInputRegion calls, for nesting
+
 	// Hierarchical focus tracking (new system, coexists with focusedID)
 	// Enables parent widgets to know which child has focus and where.
 	focusPath  *FocusPath  // Active path from root to focused leaf
@@ -113,7 +158,15 @@ type Context struct {
 
 	// Active popup tracking - persists across frames for input handling
 	// When a popup (dropdown, menu) is open, navigation should stay within it
-	activePopupID ID
+	activePopupID    ID
+	activePopupDepth int           // Focus scope depth of activePopupID (innermost wins)
+	popupOpeners     []popupOpener // Open popups, outermost first, with the focus to restore
+
+	// Escape priority chain (see escape.go)
+	escapeClaims      [escapeText + 1]ID // Claimants for next frame's Escape, by level
+	escapePopupClaims []ID               // Popups that want Escape (innermost active one wins)
+	escapeTarget      escapeTarget       // What this frame's Escape press is for
+	escapeHandled     bool               // This frame's Escape press was used
 
 	// Debug visualization
 	DebugFocusHighlight bool // When true, draw red overlays on all focused elements
@@ -121,63 +174,127 @@ type Context struct {
 
 // NewContext creates a new GUI context with default settings.
 func NewContext() *Context {
-	return &Context{
+	ctx := &Context{
 		styleStack:          make([]Style, 0, 8),
 		layoutStack:         make([]*Layout, 0, 16),
 		idStack:             make([]ID, 0, 32),
 		measuredSizes:       make(map[ID]Vec2),
-		glyphBuffer:         make([]GlyphQuad, 0, 256), // Pre-allocate for typical text
-		textMeasureCache:    make(map[string]Vec2, 64), // Cache for text measurements
-		focusPath:           NewFocusPath(),            // Hierarchical focus tracking
-		focusStack:          make([]FocusNode, 0, 8),   // Focus scope stack
-		focusRegistry:       NewFocusRegistry(),        // Focusable widget registry
+		stores:              &storeSpace{},
+		scrollableIDs:       make(map[string]ID),
+		glyphBuffer:         make([]GlyphQuad, 0, 256),         // Pre-allocate for typical text
+		textMeasureCache:    make(map[textMeasureKey]Vec2, 64), // Cache for text measurements
+		focusPath:           NewFocusPath(),                    // Hierarchical focus tracking
+		focusStack:          make([]FocusNode, 0, 8),           // Focus scope stack
+		focusRegistry:       NewFocusRegistry(),                // Focusable widget registry
 		DPIScale:            1.0,
+		tooltipDelay:        defaultTooltipDelay,
 		DebugFocusHighlight: true, // Debug: highlight focused elements in red (F10 to toggle)
 	}
+	// Drop the context's FrameStore entries once it (and its GUI) is gone
+	runtime.AddCleanup(ctx, releaseSpace, ctx.stores)
+	return ctx
 }
 
-// Style returns the current style.
+// Style returns the current style as it was set. Widgets draw with its
+// Effective form.
 func (ctx *Context) Style() Style {
-	return ctx.style
+	return ctx.baseStyle
 }
 
 // SetStyle sets the base style.
 func (ctx *Context) SetStyle(style Style) {
-	ctx.style = style
+	ctx.applyStyle(style)
 }
 
 // PushStyle temporarily overrides the style.
 func (ctx *Context) PushStyle(style Style) {
-	ctx.styleStack = append(ctx.styleStack, ctx.style)
-	ctx.style = style
+	ctx.styleStack = append(ctx.styleStack, ctx.baseStyle)
+	ctx.applyStyle(style)
 }
 
 // PopStyle restores the previous style.
 func (ctx *Context) PopStyle() {
 	n := len(ctx.styleStack)
 	if n > 0 {
-		ctx.style = ctx.styleStack[n-1]
+		ctx.applyStyle(ctx.styleStack[n-1])
 		ctx.styleStack = ctx.styleStack[:n-1]
 	}
 }
 
+// applyStyle switches to style, dropping cached text measurements if it
+// measures text differently from the current one. This is the one place the
+// effective style (high contrast, color fallbacks) is resolved.
+func (ctx *Context) applyStyle(style Style) {
+	// FontScale is part of the cache key, so scale changes keep the cache
+	if style.CharWidth != ctx.style.CharWidth || style.CharHeight != ctx.style.CharHeight ||
+		style.TabWidth != ctx.style.TabWidth {
+		ctx.InvalidateTextCache()
+	}
+	ctx.baseStyle = style
+	ctx.style = style.Effective()
+	for _, dl := range []*DrawList{ctx.DrawList, ctx.ForegroundDrawList} {
+		if dl != nil {
+			dl.AntiAliasedLines = style.AntiAliasedLines
+		}
+	}
+}
+
+// InvalidateTextCache drops all cached text measurements, so the next
+// MeasureText uses the current font. SetFont, SetFontProvider and style
+// changes that affect text size (CharWidth, CharHeight, TabWidth) call it
+// automatically (FontScale is part of the cache key); call it yourself after changing fonts directly
+// through the FontProvider. Widget caches built from measurements (combo
+// box widths, multiline wrapping) are dropped with it.
+func (ctx *Context) InvalidateTextCache() {
+	clear(ctx.textMeasureCache)
+	ctx.textCacheGen++
+}
+
+// textMeasureKey keys the text measurement cache.
+type textMeasureKey struct {
+	text  string
+	scale float32
+}
+
+// PushFontScale draws and measures text at scale (replacing
+// Style.FontScale) until the matching PopFontScale, e.g. for a large title
+// above normal body text.
+//
+// Usage:
+//
+//	ctx.PushFontScale(2)
+//	ctx.Text("Vehicle Shop")
+//	ctx.PopFontScale()
+//	ctx.Text("Pick a car")
+func (ctx *Context) PushFontScale(scale float32) {
+	style := ctx.baseStyle
+	style.FontScale = scale
+	ctx.PushStyle(style)
+}
+
+// PopFontScale restores the font scale from before PushFontScale.
+func (ctx *Context) PopFontScale() {
+	ctx.PopStyle()
+}
+
 // PushStyleColor temporarily overrides a single color.
 func (ctx *Context) PushStyleColor(field StyleColorField, color uint32) {
-	ctx.PushStyle(ctx.style)
+	style := ctx.baseStyle
 	switch field {
 	case StyleColorText:
-		ctx.style.TextColor = color
+		style.TextColor = color
 	case StyleColorButton:
-		ctx.style.ButtonColor = color
+		style.ButtonColor = color
 	case StyleColorButtonHovered:
-		ctx.style.ButtonHoveredColor = color
+		style.ButtonHoveredColor = color
 	case StyleColorButtonActive:
-		ctx.style.ButtonActiveColor = color
+		style.ButtonActiveColor = color
 	case StyleColorPanel:
-		ctx.style.PanelColor = color
+		style.PanelColor = color
 	case StyleColorSelected:
-		ctx.style.SelectedBgColor = color
+		style.SelectedBgColor = color
 	}
+	ctx.PushStyle(style)
 }
 
 // StyleColorField identifies a color field in Style for PushStyleColor.
@@ -194,14 +311,27 @@ const (
 
 // Reset prepares the context for a new frame.
 func (ctx *Context) Reset(displaySize Vec2, deltaTime float32) {
-	// Advance frame counter and clean up stale FrameStore entries
+	// Advance frame counters and clean up stale FrameStore entries
 	NextFrame()
+	ctx.NextFrame()
 
 	ctx.cursor = Vec2{0, 0}
 	ctx.layoutStack = ctx.layoutStack[:0]
 	ctx.styleStack = ctx.styleStack[:0]
+	ctx.labelWidthStack = ctx.labelWidthStack[:0]
+	ctx.fillLayout = nil
 	ctx.idStack = ctx.idStack[:0]
 	ctx.idCounter = 0
+	ctx.clipStack = ctx.clipStack[:0]
+	ctx.prevPopupRects, ctx.popupRects = ctx.popupRects, ctx.prevPopupRects[:0]
+	ctx.prevPanelRects, ctx.panelRects = ctx.panelRects, ctx.prevPanelRects[:0]
+	ctx.panelStack = ctx.panelStack[:0]
+	ctx.inPopup = false
+	ctx.popupStack = ctx.popupStack[:0]
+	ctx.lastItemRect = Rect{}
+	ctx.dragDrop.endFrame()
+	ctx.inputRegions = ctx.inputRegions[:0]
+	ctx.inputRegionStack = ctx.inputRegionStack[:0]
 	ctx.DisplaySize = displaySize
 	ctx.DeltaTime = deltaTime
 	// Note: FrameCount is incremented in GUI.PrepareInputHandling() at the START
@@ -214,9 +344,10 @@ func (ctx *Context) Reset(displaySize Vec2, deltaTime float32) {
 	// Reset input capture flags - widgets will set these during the frame
 	ctx.WantCaptureMouse = false
 	ctx.WantCaptureKeyboard = false
+	ctx.tabConsumed = false
 
 	// Clear text measurement cache (valid only for current frame)
-	clear(ctx.textMeasureCache)
+	ctx.InvalidateTextCache()
 
 	// Clear scroll focus (widgets will set it fresh each frame)
 	ctx.scrollFocusSet = false
@@ -236,17 +367,152 @@ func (ctx *Context) Reset(displaySize Vec2, deltaTime float32) {
 	if ctx.activePopupID != 0 {
 		guiLogger.Debug("Reset: clearing activePopupID", "id", ctx.activePopupID)
 	}
+	ctx.beginEscape()
+	ctx.restorePopupOpeners()
 	ctx.activePopupID = 0
 }
 
+// popupOpener remembers which widget had focus when a popup opened.
+type popupOpener struct {
+	popup  ID
+	opener ID
+}
+
+// restorePopupOpeners pops popups that were not claimed last frame and
+// returns registry focus to the widget that had it when each one opened.
+func (ctx *Context) restorePopupOpeners() {
+	for n := len(ctx.popupOpeners); n > 0; n = len(ctx.popupOpeners) {
+		top := ctx.popupOpeners[n-1]
+		if top.popup == ctx.activePopupID {
+			return
+		}
+		ctx.popupOpeners = ctx.popupOpeners[:n-1]
+		if top.opener != 0 && ctx.focusRegistry != nil {
+			ctx.focusRegistry.SetFocus(top.opener)
+		}
+	}
+}
+
+// pushClipRect pushes a clip rect intersected with the current one.
+// The result is applied to the DrawList and used for hover hit-testing,
+// so nested clipping containers compose correctly.
+func (ctx *Context) pushClipRect(rect Rect) {
+	if clip, ok := ctx.currentClipRect(); ok {
+		rect = rect.Intersect(clip)
+	}
+	ctx.clipStack = append(ctx.clipStack, rect)
+	ctx.DrawList.PushClipRect(rect.X, rect.Y, rect.X+rect.W, rect.Y+rect.H)
+}
+
+// popClipRect pops the clip rect pushed by pushClipRect.
+func (ctx *Context) popClipRect() {
+	if n := len(ctx.clipStack); n > 0 {
+		ctx.clipStack = ctx.clipStack[:n-1]
+		ctx.DrawList.PopClipRect()
+	}
+}
+
+// currentClipRect returns the active clip rect, if any.
+func (ctx *Context) currentClipRect() (Rect, bool) {
+	if n := len(ctx.clipStack); n > 0 {
+		return ctx.clipStack[n-1], true
+	}
+	return Rect{}, false
+}
+
 // Helper methods for widget interaction
 
 // isHovered returns true if the widget area is under the mouse cursor.
+// Widgets outside the current clip rect, or covered by an open popup or a
+// panel drawn above them, never report hover.
 func (ctx *Context) isHovered(id ID, rect Rect) bool {
 	if ctx.Input == nil {
 		return false
 	}
-	return rect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY})
+	mouse := Vec2{ctx.Input.MouseX, ctx.Input.MouseY}
+	if !rect.Contains(mouse) {
+		return false
+	}
+	if ctx.inPopup {
+		return true
+	}
+	if clip, ok := ctx.currentClipRect(); ok && !clip.Contains(mouse) {
+		return false
+	}
+	return !ctx.isOccluded(id, mouse)
+}
+
+// popupRect is an open popup's bounds and the widget that owns it.
+type popupRect struct {
+	owner ID
+	rect  Rect
+}
+
+// registerPopupRect records an open popup's bounds for hover occlusion.
+// Call every frame the popup is open; the owner still receives hover.
+func (ctx *Context) registerPopupRect(owner ID, rect Rect) {
+	ctx.popupRects = append(ctx.popupRects, popupRect{owner: owner, rect: rect})
+}
+
+// isOccluded reports whether the mouse is over an open popup, or a panel
+// drawn after the one containing the current widget.
+func (ctx *Context) isOccluded(id ID, mouse Vec2) bool {
+	for _, p := range ctx.prevPopupRects {
+		if p.owner != id && p.rect.Contains(mouse) {
+			return true
+		}
+	}
+	if n := len(ctx.panelStack); n > 0 {
+		for i := ctx.panelStack[n-1] + 1; i < len(ctx.prevPanelRects); i++ {
+			if ctx.prevPanelRects[i].Contains(mouse) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PushInputRegion marks a screen area as owned by the GUI, so MouseOverGUI
+// reports true for points inside it. Use it for GUI drawn outside a Panel.
+// Regions nest: a region pushed inside another is clipped to its parent.
+// Panels and open popups register themselves automatically.
+func (ctx *Context) PushInputRegion(rect Rect) {
+	if n := len(ctx.inputRegionStack); n > 0 {
+		rect = rect.Intersect(ctx.inputRegionStack[n-1])
+	}
+	ctx.inputRegionStack = append(ctx.inputRegionStack, rect)
+	ctx.inputRegions = append(ctx.inputRegions, rect)
+}
+
+// PopInputRegion ends the region started by PushInputRegion.
+func (ctx *Context) PopInputRegion() {
+	if n := len(ctx.inputRegionStack); n > 0 {
+		ctx.inputRegionStack = ctx.inputRegionStack[:n-1]
+	}
+}
+
+// MouseOverGUI reports whether pos lies over any panel, open popup or input
+// region drawn this frame. Unlike WantCaptureMouse this answers for a specific
+// point, so a 3D viewport beside the GUI can take mouse input whenever the
+// cursor isn't over GUI. Query after drawing (e.g. after GUI.End) for the
+// complete frame; the answer stays valid until the next Begin.
+func (ctx *Context) MouseOverGUI(pos Vec2) bool {
+	for _, p := range ctx.popupRects {
+		if p.rect.Contains(pos) {
+			return true
+		}
+	}
+	for _, r := range ctx.panelRects {
+		if r.Contains(pos) {
+			return true
+		}
+	}
+	for _, r := range ctx.inputRegions {
+		if r.Contains(pos) {
+			return true
+		}
+	}
+	return false
 }
 
 // IsHovered returns true if the widget area is under the mouse cursor (public API).
@@ -293,6 +559,145 @@ func (ctx *Context) isPressed(id ID, rect Rect) bool {
 	return ctx.isHovered(id, rect) && ctx.Input.MouseDown(MouseButtonLeft)
 }
 
+// buttonBehavior implements standard button semantics for the widget.
+// The widget becomes active when pressed while hovered and fires when the
+// mouse is released over it; moving off before release cancels the click.
+// With activateOnPress the click fires on the press frame instead. A
+// disabled widget reports hover only: it never becomes active, so a press
+// on it can't hold or click it, or steal a press from another widget.
+// Returns hovered, held (pressed and still over the widget) and clicked.
+func (ctx *Context) buttonBehavior(id ID, rect Rect, activateOnPress, disabled bool) (hovered, held, clicked bool) {
+	if ctx.Input == nil {
+		return false, false, false
+	}
+	hovered = ctx.isHovered(id, rect)
+	if disabled {
+		return hovered, false, false
+	}
+
+	if activateOnPress {
+		return hovered, hovered && ctx.Input.MouseDown(MouseButtonLeft), ctx.isClicked(id, rect)
+	}
+
+	if hovered && ctx.Input.MouseClicked(MouseButtonLeft) {
+		ctx.activeID = id
+	}
+	if ctx.activeID != id {
+		return hovered, false, false
+	}
+
+	switch {
+	case ctx.Input.MouseReleased(MouseButtonLeft):
+		clicked = hovered
+		ctx.activeID = 0
+	case !ctx.Input.MouseDown(MouseButtonLeft):
+		// Release happened while the widget wasn't drawn - drop the stale press
+		ctx.activeID = 0
+	default:
+		held = hovered
+	}
+	return hovered, held, clicked
+}
+
+// keyActivated reports whether Enter or Space activates id this frame: the
+// keyboard counterpart of a click. Only the controls that opt in use it (the
+// Alert's link and dismiss button, CodeBlock's Copy button); Button does not,
+// since Space is often a game key.
+func (ctx *Context) keyActivated(id ID) bool {
+	in := ctx.Input
+	return in != nil && ctx.IsRegistryFocused(id) && (in.KeyPressed(KeyEnter) || in.KeyPressed(KeySpace))
+}
+
+// dragThreshold returns the style's drag threshold, falling back to 3px.
+func (ctx *Context) dragThreshold() float32 {
+	if ctx.style.DragThreshold > 0 {
+		return ctx.style.DragThreshold
+	}
+	return 3
+}
+
+// IsMouseDragPastThreshold returns true once the mouse has moved further than
+// Style.DragThreshold from where the button was pressed. It stays true until
+// the button is released (including the release frame), so widgets can tell
+// a click from a drag when the button comes up. While the button is held it
+// agrees with IsMouseDragging(button, 0).
+func (ctx *Context) IsMouseDragPastThreshold(button MouseButton) bool {
+	return ctx.mouseDraggedPast(button, 0)
+}
+
+// IsMouseDragging returns true while button is held and the mouse has
+// moved further than threshold pixels from where it was pressed; a
+// threshold <= 0 uses Style.DragThreshold. Unlike IsMouseDragPastThreshold
+// it is false on the release frame.
+func (ctx *Context) IsMouseDragging(button MouseButton, threshold float32) bool {
+	if ctx.Input == nil || !ctx.Input.MouseDown(button) {
+		return false
+	}
+	return ctx.mouseDraggedPast(button, threshold)
+}
+
+// mouseDraggedPast reports whether the current press of button has moved
+// further than threshold (Style.DragThreshold when <= 0). It is the single
+// definition of "dragging" behind IsMouseDragging and IsMouseDragPastThreshold.
+func (ctx *Context) mouseDraggedPast(button MouseButton, threshold float32) bool {
+	if ctx.Input == nil {
+		return false
+	}
+	if threshold <= 0 {
+		threshold = ctx.dragThreshold()
+	}
+	return ctx.Input.MouseDragDistance(button) > threshold
+}
+
+// MouseDragDelta returns the mouse offset from where the button was pressed.
+// Returns zero when the button isn't held (or released this frame).
+func (ctx *Context) MouseDragDelta(button MouseButton) Vec2 {
+	if ctx.Input == nil {
+		return Vec2{}
+	}
+	return ctx.Input.MouseDragDelta(button)
+}
+
+// wheelPixelThreshold is the delta magnitude above which a wheel event is
+// assumed to already be in pixels (some platforms report trackpads this way).
+const wheelPixelThreshold = 10
+
+// wheelScroll converts a raw wheel delta into a scroll distance in pixels.
+//
+// Notched wheels report whole steps (±1, ±2) which are scaled by
+// Style.ScrollSpeed. High-resolution devices such as trackpads report
+// fractional deltas at a high rate; those are scaled the same way but
+// reported as precise so callers apply them immediately instead of easing
+// towards a target, which would lurch. Deltas larger than
+// wheelPixelThreshold are taken as pixels as-is.
+func (ctx *Context) wheelScroll(delta float32) (pixels float32, precise bool) {
+	if delta == 0 {
+		return 0, false
+	}
+	if absf(delta) > wheelPixelThreshold {
+		return delta, true
+	}
+	speed := ctx.style.ScrollSpeed
+	if speed <= 0 {
+		speed = 30
+	}
+	precise = delta != float32(int(delta))
+	return delta * speed, precise
+}
+
+// wheelScrollLines is wheelScroll for widgets scrolling by whole lines: each
+// notch moves lines*lineHeight pixels instead of Style.ScrollSpeed (lines <= 0
+// falls back to wheelScroll). Fractional deltas scroll a fraction of a line.
+func (ctx *Context) wheelScrollLines(delta float32, lines int, lineHeight float32) (pixels float32, precise bool) {
+	if lines <= 0 || delta == 0 {
+		return ctx.wheelScroll(delta)
+	}
+	if absf(delta) > wheelPixelThreshold {
+		return delta, true
+	}
+	return delta * float32(lines) * lineHeight, delta != float32(int(delta))
+}
+
 // SetFocused sets the focused widget.
 func (ctx *Context) SetFocused(id ID) {
 	ctx.focusedID = id
@@ -315,13 +720,43 @@ func (ctx *Context) HasWidgetFocus() bool {
 }
 
 // SetActivePopup marks a popup (dropdown, menu) as open.
-// While a popup is active, focus navigation should stay within it.
-// Call with id=0 to close the popup.
+// Call it every frame the popup is open, and with id=0 to close it.
+//
+// While a popup is active, NavigateFocus and NavigateTab only move between
+// items registered inside a focus group with the same ID
+// (BeginFocusGroup(id, ...)). When nested popups are open the innermost one
+// (the one claimed inside the deepest focus scope) traps focus. When a popup
+// stops being claimed, registry focus returns to the widget that had it when
+// the popup opened.
 func (ctx *Context) SetActivePopup(id ID) {
-	ctx.activePopupID = id
-	if id != 0 {
+	if id == 0 {
+		ctx.activePopupID = 0
+		return
+	}
+
+	depth := 0
+	if ctx.focusRegistry != nil {
+		depth = len(ctx.focusRegistry.scopeStack)
+	}
+	if ctx.activePopupID != 0 && ctx.activePopupID != id && depth < ctx.activePopupDepth {
+		// A nested popup already claimed focus this frame
 		ctx.WantCaptureKeyboard = true
+		return
+	}
+	ctx.activePopupID = id
+	ctx.activePopupDepth = depth
+	ctx.WantCaptureKeyboard = true
+
+	for _, p := range ctx.popupOpeners {
+		if p.popup == id {
+			return
+		}
 	}
+	opener := ID(0)
+	if ctx.focusRegistry != nil {
+		opener = ctx.focusRegistry.CurrentFocusID()
+	}
+	ctx.popupOpeners = append(ctx.popupOpeners, popupOpener{popup: id, opener: opener})
 }
 
 // HasActivePopup returns true if a popup is currently open.
@@ -360,17 +795,21 @@ func (ctx *Context) LineHeight() float32 {
 
 // MeasureText returns the size of rendered text.
 // Uses the font provider if available, otherwise falls back to monospace calculation.
+// Tabs advance to the next multiple of Style.TabWidth.
 // Results are cached per-frame to avoid redundant measurements.
 func (ctx *Context) MeasureText(text string) Vec2 {
-	// Check cache first (includes scale in key for differentiation)
+	// Check cache first (cleared whenever the font changes)
+	key := textMeasureKey{text: text, scale: ctx.style.FontScale}
 	if ctx.textMeasureCache != nil {
-		if cached, ok := ctx.textMeasureCache[text]; ok {
+		if cached, ok := ctx.textMeasureCache[key]; ok {
 			return cached
 		}
 	}
 
 	var result Vec2
-	if f := ctx.activeFont(); f != nil {
+	if ctx.hasTabStops(text) {
+		result = Vec2{X: ctx.forEachTabSegment(text, nil), Y: ctx.lineHeight()}
+	} else if f := ctx.activeFont(); f != nil {
 		size := f.MeasureText(text, ctx.style.FontScale)
 		result = Vec2{X: size.X, Y: size.Y}
 	} else {
@@ -382,12 +821,44 @@ func (ctx *Context) MeasureText(text string) Vec2 {
 
 	// Cache the result
 	if ctx.textMeasureCache != nil {
-		ctx.textMeasureCache[text] = result
+		ctx.textMeasureCache[key] = result
 	}
 
 	return result
 }
 
+// hasTabStops reports whether text contains tabs that should be expanded.
+func (ctx *Context) hasTabStops(text string) bool {
+	return ctx.style.TabWidth > 0 && strings.IndexByte(text, '\t') >= 0
+}
+
+// forEachTabSegment splits text at tabs and calls fn with each segment and its
+// x offset from the start of the text. Each tab advances to the next multiple
+// of Style.TabWidth. Returns the total width. MeasureText and AddText both go
+// through here so measurement and drawing always agree.
+func (ctx *Context) forEachTabSegment(text string, fn func(seg string, offset float32)) float32 {
+	tab := ctx.style.TabWidth
+	var x float32
+	for {
+		i := strings.IndexByte(text, '\t')
+		seg := text
+		if i >= 0 {
+			seg = text[:i]
+		}
+		if seg != "" {
+			if fn != nil {
+				fn(seg, x)
+			}
+			x += ctx.MeasureText(seg).X
+		}
+		if i < 0 {
+			return x
+		}
+		x = (float32(int(x/tab)) + 1) * tab
+		text = text[i+1:]
+	}
+}
+
 // activeFont returns the current active font, or nil if no font provider is set.
 // This is a helper to reduce repetitive null checks.
 func (ctx *Context) activeFont() Font {
@@ -402,6 +873,7 @@ func (ctx *Context) activeFont() Font {
 // Pass nil to disable font provider and use built-in monospace font.
 func (ctx *Context) SetFontProvider(fp FontProvider) {
 	ctx.fontProvider = fp
+	ctx.InvalidateTextCache()
 }
 
 // SetPanelRegistry associates a panel registry with this context.
@@ -568,6 +1040,7 @@ func (ctx *Context) SetFont(name string) error {
 	if ctx.fontProvider == nil {
 		return nil
 	}
+	defer ctx.InvalidateTextCache()
 	return ctx.fontProvider.SetActiveFont(name)
 }
 
@@ -585,6 +1058,35 @@ func (ctx *Context) CurrentLayoutWidth() float32 {
 	return ctx.currentLayoutWidth()
 }
 
+// ContentRegionAvail returns the space left in the current layout from the
+// cursor to the layout's right and bottom edges (the display edges outside
+// any layout). Widgets call it after ItemPos, so the gap before the item is
+// already taken out.
+func (ctx *Context) ContentRegionAvail() Vec2 {
+	var start Vec2
+	if layout := ctx.currentLayout(); layout != nil {
+		start = Vec2{X: layout.StartX, Y: layout.StartY}
+	}
+	return Vec2{
+		X: maxf(0, start.X+ctx.currentLayoutWidth()-ctx.cursor.X),
+		Y: maxf(0, start.Y+ctx.currentLayoutHeight()-ctx.cursor.Y),
+	}
+}
+
+// itemWidth returns the width of a widget's box: WithWidth when given,
+// otherwise the rest of the column when the widget is a LabeledRow control,
+// otherwise def. used is the width the widget already takes before its box
+// (its own label). Call after ItemPos.
+func (ctx *Context) itemWidth(o options, def, used float32) float32 {
+	if w := GetOpt(o, OptWidth); w > 0 {
+		return w
+	}
+	if ctx.fillLayout != nil && ctx.currentLayout() == ctx.fillLayout {
+		return maxf(1, ctx.ContentRegionAvail().X-used)
+	}
+	return def
+}
+
 // currentLayoutHeight returns the available height in the current layout.
 func (ctx *Context) currentLayoutHeight() float32 {
 	if len(ctx.layoutStack) > 0 {
@@ -615,44 +1117,69 @@ func (ctx *Context) addTextTo(dl *DrawList, x, y float32, text string, color uin
 }
 
 // AddTextTo draws text to a specific DrawList (public API).
-// This is useful for drawing to foreground/overlay layers.
+// This is useful for drawing to foreground/overlay layers. With
+// Style.TextShadow the text gets a drop shadow, like AddTextShadowed.
 func (ctx *Context) AddTextTo(dl *DrawList, x, y float32, text string, color uint32) {
-	if dl == nil {
+	if ctx.style.TextShadow {
+		ctx.drawText(dl, x, y, text, color, ctx.textShadowColor(), ctx.textShadowOffset())
 		return
 	}
-	if f := ctx.activeFont(); f != nil {
-		dl.SetTexture(f.TextureID())
-		fontQuads := f.GetGlyphQuads(text, x, y, ctx.style.FontScale)
+	ctx.drawText(dl, x, y, text, color, 0, Vec2{})
+}
 
-		if cap(ctx.glyphBuffer) < len(fontQuads) {
-			ctx.glyphBuffer = make([]GlyphQuad, 0, len(fontQuads)*2)
-		}
-		ctx.glyphBuffer = ctx.glyphBuffer[:len(fontQuads)]
+// AddText draws text with current style (public API).
+// Uses the font provider if available, otherwise falls back to built-in monospace font.
+func (ctx *Context) AddText(x, y float32, text string, color uint32) {
+	ctx.AddTextTo(ctx.DrawList, x, y, text, color)
+}
 
-		for i, q := range fontQuads {
-			ctx.glyphBuffer[i] = GlyphQuad{
-				X0: q.X0, Y0: q.Y0,
-				X1: q.X1, Y1: q.Y1,
-				U0: q.U0, V0: q.V0,
-				U1: q.U1, V1: q.V1,
-			}
-		}
-		dl.AddGlyphQuads(ctx.glyphBuffer, color)
-		dl.SetTexture(0)
-		return
+// AddTextShadowed draws text over a copy of it in the shadow color, moved
+// by offset, so it stays readable over busy backgrounds such as the game
+// scene. It works whether or not Style.TextShadow is set.
+//
+// Usage:
+//
+//	ctx.AddTextShadowed(x, y, "WASTED", gui.ColorRed, gui.ColorBlack, gui.Vec2{X: 2, Y: 2})
+func (ctx *Context) AddTextShadowed(x, y float32, text string, color, shadow uint32, offset Vec2) {
+	ctx.drawText(ctx.DrawList, x, y, text, color, shadow, offset)
+}
+
+// textShadowColor returns Style.TextShadowColor, defaulting to
+// defaultTextShadowColor.
+func (ctx *Context) textShadowColor() uint32 {
+	if ctx.style.TextShadowColor != 0 {
+		return ctx.style.TextShadowColor
 	}
+	return defaultTextShadowColor
+}
 
-	// Fallback to built-in monospace font (legacy renderer)
-	dl.SetTexture(ctx.FontTextureID)
-	dl.AddText(x, y, text, color, ctx.style.FontScale, ctx.style.CharWidth, ctx.style.CharHeight)
-	dl.SetTexture(0)
+// textShadowOffset returns Style.TextShadowOffset, defaulting to one pixel
+// down and right.
+func (ctx *Context) textShadowOffset() Vec2 {
+	if ctx.style.TextShadowOffset != (Vec2{}) {
+		return ctx.style.TextShadowOffset
+	}
+	return Vec2{X: 1, Y: 1}
 }
 
-// AddText draws text with current style (public API).
-// Uses the font provider if available, otherwise falls back to built-in monospace font.
-func (ctx *Context) AddText(x, y float32, text string, color uint32) {
+// drawText draws text to dl, first in the shadow color at offset unless
+// shadow is 0. The glyph quads are computed once for both.
+// Performance: reuses pre-allocated glyph buffer to avoid allocations in hot paths.
+func (ctx *Context) drawText(dl *DrawList, x, y float32, text string, color, shadow uint32, offset Vec2) {
+	if dl == nil {
+		return
+	}
+	if color&0xFF000000 == 0 {
+		shadow = 0 // No shadow for invisible text
+	}
+	if ctx.hasTabStops(text) {
+		ctx.forEachTabSegment(text, func(seg string, segX float32) {
+			ctx.drawText(dl, x+segX, y, seg, color, shadow, offset)
+		})
+		return
+	}
 	if f := ctx.activeFont(); f != nil {
-		ctx.DrawList.SetTexture(f.TextureID())
+		dl.SetTexture(f.TextureID())
 		// Get glyph quads from font and convert to GUI format
 		fontQuads := f.GetGlyphQuads(text, x, y, ctx.style.FontScale)
 
@@ -663,6 +1190,17 @@ func (ctx *Context) AddText(x, y float32, text string, color uint32) {
 		}
 		ctx.glyphBuffer = ctx.glyphBuffer[:len(fontQuads)]
 
+		if shadow != 0 {
+			for i, q := range fontQuads {
+				ctx.glyphBuffer[i] = GlyphQuad{
+					X0: q.X0 + offset.X, Y0: q.Y0 + offset.Y,
+					X1: q.X1 + offset.X, Y1: q.Y1 + offset.Y,
+					U0: q.U0, V0: q.V0,
+					U1: q.U1, V1: q.V1,
+				}
+			}
+			dl.AddGlyphQuads(ctx.glyphBuffer, shadow)
+		}
 		for i, q := range fontQuads {
 			ctx.glyphBuffer[i] = GlyphQuad{
 				X0: q.X0, Y0: q.Y0,
@@ -671,15 +1209,18 @@ func (ctx *Context) AddText(x, y float32, text string, color uint32) {
 				U1: q.U1, V1: q.V1,
 			}
 		}
-		ctx.DrawList.AddGlyphQuads(ctx.glyphBuffer, color)
-		ctx.DrawList.SetTexture(0)
+		dl.AddGlyphQuads(ctx.glyphBuffer, color)
+		dl.SetTexture(0)
 		return
 	}
 
 	// Fallback to built-in monospace font (legacy renderer)
-	ctx.DrawList.SetTexture(ctx.FontTextureID)
-	ctx.DrawList.AddText(x, y, text, color, ctx.style.FontScale, ctx.style.CharWidth, ctx.style.CharHeight)
-	ctx.DrawList.SetTexture(0)
+	dl.SetTexture(ctx.FontTextureID)
+	if shadow != 0 {
+		dl.AddText(x+offset.X, y+offset.Y, text, shadow, ctx.style.FontScale, ctx.style.CharWidth, ctx.style.CharHeight)
+	}
+	dl.AddText(x, y, text, color, ctx.style.FontScale, ctx.style.CharWidth, ctx.style.CharHeight)
+	dl.SetTexture(0)
 }
 
 // beginItem applies gap spacing before drawing an item.
@@ -712,6 +1253,9 @@ func (ctx *Context) beginItem() {
 			ctx.cursor.X += gap
 		}
 	}
+	if layout.Wrap && layout.Type == LayoutHorizontal {
+		ctx.wrapItem(layout)
+	}
 }
 
 // ItemPos returns the position for the next widget with gap applied.
@@ -728,7 +1272,10 @@ func (ctx *Context) advanceCursor(size Vec2) {
 }
 
 // AdvanceCursor moves the cursor after drawing an item (public API).
+// The item is taken to start at the cursor and becomes the last item.
 func (ctx *Context) AdvanceCursor(size Vec2) {
+	ctx.lastItemRect = Rect{X: ctx.cursor.X, Y: ctx.cursor.Y, W: size.X, H: size.Y}
+
 	layout := ctx.currentLayout()
 	if layout == nil {
 		// No layout, just advance vertically
@@ -741,6 +1288,8 @@ func (ctx *Context) AdvanceCursor(size Vec2) {
 		ctx.cursor.Y += size.Y
 		layout.MaxWidth = maxf(layout.MaxWidth, size.X)
 		layout.MaxHeight = ctx.cursor.Y - layout.StartY
+	} else if layout.Wrap {
+		ctx.wrapAdvance(layout, size)
 	} else {
 		ctx.cursor.X += size.X
 		layout.MaxWidth = ctx.cursor.X - layout.StartX
@@ -750,6 +1299,31 @@ func (ctx *Context) AdvanceCursor(size Vec2) {
 	layout.ItemCount++
 }
 
+// ItemRect returns the bounds of the last item: the widget drawn just
+// before the call.
+func (ctx *Context) ItemRect() Rect {
+	return ctx.lastItemRect
+}
+
+// IsItemHovered reports whether the mouse is over the last item and not
+// covered by a popup or panel.
+//
+// Usage:
+//
+//	ctx.Button("X")
+//	if ctx.IsItemHovered() {
+//	    ctx.Tooltip("help")
+//	}
+func (ctx *Context) IsItemHovered() bool {
+	return ctx.isHovered(0, ctx.lastItemRect)
+}
+
+// IsItemClicked reports whether the last item was clicked with the left
+// mouse button this frame.
+func (ctx *Context) IsItemClicked() bool {
+	return ctx.IsItemHovered() && ctx.Input.MouseClicked(MouseButtonLeft)
+}
+
 // =============================================================================
 // Focus Hierarchy Methods
 // =============================================================================
@@ -973,7 +1547,7 @@ func (ctx *Context) RegisterFocusable(id ID, name string, rect Rect, typ FocusTy
 	isVisible := ctx.IsInsideScrollableViewport(rect.Y, rect.H)
 	if handle != nil && handle.CanFocus() && ctx.Input != nil && ctx.Input.MouseClicked(MouseButtonLeft) {
 		mouse := Vec2{ctx.Input.MouseX, ctx.Input.MouseY}
-		inRect := rect.Contains(mouse)
+		inRect := ctx.isHovered(id, rect)
 		if inRect && isVisible {
 			guiLogger.Debug("click-to-focus triggered",
 				"id", id,
@@ -1066,16 +1640,39 @@ func (ctx *Context) NavigateFocus(dir NavDirection) bool {
 		guiLogger.Debug("NavigateFocus: registry is nil")
 		return false
 	}
-	// Skip navigation if a popup is active (navigation stays within popup)
+	// While a popup is active, navigation stays within the popup's focus group
+	// (see BeginFocusGroup). Popups that register no group handle their own keys.
 	if ctx.activePopupID != 0 {
-		guiLogger.Debug("NavigateFocus: blocked by activePopupID", "popupID", ctx.activePopupID)
-		return false
+		result := ctx.focusRegistry.NavigateWithin(ctx.activePopupID, dir)
+		guiLogger.Debug("NavigateFocus: scoped to popup", "popupID", ctx.activePopupID, "success", result)
+		return result
 	}
 	result := ctx.focusRegistry.Navigate(dir)
 	guiLogger.Debug("NavigateFocus", "dir", dir, "success", result)
 	return result
 }
 
+// NavigateTab moves focus to the next (or previous) focusable widget in
+// registration order, wrapping around at the ends. While a popup is active
+// the cycle is confined to the popup's focus group, as with NavigateFocus.
+// It does nothing when a widget drawn this frame used the Tab press (e.g.
+// to accept a completion), so call it after drawing the widgets.
+//
+// Usage (in panel HandleInput):
+//
+//	if input.KeyPressed(KeyTab) {
+//	    ctx.NavigateTab(!input.ModShift)
+//	}
+func (ctx *Context) NavigateTab(forward bool) bool {
+	if ctx.focusRegistry == nil || ctx.tabConsumed {
+		return false
+	}
+	if ctx.activePopupID != 0 {
+		return ctx.focusRegistry.NavigateTabWithin(ctx.activePopupID, forward)
+	}
+	return ctx.focusRegistry.NavigateTab(forward)
+}
+
 // SetRegistryFocus sets focus to the widget with the given ID.
 // This updates the focus registry, which is separate from the simple focusedID.
 func (ctx *Context) SetRegistryFocus(id ID) {
@@ -0,0 +1,120 @@
+package gui
+
+import "math"
+
+// damagePrim summarizes one triangle of a frame for damage tracking:
+// its clipped screen bounds and a hash of everything that affects its
+// pixels (vertices, texture, clip rect).
+type damagePrim struct {
+	bounds [4]float32 // x1, y1, x2, y2
+	hash   uint64
+}
+
+// damageTracker compares a DrawList against the previous frame's.
+// The GUI keeps one per draw list, since DrawLists come from a pool.
+type damageTracker struct {
+	prev, cur []damagePrim
+	size      Vec2 // Display size of prev
+	valid     bool // prev holds a frame
+}
+
+// update records dl as the latest frame and returns the screen area that
+// differs from the previous one. The area is conservative: every pixel
+// whose final color may have changed lies inside it. Triangles are
+// compared in draw order; everything between the first and last
+// difference counts as changed, so overlapping draws never go stale.
+func (t *damageTracker) update(dl *DrawList, display Vec2) (Rect, bool) {
+	t.cur = dl.appendDamagePrims(t.cur[:0])
+	defer func() {
+		t.prev, t.cur = t.cur, t.prev
+		t.size = display
+		t.valid = true
+	}()
+
+	full := Rect{W: display.X, H: display.Y}
+	if !t.valid || display != t.size {
+		return full, true
+	}
+
+	cur, prev := t.cur, t.prev
+	p := 0
+	for p < len(cur) && p < len(prev) && cur[p] == prev[p] {
+		p++
+	}
+	s := 0
+	for s < len(cur)-p && s < len(prev)-p && cur[len(cur)-1-s] == prev[len(prev)-1-s] {
+		s++
+	}
+
+	box := [4]float32{math.MaxFloat32, math.MaxFloat32, -math.MaxFloat32, -math.MaxFloat32}
+	extend := func(prims []damagePrim) {
+		for _, prim := range prims {
+			b := prim.bounds
+			if b[0] >= b[2] || b[1] >= b[3] {
+				continue // Fully clipped: draws nothing
+			}
+			box[0], box[1] = minf(box[0], b[0]), minf(box[1], b[1])
+			box[2], box[3] = maxf(box[2], b[2]), maxf(box[3], b[3])
+		}
+	}
+	extend(cur[p : len(cur)-s])
+	extend(prev[p : len(prev)-s])
+
+	// Round outward to whole pixels and keep it on screen
+	x1 := maxf(0, float32(math.Floor(float64(box[0]))))
+	y1 := maxf(0, float32(math.Floor(float64(box[1]))))
+	x2 := minf(display.X, float32(math.Ceil(float64(box[2]))))
+	y2 := minf(display.Y, float32(math.Ceil(float64(box[3]))))
+	if x1 >= x2 || y1 >= y2 {
+		return Rect{}, false
+	}
+	return Rect{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}, true
+}
+
+// appendDamagePrims appends a damagePrim for every triangle in dl, in draw
+// order. Works whether or not the list has been finalized.
+func (dl *DrawList) appendDamagePrims(dst []damagePrim) []damagePrim {
+	for i, cmd := range dl.CmdBuffer {
+		end := uint32(len(dl.IdxBuffer))
+		if i+1 < len(dl.CmdBuffer) {
+			end = dl.CmdBuffer[i+1].IndexOffset
+		}
+		clip := cmd.ClipRect
+
+		// The command's texture and clip rect affect every triangle in it
+		base := uint64(14695981039346656037) // FNV-1a offset basis
+		base = hashWord(base, cmd.TextureID)
+		for _, c := range clip {
+			base = hashWord(base, math.Float32bits(c))
+		}
+
+		for j := cmd.IndexOffset; j+2 < end; j += 3 {
+			h := base
+			box := [4]float32{math.MaxFloat32, math.MaxFloat32, -math.MaxFloat32, -math.MaxFloat32}
+			for _, idx := range dl.IdxBuffer[j : j+3] {
+				v := dl.VtxBuffer[cmd.VertexOffset+uint32(idx)]
+				h = hashWord(h, math.Float32bits(v.Pos[0]))
+				h = hashWord(h, math.Float32bits(v.Pos[1]))
+				h = hashWord(h, math.Float32bits(v.TexCoord[0]))
+				h = hashWord(h, math.Float32bits(v.TexCoord[1]))
+				h = hashWord(h, v.Color)
+				box[0], box[1] = minf(box[0], v.Pos[0]), minf(box[1], v.Pos[1])
+				box[2], box[3] = maxf(box[2], v.Pos[0]), maxf(box[3], v.Pos[1])
+			}
+			box[0], box[1] = maxf(box[0], clip[0]), maxf(box[1], clip[1])
+			box[2], box[3] = minf(box[2], clip[2]), minf(box[3], clip[3])
+			dst = append(dst, damagePrim{bounds: box, hash: h})
+		}
+	}
+	return dst
+}
+
+// hashWord mixes a 32-bit word into an FNV-1a hash.
+func hashWord(h uint64, w uint32) uint64 {
+	for range 4 {
+		h ^= uint64(w & 0xFF)
+		h *= 1099511628211 // FNV-1a prime
+		w >>= 8
+	}
+	return h
+}
@@ -55,6 +55,9 @@ Selection:
 	Shift+Home       Select from cursor to start
 	Shift+End        Select from cursor to end
 	Ctrl+A           Select all text
+	Click+Drag       Select characters from the click
+	Double-click     Select the word under the mouse (drag to extend by word)
+	Triple-click     Select all text
 
 Clipboard Operations:
 
@@ -75,9 +78,20 @@ Control:
 	Backspace        Delete character before cursor (or delete selection)
 	Delete           Delete character after cursor (or delete selection)
 
+## InputTextMultiline Shortcuts
+
+The InputText shortcuts apply, except:
+
+	Up/Down          Move to the previous/next visual line
+	Home/End         Jump to start/end of the visual line
+	Ctrl+Home/End    Jump to start/end of text
+	Enter            Insert a newline
+	Ctrl+Enter       Confirm input and unfocus
+
 ## Scrollable Areas (ListBox, Scrollable, List)
 
-	Mouse Wheel      Scroll vertically
+	Mouse Wheel      Scroll vertically (Style.ScrollSpeed px per notch,
+	                 or n lines with WithScrollLines/ScrollLines; Table: 3 rows)
 	Shift+Wheel      Scroll horizontally (when enabled)
 	Page Up          Scroll up by 80% of viewport height
 	Page Down        Scroll down by 80% of viewport height
@@ -87,7 +101,10 @@ Control:
 ## ComboBox Widget
 
 	Click            Open/close dropdown menu
-	Escape           Close dropdown menu
+	Enter/Space      Open dropdown menu (when focused)
+	Up/Down          Move the highlighted item (within the filtered items)
+	Enter            Select the highlighted item and close
+	Escape           Close dropdown menu without changing the selection
 	Mouse Wheel      Scroll dropdown items
 	Type characters  Filter items (when WithSearchable() is set)
 	Backspace        Delete filter character
@@ -96,15 +113,21 @@ Control:
 
 	Click+Drag       Adjust value by dragging
 	Mouse Wheel      Increment/decrement value (when hovered)
+	Double-Click     Type an exact value (with WithTypeable; Enter/Escape)
+	Left/Right       Decrement/increment value (when focused)
+	Down/Up          Decrement/increment value (WithVertical, when focused)
+	Left/Right       Move the last dragged handle (SliderFloatRange, when focused)
 
 ## NumberInput Widgets (NumberInputFloat, NumberInputInt)
 
 	Click+Drag       Adjust value by dragging left/right
-	Click (release)  Enter text edit mode (if drag distance < 3px)
+	Click (release)  Enter text edit mode (if drag stays within Style.DragThreshold)
 	Enter            Confirm text edit
 	Escape           Cancel text edit
 	0-9, ., -        Input digits/decimal/negative
 	Backspace        Delete digit
+	Up/Right, Down/Left  Step value by WithStep (focused, not editing)
+	Mouse wheel      Step value by WithStep (hovered, not editing)
 
 ## Collapsing Headers / Tree Nodes
 
@@ -114,6 +137,19 @@ Control:
 
 	Ctrl+Tab         Cycle to next panel
 	Ctrl+Shift+Tab   Cycle to previous panel
+	Click            Bring panel to front (BoundedPanel, e.g. DraggablePanel)
+
+## Escape
+
+Each press of Escape is handled by exactly one element, the topmost:
+
+ 1. A text field being edited stops editing (InputText, NumberInput,
+    typeable slider, EditableLabel, List filter)
+ 2. Otherwise the active popup closes (ComboBox, Table column menu)
+ 3. Otherwise an open ModalMenu or PanelGroup closes
+ 4. Otherwise ctx.EscapeUnhandled is set after End for the application
+
+Widgets drawn with WithNoEscape() ignore Escape and let it fall through.
 
 # Complete Component List
 
@@ -123,7 +159,8 @@ components use the "component_*" naming prefix.
 ## Text Components
 
 	ctx.Text(text string)
-	    Draws basic text at current cursor position.
+	    Draws basic text at current cursor position. Each "\n" starts a new
+	    line (also for TextColored and TextDisabled).
 	    Component name: component_text
 
 	ctx.TextColored(text string, color uint32)
@@ -133,6 +170,10 @@ components use the "component_*" naming prefix.
 	ctx.TextDisabled(text string)
 	    Draws text with the disabled/grayed out color.
 
+	ctx.TextHighlighted(text string, spans []TextSpan)
+	    Draws text with byte ranges recolored (text color and background).
+	    gui.DiffSpans(a, b) returns word-diff spans for it (removed red, added green).
+
 	ctx.TextWrapped(text string, maxWidth float32)
 	    Draws text with automatic word wrapping.
 	    Use maxWidth=0 for current layout width.
@@ -144,39 +185,90 @@ components use the "component_*" naming prefix.
 	ctx.BulletText(text string)
 	    Draws a bullet point followed by text.
 
+	ctx.EditableLabel(id string, value *string, opts ...Option) bool
+	    Text that becomes an InputText on click (or Enter when focused).
+	    Enter/click outside commits, Escape reverts. Returns true on a committed change.
+	    Options: WithWidth, WithDisabled
+
+	ctx.CodeBlock(id string, text string, opts ...Option)
+	    Bordered monospace text box with a Copy button (flashes "Copied!"
+	    after copying; Enter/Space activate it when focused).
+	    Long lines scroll horizontally (Shift+wheel) unless WithWrap is set.
+	    Options: WithWidth, WithWrap
+
+	ctx.Alert(typ AlertType, message string, opts ...Option) (dismissed bool)
+	    Persistent inline banner (AlertInfo/Success/Warning/Error) with icon
+	    and word-wrapped message. Returns true when its "x" is clicked. The
+	    "x" and link activate with Enter/Space when focused.
+	    Options: WithID, WithWidth, WithDismissible, WithAction(label, fn)
+
 ## Button Components
 
 	ctx.Button(label string, opts ...Option) bool
-	    Draws a clickable button. Returns true when clicked.
-	    Options: WithID, WithDisabled, WithWidth, WithHeight
+	    Draws a clickable button. Returns true when the mouse is released
+	    over the button; dragging off before release cancels the click.
+	    Options: WithID, WithDisabled, WithWidth, WithHeight, WithActivateOnPress
 	    Component name: component_button
 
 	ctx.SmallButton(label string, opts ...Option) bool
 	    Draws a smaller button without extra padding.
 	    Component name: component_button_small
 
+	ctx.OverflowMenu(id string, opts ...Option) *OverflowBuilder
+	    Toolbar row: b.Item(label) bool adds a button, b.End() finishes.
+	    Items that don't fit in ContentRegionAvail move into a dropdown
+	    opened by a kebab button (Up/Down/Enter inside, Escape closes).
+	    Options: WithID, WithWidth, WithNoEscape
+
 ## Input Components
 
 	ctx.InputText(label string, value *string, opts ...Option) bool
 	    Full-featured text input with cursor, selection, clipboard, undo/redo.
 	    Returns true when value changes.
-	    Options: WithID, WithDisabled, WithWidth
+	    Options: WithID, WithDisabled, WithWidth, WithGhostCompletion, WithPasswordMode,
+	             WithMask, WithMaskAllowCopy, WithCharFilter, WithMaxLength
 	    Component name: component_input_text
 
+	ctx.InputTextMultiline(label string, value *string, opts ...Option) bool
+	    Multi-line editor: word-wrapped, scrolls vertically with a draggable
+	    scrollbar; mouse drags select across lines.
+	    Enter inserts a newline; Ctrl+Enter or Escape confirms.
+	    Options: WithID, WithWidth, WithHeight (default 4 lines), WithError
+
+	ctx.InputTextMultilineHeight(label string, value *string, height float32, opts ...Option) bool
+	    InputTextMultiline with the box height as an argument.
+
 	ctx.SliderFloat(label string, value *float32, min, max float32, opts ...Option) bool
 	    Horizontal slider for float values. Returns true when value changes.
-	    Options: WithID, WithWidth, WithFormat, WithStep
+	    Options: WithID, WithWidth, WithFormat, WithStep, WithTypeable,
+	             WithVertical (fader: max at top; WithHeight sets the length)
 	    Component name: component_slider
 
 	ctx.SliderInt(label string, value *int, min, max int, opts ...Option) bool
 	    Horizontal slider for integer values. Returns true when value changes.
-	    Options: WithID, WithWidth, WithFormat, WithStep
+	    Options: WithID, WithWidth, WithFormat, WithStep, WithVertical
 	    Component name: component_slider_int
 
+	ctx.VSliderFloat(label string, height float32, value *float32, min, max float32, opts ...Option) bool
+	ctx.VSliderInt(label string, height float32, value *int, min, max int, opts ...Option) bool
+	    Vertical sliders (WithVertical + WithHeight(height)) for channel strips.
+
+	ctx.SliderFloatRange(label string, lo, hi *float32, min, max float32, opts ...Option) bool
+	    Two-handle slider for a range; the handles can't pass each other.
+	    Options: WithID, WithWidth, WithFormat, WithStep
+	    Component name: component_slider_range
+
+	ctx.Knob(label string, value *float32, min, max float32, opts ...Option) bool
+	    Circular dial: drag up/down, wheel, or arrow keys when focused.
+	    Double-click resets to WithDefault. Value and label shown below.
+	    Options: WithID, WithWidth (diameter), WithFormat, WithStep,
+	             WithDragSpeed, WithDefault
+	    Component name: component_knob
+
 	ctx.NumberInputFloat(label string, value *float32, opts ...Option) bool
 	    Numeric input with drag-to-adjust. Click to type, drag to adjust.
 	    Options: WithID, WithWidth, WithFormat, WithStep, WithRange,
-	             WithDragSpeed, WithPrefix, WithSuffix
+	             WithDragSpeed, WithPrefix, WithSuffix, WithTimeFormat
 	    Component name: component_number_input
 
 	ctx.NumberInputInt(label string, value *int, opts ...Option) bool
@@ -187,6 +279,12 @@ components use the "component_*" naming prefix.
 	    Options: WithID, WithDisabled
 	    Component name: component_checkbox
 
+	ctx.CheckboxTristate(label string, state *CheckState, opts ...Option) bool
+	    Checkbox with an Indeterminate state drawn as a dash, for "select
+	    all" boxes. Clicking checks it, or unchecks it when Checked.
+	    Options: WithID, WithDisabled
+	    Component name: component_checkbox_tristate
+
 	ctx.RadioButton(label string, active bool, opts ...Option) bool
 	    Radio button. Returns true when clicked.
 	    Options: WithID, WithDisabled
@@ -194,14 +292,85 @@ components use the "component_*" naming prefix.
 
 	ctx.ComboBox(label string, selectedIndex *int, items []string, opts ...Option) bool
 	    Dropdown selection widget. Returns true when selection changes.
-	    Options: WithID, WithWidth, WithSearchable, WithMaxDropdownHeight
+	    Options: WithID, WithWidth, WithSearchable, WithMaxDropdownHeight, AutoWidth
 	    Component name: component_combobox
 
+	ctx.ComboBoxCustom(label string, selected *int, count int, renderItem func(i int, selected bool), renderPreview func(i int), opts ...Option) bool
+	    Dropdown whose entries are drawn by callbacks (icons, colors, secondary text).
+	    Options: WithID, WithWidth, WithMaxDropdownHeight
+
+	ctx.Rating(label string, value *int, max int, opts ...Option) bool
+	    Star rating: hover previews, click sets, Left/Right adjust when focused.
+	    Options: WithID, WithDisabled, WithHalfStars (value counts half stars)
+
+	ctx.ColorPicker(label string, color *uint32, opts ...Option) bool
+	    Saturation/value square and hue bar; drag to pick. When focused,
+	    Left/Right = saturation, Up/Down = value, PageUp/PageDown = hue.
+	    Options: WithID, WithWidth, WithAlpha (adds an alpha bar)
+	    Component name: component_color_picker
+
+	ctx.ColorEdit(label string, color *uint32, opts ...Option) bool
+	    Preview swatch and R/G/B SliderInt rows (0-255).
+	    Options: WithID, WithWidth (slider tracks), WithAlpha (adds an A row),
+	             WithHexInput (adds a "#RRGGBB" field)
+
+	ctx.ColorButton(label string, color *uint32, opts ...Option) bool
+	    Color swatch that opens a ColorPicker popup (Escape/click outside closes).
+	    Options: WithID, WithWidth (swatch), WithAlpha, WithNoEscape
+
+	ctx.DatePicker(label string, t *time.Time, opts ...Option) bool
+	    Date field that opens a month calendar popup; clicking a day sets the
+	    date (time of day kept). Arrows/PageUp/PageDown + Enter when open.
+	    Options: WithID, WithWidth, WithDateFormat, WithNoEscape
+	    Component name: component_date_picker
+
+	ctx.BeginPopupContextItem(id string) bool / ctx.EndPopup()
+	    Context menu opened by right-clicking the last item; closes on a
+	    chosen entry, a click outside, or Escape. Up/Down + Enter select.
+
+	ctx.BeginMenuBar() bool / ctx.EndMenuBar()
+	    Menu bar across the layout. Clicking a header opens its menu;
+	    hovering another header switches while one is open.
+
+	ctx.BeginMenu(label string) bool / ctx.EndMenu()
+	    A menu in a menu bar, or a submenu entry inside a menu that opens to
+	    the side on hover (Right/Left open and close it from the keyboard).
+
+	ctx.MenuItem(label, shortcut string, selected bool) bool
+	    Popup entry with a check mark when selected and the shortcut text
+	    right-aligned. True when clicked or activated with Enter.
+
+	ctx.StepIndicator(steps []string, current int, opts ...Option) int
+	    Wizard header: numbered circles spread evenly and joined by lines;
+	    completed steps are checked, the current one highlighted, later ones
+	    dimmed. Returns the clicked completed step, or -1.
+	    Options: WithID, WithWidth, WithDisabled, WithStepNavigation
+
 	ctx.ProgressBar(fraction float32, opts ...Option)
 	    Displays a progress bar. Fraction should be 0.0 to 1.0.
+	    Style.ProgressBarGradient shades the fill vertically.
 	    Options: WithWidth, WithHeight
 	    Component name: component_progress_bar
 
+	ctx.ProgressBarSmooth(id string, fraction float32, opts ...Option)
+	    Progress bar whose fill eases toward fraction using DeltaTime.
+	    Options: WithWidth, WithHeight
+
+	ctx.ProgressBarIndeterminate(opts ...Option)
+	    Progress bar for work of unknown length: a block slides across
+	    the track, animated using DeltaTime.
+	    Options: WithWidth, WithHeight, WithID
+
+	ctx.SegmentedProgress(completed, total int, opts ...Option)
+	    Discrete progress: total equal segments with gaps, the first
+	    completed filled. Very large totals merge adjacent segments.
+	    Options: WithWidth, WithHeight, WithSegmentColors
+
+	ctx.Form(id string) *FormBuilder
+	    Validated form. Fields (Text, Float, Int, Select, Checkbox) take a
+	    func(T) error validator; Summary() lists errors; Submit(label) is
+	    disabled until every field validates. Tab cycles through the fields.
+
 ## Selection Components
 
 	ctx.Selectable(label string, selected bool, opts ...Option) bool
@@ -220,6 +389,10 @@ components use the "component_*" naming prefix.
 	    Panel centered on screen using cached size from previous frame.
 	    Solves ImGui's "can't center without knowing size" issue.
 
+	ctx.AnchoredPanel(id string, anchor Anchor, margin Vec2, opts ...LayoutOption) func(func())
+	    Panel pinned to a screen corner, edge or center (AnchorTopLeft ...
+	    AnchorBottomRight), margin pixels in. Follows DisplaySize changes.
+
 	ctx.VStack(opts ...LayoutOption) func(func())
 	    Vertical layout container (items stack top to bottom).
 	    Options: Gap, GapX, GapY, Padding, Width, Height, Align, Justify
@@ -227,14 +400,28 @@ components use the "component_*" naming prefix.
 
 	ctx.HStack(opts ...LayoutOption) func(func())
 	    Horizontal layout container (items stack left to right).
-	    Options: Gap, GapX, GapY, Padding, Width, Height, Align, Justify
+	    Options: Gap, GapX, GapY, Padding, Width, Height, Align, Justify, Wrap
 	    Component name: component_hstack
 
 	ctx.Row(contents func())
 	    Alias for HStack with default options.
 
+	ctx.LabeledRow(label string, controlWidthFrac float32) func(func())
+	    Inspector row: label column on the left, control column on the
+	    right (controlWidthFrac of the width, 0 = 0.65). Controls fill
+	    their column; long labels truncate with a tooltip.
+	    ctx.PushLabelWidth(w)/PopLabelWidth() fix the label column so rows
+	    line up across sections.
+
+	ctx.Columns(count int, opts ...LayoutOption) func(func(col int))
+	    Equal-width columns side by side; contents is called once per
+	    column with its index and the cursor moves below the tallest.
+	    Options: Gap, GapX
+	    ctx.WeightedColumns(weights []float32, ...) sizes them by weight.
+
 	ctx.ListBox(id string, height float32, opts ...LayoutOption) func(func())
-	    Scrollable list area with smooth scrolling.
+	    Scrollable list area with smooth scrolling. ScrollLines(n) scrolls
+	    n lines per wheel notch.
 	    Component name: component_listbox
 
 	ctx.Scrollable(id string, height float32, opts ...Option) func(func())
@@ -245,35 +432,58 @@ components use the "component_*" naming prefix.
 	ctx.List(id string, height float32, opts ...Option) *ListBuilder
 	    Advanced list with sections, search filter, and nested widgets.
 	    Returns a builder for fluent configuration.
-	    Options: ShowScrollbar, WithFilter, WithMultiSelect, DefaultOpen
+	    Options: ShowScrollbar, WithFilter, WithMultiSelect, DefaultOpen,
+	             WithReorderable (drag items to reorder)
 	    Component name: component_list
 
+	ctx.LogView(id string, lines []LogLine, opts ...Option)
+	    Colored, virtualized log that stays pinned to the newest line until
+	    scrolled up. Click/Shift+click select, Ctrl+C copies. WithFilter adds
+	    a TextFilter box ("error,warn", "-debug").
+	    Options: WithWidth, WithHeight, WithFilter, WithScrollLines
+
 ## Table Component
 
 	ctx.BeginTable(id string, columns []TableColumn, flags TableFlags, width, height float32) *Table
 	    Starts a table. Returns nil if table should be skipped.
 	    Component name: component_table
 
+	ctx.BeginTableEx(id string, columns []TableColumn, flags TableFlags, width, height float32, opts TableOptions) *Table
+	    BeginTable with TableOptions: MaxVisibleRows, ScrollLines, and
+	    CellPaddingX/CellPaddingY overriding Style.CellPaddingX/CellPaddingY.
+
 	ctx.BeginTableVirtualized(id string, columns []TableColumn, flags TableFlags, width, height float32, totalRows int) *Table
 	    Virtualized table for large datasets (1000+ rows).
 
 	Table methods:
 	    t.TableHeadersRow()                    Draw column headers
 	    t.TableNextRow()                       Start new row
+	    t.TableNextRowHeight(h float32)        Start new row h pixels tall
 	    t.TableNextColumn() Vec2               Move to next column
 	    t.TableText(text string)               Draw text in current column
 	    t.TableTextColored(text, color)        Draw colored text
+	    t.TableTextWrapped(text string)        Draw word-wrapped text
+	    t.TableInputText(value *string) bool   Editable text cell (Tab moves between editable cells)
+	    t.TableCheckbox(value *bool) bool      Checkbox cell
+	    t.TableCell(draw func())               Custom cell content (not called for hidden columns)
+	    t.TableCellHeight(h float32)           Report custom cell content height
 	    t.TableIsRowHovered() bool             Check if row is hovered
 	    t.TableIsRowClicked() bool             Check if row was clicked
+	    t.SetColumnHidden(i int, hidden bool)  Show/hide a column (also via header right-click menu)
+	    t.IsColumnHidden(i int) bool           Check if a column is hidden
+	    t.TableColumnVisible() bool            Check if the current column is shown
+	    t.ExportCSV() / t.ExportTSV() string   Cells drawn this frame (TableOptions{CaptureText: true})
+	    t.CopySelectedRows()                   Copy selected rows tab-separated (also Ctrl+C)
 	    t.EndTable()                           Finish table
 
 	TableFlags:
 	    TableFlagsResizable        Enable column resizing
-	    TableFlagsSortable         Enable sorting indicators
+	    TableFlagsSortable         Click headers to sort (t.SortSpecs, t.SortSpecsDirty)
 	    TableFlagsRowSelect        Enable row selection
 	    TableFlagsScrollY          Enable vertical scrolling
 	    TableFlagsStickyHeader     Keep header visible when scrolling
 	    TableFlagsAutoSizeColumns  Auto-size columns to content
+	    TableFlagsAutoRowHeight    Rows grow to fit their tallest cell
 	    TableFlagsBordersInner     Inner borders (H+V)
 	    TableFlagsBordersOuter     Outer borders (H+V)
 	    TableFlagsBorders          All borders
@@ -295,12 +505,22 @@ components use the "component_*" naming prefix.
 	ctx.TreePop()
 	    End a tree node started with TreeNode().
 
+	ctx.VirtualTree(id string, height float32, model TreeModel, opts ...Option) int
+	    Virtualized tree over a flattened TreeModel; only visible rows are
+	    drawn. Up/Down select, Left collapses/goes to parent, Right expands/
+	    goes to first child. Returns the selected row index or -1.
+	    Options: WithWidth, WithScrollLines
+
 ## Misc Components
 
 	ctx.Separator()
 	    Draws a horizontal separator line.
 	    Component name: component_separator
 
+	ctx.SeparatorText(label string)
+	    Separator line with a label near its left end, the line broken
+	    around the text. Groups widgets without a CollapsingHeader.
+
 	ctx.Spacing(pixels float32)
 	    Adds vertical space.
 
@@ -316,8 +536,43 @@ components use the "component_*" naming prefix.
 	ctx.SameLine()
 	    Places next widget on same line as previous.
 
+	ctx.ContentRegionAvail() Vec2
+	    Space left in the current layout from the cursor.
+
 	ctx.Tooltip(text string)
-	    Shows tooltip at mouse position.
+	    Shows tooltip at mouse position after the previous widget has been
+	    hovered for the tooltip delay (ctx.SetTooltipDelay, default 0.5s).
+
+	ctx.SetItemTooltip(text string)
+	    Like Tooltip, but drawn on the foreground list, above later
+	    widgets.
+
+	ctx.ItemRect() Rect / ctx.IsItemHovered() bool / ctx.IsItemClicked() bool
+	    Bounds, hover and left-click of the widget drawn just before.
+
+	ctx.RubberBand(id string, area Rect) (selRect Rect, active, completed bool)
+	    Click-drag marquee selection inside area (call after drawing contents).
+
+	ctx.Splitter(id string, vertical bool, size1 *float32, minSize1, minSize2 float32, opts ...Option) bool
+	    Draggable divider between two regions; adjusts *size1 within the
+	    min sizes. Arrow keys move it when focused (Shift for larger steps).
+	    Doesn't advance the cursor.
+	    Options: WithID, WithWidth/WithHeight (region size)
+	    Component name: component_splitter
+
+	ctx.BeginDragDropSource() bool / ctx.SetDragDropPayload(kind string, data []byte) / ctx.EndDragDropSource()
+	    Makes the last item draggable; widgets drawn in between form the
+	    preview that follows the mouse (ForegroundDrawList).
+
+	ctx.BeginDragDropTarget() bool / ctx.AcceptDragDropPayload(kind string) ([]byte, bool) / ctx.EndDragDropTarget()
+	    Makes the last item a drop target; Accept succeeds on release over
+	    the item when the payload kind matches.
+
+	ctx.PushInputRegion(rect Rect) / ctx.PopInputRegion()
+	    Marks a screen area as GUI-owned for MouseOverGUI.
+
+	ctx.MouseOverGUI(pos Vec2) bool
+	    True if pos is over a panel, open popup or input region this frame.
 
 # Widget Options Reference
 
@@ -327,22 +582,51 @@ Common options available for widgets:
 	WithDisabled(disabled bool)    Disable widget interaction
 	WithWidth(width float32)       Set widget width
 	WithHeight(height float32)     Set widget height
+	WithActivateOnPress()          Fire button on press instead of release
+	WithNoEscape()                 Ignore Escape; it goes to the next element
 	WithFormat(format string)      Printf-style format (e.g., "%.2f")
 	WithStep(step float32)         Value increment step
 	WithRange(min, max float32)    Value range constraints
 	WithDragSpeed(speed float32)   Drag sensitivity
+	WithDefault(v float32)         Value restored by double-click (Knob)
 	WithPrefix(prefix string)      Text prefix (e.g., "X:")
 	WithSuffix(suffix string)      Text suffix (e.g., "px")
+	WithTypeable()                 Double-click a slider to type a value
+	WithVertical()                 Vertical slider, max at the top
+	WithTimeFormat(f TimeFormat)   Show/parse NumberInput seconds as M:SS or H:MM:SS
+	WithHalfStars()                Half-star steps for Rating
+	WithAlpha()                    Alpha bar/row (ColorPicker, ColorButton, ColorEdit)
+	WithHexInput()                 Hex text field (ColorEdit)
+	WithDateFormat(layout string)  time.Format layout of a DatePicker's date
+	WithSegmentColors(c ...uint32) Per-segment status colors (SegmentedProgress)
+	WithStepNavigation()           Completed steps are clickable (StepIndicator)
 	WithSearchable()               Enable typing to filter (ComboBox)
 	WithMaxDropdownHeight(h)       Limit dropdown height
+	AutoWidth(max ...float32)      Size ComboBox to its widest item
 	WithColumns(n int)             Multi-column layout
 	ShowScrollbar(always bool)     Control scrollbar visibility
 	ScrollbarPosition(side)        Scrollbar side (left/right)
 	EnableHorizontal()             Enable horizontal scroll
 	ClampToContent()               Don't scroll past content
-	WithFilter(placeholder)        Enable search filter (List)
+	WithScrollLines(n int)         Scroll n lines per wheel notch (Scrollable, List, LogView, VirtualTree)
+	WithFilter(placeholder)        Enable search filter (List, LogView)
 	WithMultiSelect()              Allow multiple selection
 	DefaultOpen()                  Start sections expanded
+	WithReorderable(fn)            Drag List items to reorder; fn(from, to) on drop
+	WithOnChange(fn func())        Callback fired once per value change (see also SetChangeObserver)
+	WithDebounce()                 Report drag/edit changes when they end
+	WithError(msg string)          Validation error border and message (inputs)
+	WithGhostCompletion(fn)        Dimmed suggested suffix, Tab/Right accepts (InputText)
+	WithPasswordMode()             Draw characters as '•', no copying (InputText)
+	WithMask(r rune)               Draw characters as r, no copying (InputText)
+	WithPassword()                 Alias of WithPasswordMode
+	WithPasswordMask(r rune)       Alias of WithMask
+	WithMaskAllowCopy()            Let Ctrl+C/Ctrl+X copy a masked field's real text
+	WithCharFilter(accept)         Only accept typed/pasted characters accept allows
+	WithMaxLength(n int)           Limit an InputText to n characters
+	WithWrap()                     Wrap long lines instead of scrolling (CodeBlock)
+	WithDismissible()              Show a dismiss "x" (Alert)
+	WithAction(label, fn)          Link beneath the message (Alert)
 
 # Layout Options Reference
 
@@ -357,6 +641,10 @@ Options for Panel, VStack, HStack, and other layout containers:
 	Height(h float32)              Fixed height
 	Align(alignment Alignment)     Cross-axis alignment
 	Justify(just Justification)    Main-axis alignment
+	MaxHeight(h float32)           Maximum panel height
+	ClipContent()                  Clip panel contents to its content region
+	Wrap()                         HStack: start a new row when the next item doesn't fit
+	ScrollLines(n int)             ListBox: scroll n lines per wheel notch
 
 Alignment values: AlignStart, AlignCenter, AlignEnd, AlignStretch
 Justification values: JustifyStart, JustifyCenter, JustifyEnd, JustifyBetween
@@ -380,16 +668,141 @@ Use these instead of magic numbers:
 Widget state types for GetState/SetState:
 
 	ScrollState           Scroll position for ListBox
-	InputTextState        Cursor, selection, undo stack for InputText
+	InputTextState        Cursor, selection, undo stack for InputText(Multiline)
 	TreeNodeState         Expanded state for TreeNode
 	CollapsingHeaderState Collapsed state for CollapsingHeader
+	VirtualTreeState      Scroll and selection for VirtualTree
 	SliderState           Drag state for Slider
-	ComboBoxState         Open/scroll state for ComboBox
+	KnobState             Drag and double-click state for Knob
+	ColorPickerState      HSV, drag, popup and hex text for the color widgets
+	ComboBoxState         Open/direction/scroll state for ComboBox
+	DatePickerState       Open state, shown month and keyboard day for DatePicker
+	PopupState            Open state, position, size and submenu of a context menu or menu
+	MenuBarState          The menu bar's open menu
 	ScrollableState       Full scroll state for Scrollable
 	ListState             Scroll/filter/selection for List
+	LogViewState          Scroll/pin/filter/selection for LogView
 	NumberInputState      Edit/drag state for NumberInput
 	TableState            Column widths, sort, selection for Table
 
+State in a FrameStore is dropped once it goes a frame without access.
+FrameStore.SetRetention(frames) keeps entries longer (e.g. for panels that
+are hidden for a while), and Pin(id)/Unpin(id) keep specific entries until
+released.
+
+# Multiple GUIs
+
+Several GUI instances can share one renderer (a main window and a detached
+tool, say). Each has its own Context, and with it its own IDs, focus,
+popups and widget state: the built-in widgets use the FrameStore ...For
+methods (GetFor(ctx, id, def), SetFor, PinFor, ...), which keep each
+Context's entries apart and age them only by its own Begin/End cycles. A
+GUI's frame may even run inside another's, and a GUI's entries are
+released once it is garbage collected. Custom widgets get the same
+isolation by using the ...For methods; Get, Set and the other methods
+without a Context share one set of entries between all GUIs. The clipboard
+is shared, like the system clipboard it stands in for.
+
+# High Contrast
+
+Set Style.HighContrast for users who need stronger focus and selection
+cues. Style.Effective resolves the style every widget draws with: the
+focus ring, selected rows, text selection and text caret switch to the
+HighContrastFocusColor, HighContrastSelectionColor and
+HighContrastCaretColor values, and borders are drawn at least 2 pixels
+thick. It is applied by SetStyle, so it can be toggled between frames:
+
+	s := ui.Style()
+	s.HighContrast = !s.HighContrast
+	ui.SetStyle(s)
+
+Without it, Style.CaretColor and Style.SelectionColor set the caret and
+text selection colors (0 falls back to TextColor and SelectedBgColor).
+
+# Font Scale
+
+ctx.PushFontScale(scale) draws and measures text at scale instead of
+Style.FontScale until ctx.PopFontScale, so a panel can mix a large title
+with normal body text:
+
+	ctx.PushFontScale(2)
+	ctx.Text("Vehicle Shop")
+	ctx.PopFontScale()
+
+# Text Shadow
+
+Text drawn over the game scene can be hard to read. Set Style.TextShadow
+to draw every piece of text over a copy of itself in
+Style.TextShadowColor (0 = translucent black), moved by
+Style.TextShadowOffset (zero = 1 pixel down and right). For a single
+string, ctx.AddTextShadowed(x, y, text, color, shadow, offset) does the
+same without the style flag:
+
+	ctx.AddTextShadowed(x, y, "MISSION PASSED", gui.ColorYellow, gui.ColorBlack, gui.Vec2{X: 2, Y: 2})
+
+# Anti-Aliased Lines
+
+Lines are drawn as solid quads, which look jagged at an angle or at
+non-integer positions. Set Style.AntiAliasedLines to draw every AddLine
+(graphs, separators, knobs, check marks) with edges that fade out over a
+pixel. Custom drawing can call DrawList.AddLineAA directly, or set
+DrawList.AntiAliasedLines on its own list.
+
+# Circles
+
+DrawList.AddCircleFilled draws a filled circle and AddCircleOutline a ring.
+AddCircle also fills, matching AddRect/AddRectOutline; unlike ImGui's
+AddCircle, it doesn't draw an outline.
+
+# Corner Radius
+
+Style.CornerRadius rounds the corners of panels and buttons; set it rather
+than Style.Rounding, the field's deprecated old name. Rounding is only read
+when CornerRadius is 0, so existing themes keep working, but CornerRadius
+wins whenever both are set.
+
+# Gradients
+
+Style.PanelGradient, Style.HeaderGradient and Style.ButtonGradient give
+panels, headers (panel, section, list and table) and buttons a subtle
+vertical gradient from a lighter top down to their usual color. The shading
+uses per-vertex colors, so it needs no renderer support. Custom drawing can
+use DrawList.AddRectGradientV/AddRectGradientH, or
+AddRectRoundedGradientV for rounded rectangles.
+
+# Style Files
+
+gui.SaveStyle(w, style) writes a Style as JSON, one key per field, with
+colors as "#RRGGBBAA" strings. gui.LoadStyle(r) reads it back; missing
+fields keep their DefaultStyle value, so a theme only lists what it
+changes, and unknown fields are an error. Themes can then be edited
+without recompiling:
+
+	f, err := os.Open("theme.json")
+	if err != nil {
+	    return err
+	}
+	defer f.Close()
+	style, err := gui.LoadStyle(f)
+	if err != nil {
+	    return err
+	}
+	ui.SetStyle(style)
+
+# Observing Changes
+
+ctx.SetChangeObserver(fn) installs a hook that sees every value change made
+through a value widget (Checkbox, InputText, NumberInput, sliders,
+ComboBox, Rating, ColorPicker), for macro recording or undo logs:
+
+	ctx.SetChangeObserver(func(id gui.ID, name, kind string, value any) {
+	    recorder.Log(name, kind, value) // e.g. "Volume", "component_slider", float32(0.8)
+	})
+
+It is called on every frame a widget reports a change, after the value is
+written, with the value as float32, int, uint32, string or bool. It is independent
+of WithOnChange and WithDebounce. With no observer set it costs a nil check.
+
 # Component Interface
 
 For creating custom components:
@@ -411,7 +824,7 @@ For creating custom components:
 
 # Clipboard Integration
 
-To enable clipboard support, implement ClipboardProvider:
+To enable system clipboard support, implement ClipboardProvider:
 
 	type ClipboardProvider interface {
 	    GetText() string
@@ -434,6 +847,11 @@ To enable clipboard support, implement ClipboardProvider:
 	// Register during init:
 	gui.SetClipboardProvider(&GLFWClipboard{window: window})
 
+Without a provider, an in-memory clipboard is used so copy/paste still works
+within the application. SetClipboardProvider(nil) reverts to it.
+GetClipboardProvider reports the registered provider (nil if none), and
+EffectiveClipboardProvider the clipboard actually in use.
+
 # Text Utilities
 
 For advanced text handling:
@@ -459,7 +877,9 @@ Built-in optimizations:
   - sync.Pool for DrawList buffer reuse
   - Batched rendering by texture
   - Pre-allocated glyph buffer for text
-  - Per-frame text measurement cache
+  - Per-frame text measurement cache, keyed by text and FontScale (flushed
+    on font changes; call ctx.InvalidateTextCache after switching fonts
+    through the FontProvider directly)
   - ListClipper for virtualizing large lists
   - Table row virtualization
 
@@ -481,6 +901,28 @@ For large datasets, use:
 	    // Draw item at y
 	}
 
+# Partial Redraw
+
+With the WithDamageTracking option, GUI.End compares each frame's draw
+lists with the previous frame's before rendering. DrawList.DamageRect reports the screen area that may have
+changed, or false if nothing did. It covers hover and focus changes and
+widgets that appeared or disappeared. The rect is conservative and covers
+both the main and foreground lists. A backend that keeps the previous frame
+in its back buffer can scissor to it and skip everything else:
+
+	func (r *MyRenderer) Render(dl *gui.DrawList) error {
+	    damage, changed := dl.DamageRect()
+	    if !changed {
+	        return nil // Previous frame is still on screen
+	    }
+	    r.setScissor(damage) // Clear and draw only inside damage
+	    ...
+	}
+
+The first frame, and any frame after a display size change, reports the
+whole display. Tracking compares geometry only: after changing a texture's
+contents, call GUI.InvalidateDamage so the next frame redraws everything.
+
 # Differences from Dear ImGui
 
 This implementation addresses known ImGui issues:
@@ -33,6 +33,9 @@ func main() {
 	}
 }
 
+// frameDelta is the simulated time in seconds between captured frames.
+const frameDelta = 1.0 / 60
+
 // screenshot defines a single widget screenshot to capture.
 type screenshot struct {
 	name   string                 // filename without extension
@@ -97,6 +100,9 @@ func capture(_ *glfw.Window, renderer *opengl.Renderer, _ *gui.GUI, s screenshot
 	renderer.Resize(s.width, s.height)
 
 	// Fresh GUI per screenshot to avoid state leaking between captures.
+	// The deterministic clock advances by frameDelta per rendered frame, so
+	// animated widgets (caret blink, eased fills) settle the same way on
+	// every run regardless of how long a frame really takes.
 	ui := gui.New(renderer, gui.WithStyle(gui.GTAStyle()))
 
 	frames := 2
@@ -109,8 +115,9 @@ func capture(_ *glfw.Window, renderer *opengl.Renderer, _ *gui.GUI, s screenshot
 		gl.ClearColor(0.12, 0.12, 0.14, 1.0)
 		gl.Clear(gl.COLOR_BUFFER_BIT)
 
+		ui.SetTime(float32(i) * frameDelta)
 		displaySize := gui.Vec2{X: float32(s.width), Y: float32(s.height)}
-		ctx := ui.Begin(&gui.InputState{}, displaySize, 1.0/60.0)
+		ctx := ui.Begin(&gui.InputState{}, displaySize, frameDelta)
 		s.draw(ctx)
 		if err := ui.End(); err != nil {
 			return err
@@ -21,6 +21,15 @@ func (d *DragState) Reset() {
 	d.PanelName = ""
 }
 
+// InteractionMode is what a mouse press on a DraggablePanel is doing.
+type InteractionMode uint8
+
+const (
+	InteractionNone     InteractionMode = iota
+	InteractionDragging                 // Moving the panel by its title bar
+	InteractionResizing                 // Resizing the panel by an edge or corner
+)
+
 // SnapConfig configures panel snapping behavior.
 type SnapConfig struct {
 	Enabled     bool    // Enable snapping
@@ -116,18 +125,47 @@ func NewResizablePanel(x, y, w, h float32) *DraggablePanel {
 }
 
 // TitleBarRect returns the rectangle for the draggable title bar area.
-// The title bar is at the top of the panel.
+// The title bar is at the top of the panel. On a resizable panel it stops
+// short of the resize handles along the top, left and right edges, so a
+// press there can only start a resize.
 func (dp *DraggablePanel) TitleBarRect(ctx *Context) Rect {
 	h := dp.TitleBarHeight
 	if h == 0 {
 		h = ctx.LineHeight() + ctx.Style().PanelPadding*2
 	}
-	return Rect{
+	r := Rect{
 		X: dp.Position.X,
 		Y: dp.Position.Y,
 		W: dp.Size.X,
 		H: h,
 	}
+	if dp.Resizable {
+		hs := dp.handleSize()
+		r = Rect{X: r.X + hs, Y: r.Y + hs, W: maxf(0, r.W-hs*2), H: maxf(0, r.H-hs)}
+	}
+	return r
+}
+
+// handleSize returns the width of the resize handle area.
+func (dp *DraggablePanel) handleSize() float32 {
+	if dp.ResizeHandleSize <= 0 {
+		return 6
+	}
+	return dp.ResizeHandleSize
+}
+
+// InteractionMode reports whether the panel is being dragged, resized, or
+// neither. A press on a resize edge starts a resize, a press elsewhere in
+// the title bar starts a drag, and whichever starts keeps the panel until
+// the mouse button is released.
+func (dp *DraggablePanel) InteractionMode() InteractionMode {
+	switch {
+	case dp.resizeState.Active:
+		return InteractionResizing
+	case dp.dragState.Active:
+		return InteractionDragging
+	}
+	return InteractionNone
 }
 
 // HandleDrag processes drag input for the panel.
@@ -141,10 +179,11 @@ func (dp *DraggablePanel) HandleDrag(ctx *Context) bool {
 	input := ctx.Input
 	mousePos := Vec2{X: input.MouseX, Y: input.MouseY}
 
-	// Check for drag start (mouse click in title bar)
-	if input.MouseClicked(MouseButtonLeft) {
+	// Check for drag start (mouse click in title bar). Resizing wins when
+	// the press is on a resize edge, whichever handler runs first.
+	if input.MouseClicked(MouseButtonLeft) && dp.InteractionMode() == InteractionNone {
 		titleBar := dp.TitleBarRect(ctx)
-		if titleBar.Contains(mousePos) {
+		if titleBar.Contains(mousePos) && dp.GetResizeEdge(ctx) == ResizeEdgeNone {
 			dp.dragState.Active = true
 			dp.dragState.StartX = mousePos.X
 			dp.dragState.StartY = mousePos.Y
@@ -153,9 +192,14 @@ func (dp *DraggablePanel) HandleDrag(ctx *Context) bool {
 		}
 	}
 
-	// Handle ongoing drag
+	// Handle ongoing drag. The panel only follows the mouse once it has
+	// moved past the drag threshold, so a click on the title bar doesn't
+	// nudge it.
 	if dp.dragState.Active {
-		if input.MouseDown(MouseButtonLeft) {
+		if !input.MouseDown(MouseButtonLeft) {
+			// Mouse released, end drag and apply snapping
+			dp.endDrag(ctx)
+		} else if ctx.IsMouseDragging(MouseButtonLeft, 0) {
 			// Update position based on mouse movement
 			newX := mousePos.X + dp.dragState.OffsetX
 			newY := mousePos.Y + dp.dragState.OffsetY
@@ -181,9 +225,6 @@ func (dp *DraggablePanel) HandleDrag(ctx *Context) bool {
 			}
 
 			dp.Position = Vec2{X: newX, Y: newY}
-		} else {
-			// Mouse released, end drag and apply snapping
-			dp.endDrag(ctx)
 		}
 	}
 
@@ -339,10 +380,7 @@ func (dp *DraggablePanel) GetResizeEdge(ctx *Context) ResizableEdge {
 		return ResizeEdgeNone
 	}
 
-	handleSize := dp.ResizeHandleSize
-	if handleSize <= 0 {
-		handleSize = 6
-	}
+	handleSize := dp.handleSize()
 
 	mx, my := ctx.Input.MouseX, ctx.Input.MouseY
 	px, py := dp.Position.X, dp.Position.Y
@@ -384,8 +422,9 @@ func (dp *DraggablePanel) HandleResize(ctx *Context) bool {
 	input := ctx.Input
 	mousePos := Vec2{X: input.MouseX, Y: input.MouseY}
 
-	// Check for resize start (mouse click on edge)
-	if input.MouseClicked(MouseButtonLeft) && !dp.resizeState.Active {
+	// Check for resize start (mouse click on edge), unless a drag already
+	// owns the press
+	if input.MouseClicked(MouseButtonLeft) && dp.InteractionMode() == InteractionNone {
 		edge := dp.GetResizeEdge(ctx)
 		if edge != ResizeEdgeNone {
 			dp.resizeState.Active = true
@@ -463,6 +502,12 @@ func (dp *DraggablePanel) HandleResize(ctx *Context) bool {
 	return dp.resizeState.Active
 }
 
+// Bounds returns the panel's screen rectangle.
+// This makes panels embedding DraggablePanel a BoundedPanel.
+func (dp *DraggablePanel) Bounds() Rect {
+	return Rect{X: dp.Position.X, Y: dp.Position.Y, W: dp.Size.X, H: dp.Size.Y}
+}
+
 // IsResizing returns true if the panel is currently being resized.
 func (dp *DraggablePanel) IsResizing() bool {
 	return dp.resizeState.Active
@@ -0,0 +1,178 @@
+package gui
+
+// dragDropPreviewOffset is where a drag preview starts relative to the mouse.
+var dragDropPreviewOffset = Vec2{X: 12, Y: 12}
+
+// dragDropState is the drag-and-drop in progress. Only one payload can be
+// dragged at a time.
+type dragDropState struct {
+	pressID  ID // Source pressed but not yet dragged past the threshold
+	sourceID ID // Source being dragged (0 = no drag)
+	kind     string
+	data     []byte
+	seen     bool // The source was drawn this frame
+	released bool // The mouse was released: the drag ends after this frame
+
+	// Saved while drawing a source's preview
+	inSource      bool
+	savedCursor   Vec2
+	savedDrawList *DrawList
+	savedLayouts  []*Layout
+	savedLastItem Rect
+	savedVtxCount int
+}
+
+// endFrame clears a drag that was dropped, or whose source stopped being
+// drawn. Called at the start of each frame.
+func (d *dragDropState) endFrame() {
+	if d.sourceID != 0 && (d.released || !d.seen) {
+		*d = dragDropState{}
+	}
+	d.seen = false
+}
+
+// BeginDragDropSource makes the last item (the widget drawn just before)
+// a drag source. Pressing it and moving the mouse past Style.DragThreshold
+// starts a drag; while it lasts the call returns true and the caller sets
+// the payload with SetDragDropPayload, draws a preview, and calls
+// EndDragDropSource. The preview is drawn at the mouse on the
+// ForegroundDrawList; when nothing is drawn, a box naming the payload kind
+// is shown instead. Keep the preview to display-only widgets (Text etc).
+//
+// Usage:
+//
+//	ctx.Button(item.Name)
+//	if ctx.BeginDragDropSource() {
+//	    ctx.SetDragDropPayload("item", []byte(item.ID))
+//	    ctx.Text(item.Name)
+//	    ctx.EndDragDropSource()
+//	}
+func (ctx *Context) BeginDragDropSource() bool {
+	id := ctx.GetID("drag_drop_source")
+	if ctx.Input == nil {
+		return false
+	}
+	d := &ctx.dragDrop
+	input := ctx.Input
+
+	if d.sourceID == 0 {
+		if input.MouseClicked(MouseButtonLeft) && ctx.isHovered(0, ctx.lastItemRect) {
+			d.pressID = id
+		}
+		if d.pressID != id {
+			return false
+		}
+		if !input.MouseDown(MouseButtonLeft) {
+			d.pressID = 0
+			return false
+		}
+		if !ctx.IsMouseDragging(MouseButtonLeft, 0) {
+			return false
+		}
+		d.pressID, d.sourceID = 0, id
+	}
+	if d.sourceID != id {
+		return false
+	}
+
+	d.seen = true
+	if !input.MouseDown(MouseButtonLeft) {
+		d.released = true
+	}
+	ctx.WantCaptureMouse = true
+
+	// Draw the preview at the mouse, outside any layout
+	d.inSource = true
+	d.savedCursor = ctx.cursor
+	d.savedDrawList = ctx.DrawList
+	d.savedLayouts = ctx.layoutStack
+	d.savedLastItem = ctx.lastItemRect
+	ctx.layoutStack = ctx.layoutStack[len(ctx.layoutStack):]
+	ctx.DrawList = ctx.foregroundDrawList()
+	d.savedVtxCount = len(ctx.DrawList.VtxBuffer)
+	ctx.cursor = Vec2{X: input.MouseX, Y: input.MouseY}.Add(dragDropPreviewOffset)
+	return true
+}
+
+// SetDragDropPayload sets the kind and data carried by the active drag.
+// Call it between BeginDragDropSource and EndDragDropSource; targets
+// accept the payload by kind.
+func (ctx *Context) SetDragDropPayload(kind string, data []byte) {
+	d := &ctx.dragDrop
+	if !d.inSource {
+		return
+	}
+	d.kind = kind
+	d.data = append(d.data[:0], data...)
+}
+
+// EndDragDropSource finishes a source begun with BeginDragDropSource.
+// Call it only when BeginDragDropSource returned true.
+func (ctx *Context) EndDragDropSource() {
+	d := &ctx.dragDrop
+	if !d.inSource {
+		return
+	}
+	if len(ctx.DrawList.VtxBuffer) == d.savedVtxCount {
+		// Nothing drawn: show the payload kind
+		start := Vec2{X: ctx.Input.MouseX, Y: ctx.Input.MouseY}.Add(dragDropPreviewOffset)
+		pad := ctx.style.InputPadding
+		size := ctx.MeasureText(d.kind)
+		ctx.DrawList.AddRect(start.X, start.Y, size.X+pad*2, size.Y+pad*2, ctx.style.PanelColor)
+		ctx.DrawList.AddRectOutline(start.X, start.Y, size.X+pad*2, size.Y+pad*2, ctx.style.BorderColor, ctx.style.BorderSize)
+		ctx.addText(start.X+pad, start.Y+pad, d.kind, ctx.style.TextColor)
+	}
+
+	ctx.cursor = d.savedCursor
+	ctx.DrawList = d.savedDrawList
+	ctx.layoutStack = d.savedLayouts
+	ctx.lastItemRect = d.savedLastItem
+	d.inSource = false
+	d.savedDrawList, d.savedLayouts = nil, nil
+}
+
+// BeginDragDropTarget makes the last item a drop target. It returns true
+// while a drag is over the item; the caller then checks the payload with
+// AcceptDragDropPayload and calls EndDragDropTarget.
+//
+// Usage:
+//
+//	ctx.Button("Trash")
+//	if ctx.BeginDragDropTarget() {
+//	    if data, ok := ctx.AcceptDragDropPayload("item"); ok {
+//	        remove(string(data))
+//	    }
+//	    ctx.EndDragDropTarget()
+//	}
+func (ctx *Context) BeginDragDropTarget() bool {
+	if ctx.dragDrop.sourceID == 0 {
+		return false
+	}
+	return ctx.isHovered(0, ctx.lastItemRect)
+}
+
+// AcceptDragDropPayload returns the payload's data when the drag carries
+// kind and the mouse is released over the target this frame. While the
+// drag hovers a matching target, the target is outlined.
+func (ctx *Context) AcceptDragDropPayload(kind string) ([]byte, bool) {
+	d := &ctx.dragDrop
+	if d.sourceID == 0 || d.kind != kind {
+		return nil, false
+	}
+	r := ctx.lastItemRect
+	ctx.foregroundDrawList().AddRectOutline(r.X, r.Y, r.W, r.H, ctx.style.FocusColor, 2)
+	if ctx.Input.MouseDown(MouseButtonLeft) {
+		return nil, false
+	}
+	d.released = true
+	return d.data, true
+}
+
+// EndDragDropTarget finishes a target begun with BeginDragDropTarget.
+func (ctx *Context) EndDragDropTarget() {}
+
+// IsDragDropActive reports whether a drag-and-drop is in progress, and
+// the kind of its payload.
+func (ctx *Context) IsDragDropActive() (kind string, active bool) {
+	return ctx.dragDrop.kind, ctx.dragDrop.sourceID != 0
+}
@@ -240,3 +240,104 @@ func TestDragState_Reset(t *testing.T) {
 		t.Error("DragState.Reset() did not clear all fields")
 	}
 }
+
+func TestDraggablePanel_ResizeTakesPrecedence(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetStyle(DefaultStyle())
+	ctx.Input = NewInputState()
+	ctx.DisplaySize = Vec2{X: 800, Y: 600}
+
+	// frame runs both handlers in the given order
+	frame := func(dp *DraggablePanel, resizeFirst bool) {
+		if resizeFirst {
+			dp.HandleResize(ctx)
+			dp.HandleDrag(ctx)
+		} else {
+			dp.HandleDrag(ctx)
+			dp.HandleResize(ctx)
+		}
+		ctx.Input.Reset()
+	}
+
+	for _, resizeFirst := range []bool{false, true} {
+		dp := NewResizablePanel(100, 100, 200, 150)
+
+		// The top-left corner is inside the unadjusted title bar and on both
+		// resize edges: resizing wins
+		ctx.Input.SetMousePos(102, 102)
+		ctx.Input.SetMouseButton(MouseButtonLeft, true)
+		frame(dp, resizeFirst)
+		if dp.InteractionMode() != InteractionResizing {
+			t.Fatalf("resizeFirst=%v: mode = %v after corner press, want resizing", resizeFirst, dp.InteractionMode())
+		}
+
+		// Locked until release, even over the middle of the title bar
+		ctx.Input.SetMousePos(150, 110)
+		frame(dp, resizeFirst)
+		if dp.InteractionMode() != InteractionResizing || dp.IsDragging() {
+			t.Errorf("resizeFirst=%v: mode changed mid-resize to %v", resizeFirst, dp.InteractionMode())
+		}
+		ctx.Input.SetMouseButton(MouseButtonLeft, false)
+		frame(dp, resizeFirst)
+		if dp.InteractionMode() != InteractionNone {
+			t.Errorf("resizeFirst=%v: mode = %v after release, want none", resizeFirst, dp.InteractionMode())
+		}
+
+		// A press in the title bar away from the edges drags
+		ctx.Input.SetMousePos(dp.Position.X+50, dp.Position.Y+10)
+		ctx.Input.SetMouseButton(MouseButtonLeft, true)
+		frame(dp, resizeFirst)
+		if dp.InteractionMode() != InteractionDragging {
+			t.Errorf("resizeFirst=%v: mode = %v after title bar press, want dragging", resizeFirst, dp.InteractionMode())
+		}
+		ctx.Input.SetMouseButton(MouseButtonLeft, false)
+		frame(dp, resizeFirst)
+	}
+}
+
+func TestDraggablePanel_TitleBarExcludesResizeHandles(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetStyle(DefaultStyle())
+
+	dp := NewResizablePanel(100, 100, 200, 150)
+	bar := dp.TitleBarRect(ctx)
+	hs := dp.ResizeHandleSize
+	if bar.X != 100+hs || bar.Y != 100+hs || bar.W != 200-hs*2 {
+		t.Errorf("title bar = %+v, want inset by %v from the top, left and right", bar, hs)
+	}
+
+	dp.Resizable = false
+	if bar := dp.TitleBarRect(ctx); bar.X != 100 || bar.Y != 100 || bar.W != 200 {
+		t.Errorf("non-resizable title bar = %+v, want the full width", bar)
+	}
+}
+
+func TestDraggablePanel_IgnoresMovementWithinThreshold(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetStyle(DefaultStyle())
+	ctx.Input = NewInputState()
+	ctx.DisplaySize = Vec2{X: 800, Y: 600}
+
+	dp := NewDraggablePanel(100, 100)
+	dp.Size = Vec2{X: 200, Y: 150}
+
+	ctx.Input.SetMousePos(150, 110)
+	ctx.Input.SetMouseButton(MouseButtonLeft, true)
+	dp.HandleDrag(ctx)
+
+	// Jitter within Style.DragThreshold doesn't move the panel
+	ctx.Input.Reset()
+	ctx.Input.SetMousePos(152, 111)
+	dp.HandleDrag(ctx)
+	if dp.Position.X != 100 || dp.Position.Y != 100 {
+		t.Errorf("Expected panel to stay at (100, 100) within the drag threshold, got (%f, %f)", dp.Position.X, dp.Position.Y)
+	}
+
+	// Past the threshold it follows the mouse from the press point
+	ctx.Input.Reset()
+	ctx.Input.SetMousePos(160, 110)
+	dp.HandleDrag(ctx)
+	if dp.Position.X != 110 || dp.Position.Y != 100 {
+		t.Errorf("Expected position (110, 100) past the drag threshold, got (%f, %f)", dp.Position.X, dp.Position.Y)
+	}
+}
@@ -1,6 +1,9 @@
 package gui
 
-import "sync"
+import (
+	"math"
+	"sync"
+)
 
 // drawListPool provides efficient reuse of DrawList buffers.
 // This avoids allocations on every frame, which is critical for
@@ -43,6 +46,13 @@ type DrawList struct {
 	textureID    uint32       // Current texture for batching
 	cmdOffset    uint32       // Vertex offset for current command
 	idxCmdOffset uint32       // Index offset for current command
+
+	damage  Rect // Area changed since the previous frame (see DamageRect)
+	damaged bool // False when nothing changed
+
+	// AntiAliasedLines makes AddLine draw feathered lines (see AddLineAA).
+	// Clear turns it off; the GUI sets it from Style.AntiAliasedLines.
+	AntiAliasedLines bool
 }
 
 // Clear resets the DrawList for a new frame.
@@ -56,6 +66,22 @@ func (dl *DrawList) Clear() {
 	dl.textureID = 0
 	dl.cmdOffset = 0
 	dl.idxCmdOffset = 0
+	dl.damage = Rect{X: -1e9, Y: -1e9, W: 2e9, H: 2e9} // Unknown: everything
+	dl.damaged = true
+	dl.AntiAliasedLines = false
+}
+
+// DamageRect returns the screen area whose pixels may differ from the
+// previous frame, and false if nothing changed. With WithDamageTracking,
+// GUI.End fills it in before rendering by comparing every triangle with the
+// previous frame's, so hover and focus changes and widgets that appeared or
+// disappeared are all included. The rect is conservative (never too small)
+// and covers both the main and foreground lists, so a backend can set it as
+// the scissor for the whole frame and skip clearing and drawing outside it.
+// Without tracking, and for lists not drawn through GUI, it reports the
+// whole surface.
+func (dl *DrawList) DamageRect() (Rect, bool) {
+	return dl.damage, dl.damaged
 }
 
 // PushClipRect pushes a new clip rectangle onto the stack.
@@ -153,6 +179,36 @@ func (dl *DrawList) AddRect(x, y, w, h float32, color uint32) {
 	dl.addIndices(idx, idx+1, idx+2, idx, idx+2, idx+3)
 }
 
+// AddRectMultiColor draws a filled rectangle with a color per corner, which
+// the renderer interpolates across the quad (gradients). Corners are given
+// clockwise from the top left.
+func (dl *DrawList) AddRectMultiColor(x, y, w, h float32, topLeft, topRight, bottomRight, bottomLeft uint32) {
+	if (topLeft|topRight|bottomRight|bottomLeft)&0xFF000000 == 0 { // Skip fully transparent
+		return
+	}
+
+	idx := dl.addVertices(
+		Vertex{Pos: [2]float32{x, y}, Color: topLeft},
+		Vertex{Pos: [2]float32{x + w, y}, Color: topRight},
+		Vertex{Pos: [2]float32{x + w, y + h}, Color: bottomRight},
+		Vertex{Pos: [2]float32{x, y + h}, Color: bottomLeft},
+	)
+
+	dl.addIndices(idx, idx+1, idx+2, idx, idx+2, idx+3)
+}
+
+// AddRectGradientV draws a filled rectangle shading from topColor at the
+// top edge to bottomColor at the bottom edge.
+func (dl *DrawList) AddRectGradientV(x, y, w, h float32, topColor, bottomColor uint32) {
+	dl.AddRectMultiColor(x, y, w, h, topColor, topColor, bottomColor, bottomColor)
+}
+
+// AddRectGradientH draws a filled rectangle shading from leftColor at the
+// left edge to rightColor at the right edge.
+func (dl *DrawList) AddRectGradientH(x, y, w, h float32, leftColor, rightColor uint32) {
+	dl.AddRectMultiColor(x, y, w, h, leftColor, rightColor, rightColor, leftColor)
+}
+
 // AddRectOutline draws a rectangle outline.
 func (dl *DrawList) AddRectOutline(x, y, w, h float32, color uint32, thickness float32) {
 	if color&0xFF000000 == 0 {
@@ -170,11 +226,16 @@ func (dl *DrawList) AddRectOutline(x, y, w, h float32, color uint32, thickness f
 }
 
 // AddLine draws a line between two points.
-// Uses a quad to create thickness.
+// Uses a quad to create thickness, or AddLineAA's feathered quads when
+// AntiAliasedLines is set.
 func (dl *DrawList) AddLine(x1, y1, x2, y2 float32, color uint32, thickness float32) {
 	if color&0xFF000000 == 0 {
 		return
 	}
+	if dl.AntiAliasedLines {
+		dl.AddLineAA(x1, y1, x2, y2, color, thickness)
+		return
+	}
 
 	// Calculate perpendicular direction for thickness
 	dx := x2 - x1
@@ -198,6 +259,46 @@ func (dl *DrawList) AddLine(x1, y1, x2, y2 float32, color uint32, thickness floa
 	dl.addIndices(idx, idx+1, idx+2, idx, idx+2, idx+3)
 }
 
+// lineFringe is the width of the alpha ramp along each edge of an
+// anti-aliased line.
+const lineFringe = float32(1)
+
+// AddLineAA draws a line between two points with feathered edges, so it
+// looks smooth at any angle and at non-integer positions: a solid core
+// thickness-1 wide between two 1-pixel fringes that fade to transparent.
+// Lines 1 pixel or thinner are just the two fringes. It emits 8 vertices
+// and 6 triangles, against 4 and 2 for an aliased line.
+func (dl *DrawList) AddLineAA(x1, y1, x2, y2 float32, color uint32, thickness float32) {
+	if color&0xFF000000 == 0 {
+		return
+	}
+
+	// Unit normal to the line
+	var nx, ny float32
+	if l := vecLen(Vec2{X: x2 - x1, Y: y2 - y1}); l > 0 {
+		nx, ny = -(y2-y1)/l, (x2-x1)/l
+	}
+	core := maxf(thickness-lineFringe, 0) * 0.5
+	outer := core + lineFringe
+	transparent := color & 0x00FFFFFF
+
+	// Across each end: outer edge, core edge, core edge, outer edge
+	idx := dl.addVertices(
+		Vertex{Pos: [2]float32{x1 + nx*outer, y1 + ny*outer}, Color: transparent},
+		Vertex{Pos: [2]float32{x1 + nx*core, y1 + ny*core}, Color: color},
+		Vertex{Pos: [2]float32{x1 - nx*core, y1 - ny*core}, Color: color},
+		Vertex{Pos: [2]float32{x1 - nx*outer, y1 - ny*outer}, Color: transparent},
+		Vertex{Pos: [2]float32{x2 + nx*outer, y2 + ny*outer}, Color: transparent},
+		Vertex{Pos: [2]float32{x2 + nx*core, y2 + ny*core}, Color: color},
+		Vertex{Pos: [2]float32{x2 - nx*core, y2 - ny*core}, Color: color},
+		Vertex{Pos: [2]float32{x2 - nx*outer, y2 - ny*outer}, Color: transparent},
+	)
+	for i := range uint16(3) {
+		a, b := idx+i, idx+4+i
+		dl.addIndices(a, b, b+1, a, b+1, a+1)
+	}
+}
+
 // AddTriangle draws a filled triangle.
 func (dl *DrawList) AddTriangle(x1, y1, x2, y2, x3, y3 float32, color uint32) {
 	if color&0xFF000000 == 0 {
@@ -213,6 +314,309 @@ func (dl *DrawList) AddTriangle(x1, y1, x2, y2, x3, y3 float32, color uint32) {
 	dl.addIndices(idx, idx+1, idx+2)
 }
 
+// AddConvexPolyFilled draws a filled convex polygon as a triangle fan.
+// Points may be in either winding order; fewer than three draw nothing.
+func (dl *DrawList) AddConvexPolyFilled(points []Vec2, color uint32) {
+	if color&0xFF000000 == 0 || len(points) < 3 {
+		return
+	}
+
+	verts := make([]Vertex, len(points))
+	for i, p := range points {
+		verts[i] = Vertex{Pos: [2]float32{p.X, p.Y}, Color: color}
+	}
+	idx := dl.addVertices(verts...)
+
+	for i := uint16(1); i+1 < uint16(len(points)); i++ {
+		dl.addIndices(idx, idx+i, idx+i+1)
+	}
+}
+
+// AddCircle draws a filled circle, like AddRect fills a rectangle; it is
+// the same as AddCircleFilled. Use AddCircleOutline for a ring. (Note that
+// ImGui's AddCircle draws the outline.)
+func (dl *DrawList) AddCircle(cx, cy, radius float32, color uint32, segments int) {
+	dl.AddCircleFilled(cx, cy, radius, color, segments)
+}
+
+// AddCircleFilled draws a filled circle as a triangle fan. segments <= 0
+// picks a count from the radius.
+func (dl *DrawList) AddCircleFilled(cx, cy, radius float32, color uint32, segments int) {
+	if color&0xFF000000 == 0 || radius <= 0 {
+		return
+	}
+	segments = circleSegments(radius, segments)
+
+	idx := dl.addVertices(Vertex{Pos: [2]float32{cx, cy}, Color: color})
+	for i := range segments {
+		x, y := circlePoint(cx, cy, radius, i, segments)
+		dl.addVertices(Vertex{Pos: [2]float32{x, y}, Color: color})
+	}
+	for i := range uint16(segments) {
+		dl.addIndices(idx, idx+1+i, idx+1+(i+1)%uint16(segments))
+	}
+}
+
+// AddCircleOutline draws a ring whose outer edge lies on the circle, like
+// AddRectOutline keeps its border inside the rectangle. segments <= 0
+// picks a count from the radius.
+func (dl *DrawList) AddCircleOutline(cx, cy, radius float32, color uint32, segments int, thickness float32) {
+	if color&0xFF000000 == 0 || radius <= 0 {
+		return
+	}
+	segments = circleSegments(radius, segments)
+	inner := maxf(radius-thickness, 0)
+
+	// Outer and inner vertex for each segment, joined into quads
+	var idx uint16
+	for i := range segments {
+		ox, oy := circlePoint(cx, cy, radius, i, segments)
+		ix, iy := circlePoint(cx, cy, inner, i, segments)
+		first := dl.addVertices(
+			Vertex{Pos: [2]float32{ox, oy}, Color: color},
+			Vertex{Pos: [2]float32{ix, iy}, Color: color},
+		)
+		if i == 0 {
+			idx = first
+		}
+	}
+	for i := range uint16(segments) {
+		a, b := idx+i*2, idx+(i+1)%uint16(segments)*2
+		dl.addIndices(a, b, b+1, a, b+1, a+1)
+	}
+}
+
+// circleSegments returns segments, or when it is <= 0 a count for radius
+// keeping each side about 4 pixels long.
+func circleSegments(radius float32, segments int) int {
+	if segments > 0 {
+		return max(segments, 3)
+	}
+	n := int(math.Ceil(2 * math.Pi * float64(radius) / 4))
+	return min(max(n, 12), 64)
+}
+
+// circlePoint returns point i of a circle divided into segments.
+func circlePoint(cx, cy, radius float32, i, segments int) (x, y float32) {
+	angle := float64(i) * 2 * math.Pi / float64(segments)
+	return cx + radius*float32(math.Cos(angle)), cy + radius*float32(math.Sin(angle))
+}
+
+// AddBezierCubic draws a cubic Bézier curve from p0 to p3 with control
+// points p1 and p2, flattened into segments line pieces joined without
+// gaps. segments <= 0 picks a count from the curve's length. Vertices go
+// straight into the buffers, so nothing is allocated per curve.
+func (dl *DrawList) AddBezierCubic(p0, p1, p2, p3 Vec2, color uint32, thickness float32, segments int) {
+	if color&0xFF000000 == 0 {
+		return
+	}
+	if segments <= 0 {
+		// The control polygon is at least as long as the curve
+		length := vecLen(p1.Sub(p0)) + vecLen(p2.Sub(p1)) + vecLen(p3.Sub(p2))
+		segments = bezierSegments(length)
+	}
+
+	// Two vertices per point, offset along the normal of the curve's
+	// direction there, joined into quads
+	half := thickness * 0.5
+	var idx uint16
+	for i := 0; i <= segments; i++ {
+		t := float32(i) / float32(segments)
+		p := bezierCubicPoint(p0, p1, p2, p3, t)
+		d := bezierCubicTangent(p0, p1, p2, p3, t)
+		if d == (Vec2{}) {
+			d = p3.Sub(p0) // Coincident control points: use the chord
+		}
+		n := Vec2{}
+		if l := vecLen(d); l > 0 {
+			n = Vec2{X: -d.Y / l * half, Y: d.X / l * half}
+		}
+		first := dl.addVertices(
+			Vertex{Pos: [2]float32{p.X + n.X, p.Y + n.Y}, Color: color},
+			Vertex{Pos: [2]float32{p.X - n.X, p.Y - n.Y}, Color: color},
+		)
+		if i == 0 {
+			idx = first
+		}
+	}
+	for i := range uint16(segments) {
+		a, b := idx+i*2, idx+(i+1)*2
+		dl.addIndices(a, b, b+1, a, b+1, a+1)
+	}
+}
+
+// bezierSegments returns a segment count for a curve about length pixels
+// long, keeping each piece about 4 pixels long.
+func bezierSegments(length float32) int {
+	return min(max(int(length/4), 8), 64)
+}
+
+// bezierCubicPoint returns the point at t (0-1) of a cubic Bézier curve.
+func bezierCubicPoint(p0, p1, p2, p3 Vec2, t float32) Vec2 {
+	u := 1 - t
+	return p0.Mul(u * u * u).Add(p1.Mul(3 * u * u * t)).Add(p2.Mul(3 * u * t * t)).Add(p3.Mul(t * t * t))
+}
+
+// bezierCubicTangent returns the derivative at t of a cubic Bézier curve.
+func bezierCubicTangent(p0, p1, p2, p3 Vec2, t float32) Vec2 {
+	u := 1 - t
+	return p1.Sub(p0).Mul(3 * u * u).Add(p2.Sub(p1).Mul(6 * u * t)).Add(p3.Sub(p2).Mul(3 * t * t))
+}
+
+// vecLen returns the length of v.
+func vecLen(v Vec2) float32 {
+	return float32(math.Hypot(float64(v.X), float64(v.Y)))
+}
+
+// AddRectRounded draws a filled rectangle with all four corners rounded
+// by radius (clamped to half the shorter side). A radius of 0 draws the
+// same quad as AddRect.
+func (dl *DrawList) AddRectRounded(x, y, w, h float32, color uint32, radius float32) {
+	dl.AddRectRoundedCorners(x, y, w, h, color, radius, radius, radius, radius)
+}
+
+// AddRectRoundedCorners draws a filled rectangle with a radius per corner,
+// given clockwise from the top left. Corners with radius 0 stay sharp, e.g.
+// a panel header rounds only its top corners.
+func (dl *DrawList) AddRectRoundedCorners(x, y, w, h float32, color uint32, topLeft, topRight, bottomRight, bottomLeft float32) {
+	if color&0xFF000000 == 0 {
+		return
+	}
+	radii := clampCornerRadii(w, h, [4]float32{topLeft, topRight, bottomRight, bottomLeft})
+	if radii == [4]float32{} {
+		dl.AddRect(x, y, w, h, color)
+		return
+	}
+	dl.AddConvexPolyFilled(roundedRectPath(nil, x, y, w, h, radii, cornerSegments(radii)), color)
+}
+
+// AddRectRoundedGradientV draws a filled rounded rectangle shading from
+// topColor at the top edge to bottomColor at the bottom edge.
+func (dl *DrawList) AddRectRoundedGradientV(x, y, w, h float32, topColor, bottomColor uint32, radius float32) {
+	dl.AddRectRoundedCornersGradientV(x, y, w, h, topColor, bottomColor, radius, radius, radius, radius)
+}
+
+// AddRectRoundedCornersGradientV is AddRectRoundedCorners with the vertical
+// shading of AddRectRoundedGradientV.
+func (dl *DrawList) AddRectRoundedCornersGradientV(x, y, w, h float32, topColor, bottomColor uint32, topLeft, topRight, bottomRight, bottomLeft float32) {
+	if (topColor|bottomColor)&0xFF000000 == 0 {
+		return
+	}
+	radii := clampCornerRadii(w, h, [4]float32{topLeft, topRight, bottomRight, bottomLeft})
+	if radii == [4]float32{} {
+		dl.AddRectGradientV(x, y, w, h, topColor, bottomColor)
+		return
+	}
+	pts := roundedRectPath(nil, x, y, w, h, radii, cornerSegments(radii))
+	idx := dl.addVertices(gradientVertices(pts, y, h, topColor, bottomColor)...)
+	for i := uint16(1); i+1 < uint16(len(pts)); i++ {
+		dl.addIndices(idx, idx+i, idx+i+1)
+	}
+}
+
+// gradientVertices returns a vertex for each point, colored by its height
+// between topColor at y and bottomColor at y+h.
+func gradientVertices(pts []Vec2, y, h float32, topColor, bottomColor uint32) []Vertex {
+	verts := make([]Vertex, len(pts))
+	for i, p := range pts {
+		t := float32(0)
+		if h > 0 {
+			t = clampf((p.Y-y)/h, 0, 1)
+		}
+		verts[i] = Vertex{Pos: [2]float32{p.X, p.Y}, Color: lerpColor(topColor, bottomColor, t)}
+	}
+	return verts
+}
+
+// AddRectRoundedOutline draws the outline of a rounded rectangle. Like
+// AddRectOutline the border lies inside the rectangle; its inner edge is
+// rounded by radius minus thickness. A radius of 0 draws AddRectOutline.
+func (dl *DrawList) AddRectRoundedOutline(x, y, w, h float32, color uint32, radius, thickness float32) {
+	if color&0xFF000000 == 0 {
+		return
+	}
+	radii := clampCornerRadii(w, h, [4]float32{radius, radius, radius, radius})
+	if radii == [4]float32{} {
+		dl.AddRectOutline(x, y, w, h, color, thickness)
+		return
+	}
+	if thickness*2 >= minf(w, h) {
+		dl.AddRectRounded(x, y, w, h, color, radius)
+		return
+	}
+
+	// Inner and outer paths share the segment counts, so their points pair up
+	segs := cornerSegments(radii)
+	var inner [4]float32
+	for i, r := range radii {
+		inner[i] = maxf(r-thickness, 0)
+	}
+	outerPts := roundedRectPath(nil, x, y, w, h, radii, segs)
+	innerPts := roundedRectPath(nil, x+thickness, y+thickness, w-thickness*2, h-thickness*2, inner, segs)
+
+	verts := make([]Vertex, 0, len(outerPts)*2)
+	for i := range outerPts {
+		verts = append(verts,
+			Vertex{Pos: [2]float32{outerPts[i].X, outerPts[i].Y}, Color: color},
+			Vertex{Pos: [2]float32{innerPts[i].X, innerPts[i].Y}, Color: color},
+		)
+	}
+	idx := dl.addVertices(verts...)
+	n := uint16(len(outerPts))
+	for i := range n {
+		a, b := idx+i*2, idx+(i+1)%n*2
+		dl.addIndices(a, b, b+1, a, b+1, a+1)
+	}
+}
+
+// clampCornerRadii limits each radius to half the shorter side of a w×h
+// rectangle, so opposite corners never overlap.
+func clampCornerRadii(w, h float32, radii [4]float32) [4]float32 {
+	limit := maxf(minf(w, h)/2, 0)
+	for i, r := range radii {
+		radii[i] = clampf(r, 0, limit)
+	}
+	return radii
+}
+
+// cornerSegments returns the arc steps for each corner: a quarter of a
+// circle's default segments, or 0 for a sharp corner.
+func cornerSegments(radii [4]float32) [4]int {
+	var segs [4]int
+	for i, r := range radii {
+		if r > 0 {
+			segs[i] = circleSegments(r, 0) / 4
+		}
+	}
+	return segs
+}
+
+// roundedRectPath appends the outline of a rounded rectangle to pts,
+// clockwise from the top-left corner. Each corner contributes segs+1
+// points along its arc, or a single point when segs is 0.
+func roundedRectPath(pts []Vec2, x, y, w, h float32, radii [4]float32, segs [4]int) []Vec2 {
+	centers := [4]Vec2{
+		{X: x + radii[0], Y: y + radii[0]},
+		{X: x + w - radii[1], Y: y + radii[1]},
+		{X: x + w - radii[2], Y: y + h - radii[2]},
+		{X: x + radii[3], Y: y + h - radii[3]},
+	}
+	for i, c := range centers {
+		start := math.Pi + float64(i)*math.Pi/2
+		for k := 0; k <= segs[i]; k++ {
+			angle := start
+			if segs[i] > 0 {
+				angle += float64(k) / float64(segs[i]) * math.Pi / 2
+			}
+			pts = append(pts, Vec2{
+				X: c.X + radii[i]*float32(math.Cos(angle)),
+				Y: c.Y + radii[i]*float32(math.Sin(angle)),
+			})
+		}
+	}
+	return pts
+}
+
 // AddText draws text at the specified position.
 // fontScale is typically 1.0 for normal size.
 // charWidth and charHeight define the size of each character cell.
@@ -317,40 +721,83 @@ func (dl *DrawList) InsertRect(x, y, w, h float32, color uint32) {
 		return
 	}
 
-	// Create the vertices
-	verts := []Vertex{
+	dl.insertShape([]Vertex{
 		{Pos: [2]float32{x, y}, Color: color},
 		{Pos: [2]float32{x + w, y}, Color: color},
 		{Pos: [2]float32{x + w, y + h}, Color: color},
 		{Pos: [2]float32{x, y + h}, Color: color},
+	}, []uint16{0, 1, 2, 0, 2, 3})
+}
+
+// InsertRectRounded inserts a rounded rectangle at the beginning of the
+// draw list, like InsertRect. A radius of 0 inserts the same quad.
+func (dl *DrawList) InsertRectRounded(x, y, w, h float32, color uint32, radius float32) {
+	if color&0xFF000000 == 0 {
+		return
+	}
+	radii := clampCornerRadii(w, h, [4]float32{radius, radius, radius, radius})
+	if radii == [4]float32{} {
+		dl.InsertRect(x, y, w, h, color)
+		return
 	}
 
-	// Insert at beginning
-	dl.VtxBuffer = append(verts, dl.VtxBuffer...)
+	pts := roundedRectPath(nil, x, y, w, h, radii, cornerSegments(radii))
+	verts := make([]Vertex, len(pts))
+	for i, p := range pts {
+		verts[i] = Vertex{Pos: [2]float32{p.X, p.Y}, Color: color}
+	}
+	indices := make([]uint16, 0, (len(pts)-2)*3)
+	for i := uint16(1); i+1 < uint16(len(pts)); i++ {
+		indices = append(indices, 0, i, i+1)
+	}
+	dl.insertShape(verts, indices)
+}
 
-	// Indices for the new rect (these are absolute since VertexOffset=0)
-	newIndices := []uint16{0, 1, 2, 0, 2, 3}
+// InsertRectRoundedGradientV inserts a rounded rectangle shading from
+// topColor to bottomColor at the beginning of the draw list, like
+// InsertRectRounded.
+func (dl *DrawList) InsertRectRoundedGradientV(x, y, w, h float32, topColor, bottomColor uint32, radius float32) {
+	if (topColor|bottomColor)&0xFF000000 == 0 {
+		return
+	}
+	radii := clampCornerRadii(w, h, [4]float32{radius, radius, radius, radius})
+	pts := []Vec2{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+	if radii != [4]float32{} {
+		pts = roundedRectPath(nil, x, y, w, h, radii, cornerSegments(radii))
+	}
+	indices := make([]uint16, 0, (len(pts)-2)*3)
+	for i := uint16(1); i+1 < uint16(len(pts)); i++ {
+		indices = append(indices, 0, i, i+1)
+	}
+	dl.insertShape(gradientVertices(pts, y, h, topColor, bottomColor), indices)
+}
+
+// insertShape inserts vertices and indices (relative to the first vertex)
+// at the beginning of the draw list as their own command.
+func (dl *DrawList) insertShape(verts []Vertex, indices []uint16) {
+	nVtx, nIdx := uint32(len(verts)), uint32(len(indices))
 
-	// Insert new indices at beginning
-	dl.IdxBuffer = append(newIndices, dl.IdxBuffer...)
+	// Insert at beginning
+	dl.VtxBuffer = append(verts, dl.VtxBuffer...)
+	dl.IdxBuffer = append(indices, dl.IdxBuffer...)
 
 	// Update command offsets - indices are relative to VertexOffset,
 	// so we only need to shift VertexOffset and IndexOffset.
 	// DO NOT modify the index values themselves - they're relative indices
 	// that work with DrawElementsBaseVertex.
 	for i := range dl.CmdBuffer {
-		dl.CmdBuffer[i].VertexOffset += 4
-		dl.CmdBuffer[i].IndexOffset += 6
+		dl.CmdBuffer[i].VertexOffset += nVtx
+		dl.CmdBuffer[i].IndexOffset += nIdx
 	}
 
 	// Also update the tracking offsets so that subsequent SetTexture calls
 	// correctly calculate ElemCount for any pending command.
-	dl.cmdOffset += 4
-	dl.idxCmdOffset += 6
+	dl.cmdOffset += nVtx
+	dl.idxCmdOffset += nIdx
 
 	// Insert a new command at the beginning for the background
 	bgCmd := DrawCmd{
-		ElemCount:    6,
+		ElemCount:    nIdx,
 		ClipRect:     dl.currentClip,
 		TextureID:    0,
 		VertexOffset: 0,
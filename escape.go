@@ -0,0 +1,91 @@
+package gui
+
+import "slices"
+
+// escapeLevel ranks what a press of Escape closes. Exactly one level handles
+// each press: the highest one that had a claimant on the previous frame.
+type escapeLevel uint8
+
+const (
+	escapeNone  escapeLevel = iota
+	escapeModal             // An open ModalMenu or PanelGroup
+	escapePopup             // The active popup (dropdown, menu)
+	escapeText              // A text field being edited
+)
+
+// escapeTarget is the element a frame's Escape press is meant for.
+type escapeTarget struct {
+	level escapeLevel
+	id    ID
+}
+
+// claimEscape registers id as wanting next frame's Escape at level. Widgets
+// call it every frame they would react to Escape: text fields while editing,
+// popups while open, modals while shown. Widgets drawn with WithNoEscape()
+// don't claim, so the press falls through to the next level.
+//
+// For text fields and modals the last claimant wins; for popups only the
+// innermost active popup (ActivePopupID at the end of the frame) qualifies.
+func (ctx *Context) claimEscape(level escapeLevel, id ID) {
+	switch level {
+	case escapePopup:
+		ctx.escapePopupClaims = append(ctx.escapePopupClaims, id)
+	case escapeText, escapeModal:
+		ctx.escapeClaims[level] = id
+	}
+}
+
+// escapeFor reports whether this frame's Escape press is for id at level,
+// and marks it handled so no other element reacts to it.
+func (ctx *Context) escapeFor(level escapeLevel, id ID) bool {
+	if !ctx.escapeTargets(level, id) {
+		return false
+	}
+	ctx.escapeHandled = true
+	return true
+}
+
+// escapeTargets is escapeFor without handling the press.
+func (ctx *Context) escapeTargets(level escapeLevel, id ID) bool {
+	return !ctx.escapeHandled && ctx.escapeTarget.level == level && ctx.escapeTarget.id == id
+}
+
+// beginEscape picks the target of this frame's Escape press from the
+// previous frame's claims. Called from Reset, before the popup is cleared.
+func (ctx *Context) beginEscape() {
+	if ctx.activePopupID != 0 && slices.Contains(ctx.escapePopupClaims, ctx.activePopupID) {
+		ctx.escapeClaims[escapePopup] = ctx.activePopupID
+	}
+
+	ctx.escapeTarget = escapeTarget{}
+	if ctx.Input != nil && ctx.Input.KeyPressed(KeyEscape) {
+		for level := escapeText; level > escapeNone; level-- {
+			if id := ctx.escapeClaims[level]; id != 0 {
+				ctx.escapeTarget = escapeTarget{level: level, id: id}
+				break
+			}
+		}
+	}
+
+	ctx.escapeClaims = [escapeText + 1]ID{}
+	ctx.escapePopupClaims = ctx.escapePopupClaims[:0]
+	ctx.escapeHandled = false
+	ctx.EscapeUnhandled = false
+}
+
+// HandleEscape ends the frame's Escape handling; GUI.End calls it. Escape
+// closes the topmost interactive element, one per press, in this order:
+//
+//  1. a text field being edited stops editing
+//  2. otherwise the active popup (dropdown, menu) closes
+//  3. otherwise an open ModalMenu or PanelGroup closes
+//
+// When nothing took the press, EscapeUnhandled is set so the application can
+// act on it (leave a mode, open a pause menu, ...).
+func (ctx *Context) HandleEscape() {
+	if ctx.Input == nil || !ctx.Input.KeyPressed(KeyEscape) || ctx.escapeHandled {
+		return
+	}
+	ctx.escapeHandled = true
+	ctx.EscapeUnhandled = true
+}
@@ -69,6 +69,17 @@ func (fm *FocusManager) FocusPanel(panel Panel) {
 	}
 }
 
+// setFocused focuses a panel without changing focus ring visibility.
+// Used when a panel is raised by clicking it.
+func (fm *FocusManager) setFocused(panel Panel) {
+	for i, entry := range fm.getOpenPanels() {
+		if entry.Panel == panel {
+			fm.focusedIndex = i
+			return
+		}
+	}
+}
+
 // FocusPanelByName focuses a specific panel by name.
 func (fm *FocusManager) FocusPanelByName(name string) {
 	openPanels := fm.getOpenPanels()
@@ -72,6 +72,10 @@ type FocusRegistry struct {
 	// scopeStack tracks nested focus scopes (containers)
 	scopeStack []FocusScopeEntry
 
+	// Double-buffered closed scopes, used to confine navigation to a scope
+	prevScopes []FocusScopeEntry
+	scopes     []FocusScopeEntry
+
 	// pendingFocusID is set when focus should change next frame
 	pendingFocusID ID
 
@@ -94,6 +98,7 @@ type FocusScopeEntry struct {
 	Type         FocusType
 	Rect         Rect
 	StartIdx     int // Index of first child in items
+	EndIdx       int // One past the last child in items (set by EndScope)
 	FocusedChild int // Which child has focus (-1 = none)
 }
 
@@ -127,6 +132,8 @@ func NewFocusRegistry() *FocusRegistry {
 		items:           make([]FocusableItem, 0, 64),
 		currentFocusIdx: -1,
 		scopeStack:      make([]FocusScopeEntry, 0, 8),
+		prevScopes:      make([]FocusScopeEntry, 0, 8),
+		scopes:          make([]FocusScopeEntry, 0, 8),
 	}
 }
 
@@ -151,6 +158,8 @@ func (r *FocusRegistry) ResetForFrame(frameNumber uint64) {
 	// Swap buffers: current items become previous, then clear current
 	r.prevItems, r.items = r.items, r.prevItems
 	r.items = r.items[:0]
+	r.prevScopes, r.scopes = r.scopes, r.prevScopes
+	r.scopes = r.scopes[:0]
 	r.currentFocusIdx = -1
 	r.scopeStack = r.scopeStack[:0]
 
@@ -251,6 +260,8 @@ func (r *FocusRegistry) EndScope() FocusScopeEntry {
 
 	entry := r.scopeStack[n-1]
 	r.scopeStack = r.scopeStack[:n-1]
+	entry.EndIdx = len(r.items)
+	r.scopes = append(r.scopes, entry)
 
 	// Find which child had focus (check current items being built)
 	for i := entry.StartIdx; i < len(r.items); i++ {
@@ -329,18 +340,88 @@ func (r *FocusRegistry) MarkKeyboardNavigated() {
 // Uses the previous frame's items for navigation (double-buffered).
 // Sets keyboardNavigated flag on success, enabling auto-scroll in Scrollable.
 func (r *FocusRegistry) Navigate(dir NavDirection) bool {
-	if len(r.prevItems) == 0 {
+	return r.navigateRange(dir, 0, len(r.prevItems))
+}
+
+// NavigateWithin moves focus in the given direction, considering only items
+// registered inside the scope with the given ID (including nested scopes).
+// If focus is currently outside the scope, the scope's first focusable item
+// is focused. Returns false if the scope was not registered last frame.
+func (r *FocusRegistry) NavigateWithin(scopeID ID, dir NavDirection) bool {
+	for _, scope := range r.prevScopes {
+		if scope.ID == scopeID {
+			return r.navigateRange(dir, scope.StartIdx, scope.EndIdx)
+		}
+	}
+	return false
+}
+
+// NavigateTab moves focus to the next (or previous) focusable item in
+// registration order, wrapping around at the ends.
+func (r *FocusRegistry) NavigateTab(forward bool) bool {
+	return r.navigateTabRange(forward, 0, len(r.prevItems))
+}
+
+// NavigateTabWithin is NavigateTab confined to the scope with the given ID.
+// Returns false if the scope was not registered last frame.
+func (r *FocusRegistry) NavigateTabWithin(scopeID ID, forward bool) bool {
+	for _, scope := range r.prevScopes {
+		if scope.ID == scopeID {
+			return r.navigateTabRange(forward, scope.StartIdx, scope.EndIdx)
+		}
+	}
+	return false
+}
+
+// navigateTabRange implements NavigateTab over prevItems[lo:hi].
+func (r *FocusRegistry) navigateTabRange(forward bool, lo, hi int) bool {
+	if hi > len(r.prevItems) {
+		hi = len(r.prevItems)
+	}
+	n := hi - lo
+	if n <= 0 {
+		return false
+	}
+
+	delta := 1
+	start := lo - 1 // No focus in range: forward starts at the first item
+	if !forward {
+		delta = -1
+		start = hi // ... and backward at the last
+	}
+	if r.currentFocusIdx >= lo && r.currentFocusIdx < hi {
+		start = r.currentFocusIdx
+	}
+
+	for step := 1; step <= n; step++ {
+		i := lo + ((start-lo+delta*step)%n+n)%n
+		if r.prevItems[i].CanFocus && i != r.currentFocusIdx {
+			r.setFocusByIndex(i)
+			r.keyboardNavigated = true
+			return true
+		}
+	}
+	return false
+}
+
+// navigateRange implements Navigate over prevItems[lo:hi].
+func (r *FocusRegistry) navigateRange(dir NavDirection, lo, hi int) bool {
+	if hi > len(r.prevItems) {
+		hi = len(r.prevItems)
+	}
+	if lo >= hi {
 		focusLogger.Debug("Navigate: no prevItems available (widgets not registered yet)")
 		return false
 	}
 
 	// Find current focus index
 	currentIdx := r.currentFocusIdx
-	if currentIdx < 0 {
-		// No current focus - focus first focusable item
+	if currentIdx < lo || currentIdx >= hi {
+		// No current focus in range - focus first focusable item
 		focusLogger.Debug("Navigate: no current focus, looking for first focusable",
-			"prevItemsCount", len(r.prevItems))
-		for i, item := range r.prevItems {
+			"prevItemsCount", hi-lo)
+		for i := lo; i < hi; i++ {
+			item := &r.prevItems[i]
 			if item.CanFocus {
 				focusLogger.Debug("Navigate: auto-focusing first widget",
 					"idx", i, "name", item.Name, "id", item.ID)
@@ -354,9 +435,6 @@ func (r *FocusRegistry) Navigate(dir NavDirection) bool {
 	}
 
 	// Check for custom navigation target
-	if currentIdx >= len(r.prevItems) {
-		return false
-	}
 	current := &r.prevItems[currentIdx]
 	var targetID ID
 	switch dir {
@@ -372,8 +450,8 @@ func (r *FocusRegistry) Navigate(dir NavDirection) bool {
 
 	if targetID != 0 {
 		// Use custom target
-		for i, item := range r.prevItems {
-			if item.ID == targetID && item.CanFocus {
+		for i := lo; i < hi; i++ {
+			if item := &r.prevItems[i]; item.ID == targetID && item.CanFocus {
 				r.setFocusByIndex(i)
 				r.keyboardNavigated = true
 				return true
@@ -385,9 +463,9 @@ func (r *FocusRegistry) Navigate(dir NavDirection) bool {
 	var success bool
 	switch dir {
 	case NavUp, NavDown:
-		success = r.navigateVertical(dir)
+		success = r.navigateVertical(dir, lo, hi)
 	case NavLeft, NavRight:
-		success = r.navigateHorizontal(dir)
+		success = r.navigateHorizontal(dir, lo, hi)
 	}
 
 	if success {
@@ -398,9 +476,9 @@ func (r *FocusRegistry) Navigate(dir NavDirection) bool {
 
 // navigateVertical handles up/down navigation.
 // Uses prevItems for navigation (double-buffered).
-func (r *FocusRegistry) navigateVertical(dir NavDirection) bool {
+func (r *FocusRegistry) navigateVertical(dir NavDirection, lo, hi int) bool {
 	currentIdx := r.currentFocusIdx
-	if currentIdx < 0 || currentIdx >= len(r.prevItems) {
+	if currentIdx < lo || currentIdx >= hi {
 		focusLogger.Debug("navigateVertical: invalid index", "currentIdx", currentIdx, "prevItemsLen", len(r.prevItems))
 		return false
 	}
@@ -414,7 +492,7 @@ func (r *FocusRegistry) navigateVertical(dir NavDirection) bool {
 	focusLogger.Debug("navigateVertical: from", "idx", currentIdx, "name", r.prevItems[currentIdx].Name, "delta", delta)
 
 	// Find next focusable item
-	for i := currentIdx + delta; i >= 0 && i < len(r.prevItems); i += delta {
+	for i := currentIdx + delta; i >= lo && i < hi; i += delta {
 		item := &r.prevItems[i]
 		focusLogger.Debug("navigateVertical: checking", "idx", i, "name", item.Name, "canFocus", item.CanFocus)
 		if item.CanFocus {
@@ -430,9 +508,9 @@ func (r *FocusRegistry) navigateVertical(dir NavDirection) bool {
 
 // navigateHorizontal handles left/right navigation.
 // Uses prevItems for navigation (double-buffered).
-func (r *FocusRegistry) navigateHorizontal(dir NavDirection) bool {
+func (r *FocusRegistry) navigateHorizontal(dir NavDirection, lo, hi int) bool {
 	currentIdx := r.currentFocusIdx
-	if currentIdx < 0 || currentIdx >= len(r.prevItems) {
+	if currentIdx < lo || currentIdx >= hi {
 		return false
 	}
 
@@ -442,7 +520,8 @@ func (r *FocusRegistry) navigateHorizontal(dir NavDirection) bool {
 	bestIdx := -1
 	bestDist := float32(1e9)
 
-	for i, item := range r.prevItems {
+	for i := lo; i < hi; i++ {
+		item := &r.prevItems[i]
 		if i == currentIdx || !item.CanFocus {
 			continue
 		}
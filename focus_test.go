@@ -367,3 +367,181 @@ func TestFocusRegistry_NavigateAcrossFrames(t *testing.T) {
 		t.Errorf("Expected focus on ID 2 (Button1), got %d", registry.CurrentFocusID())
 	}
 }
+
+// TestFocusRegistry_NavigateWithinScope verifies that navigation confined to a
+// scope (e.g. an open popup) never leaves the scope's items.
+func TestFocusRegistry_NavigateWithinScope(t *testing.T) {
+	registry := NewFocusRegistry()
+
+	registry.Register(1, "Background", Rect{X: 0, Y: 0, W: 100, H: 20}, FocusTypeLeaf)
+	registry.BeginScope(100, "Popup", FocusTypeContainer, Rect{X: 0, Y: 30, W: 100, H: 60})
+	registry.Register(2, "Item1", Rect{X: 0, Y: 30, W: 100, H: 20}, FocusTypeLeaf)
+	registry.Register(3, "Item2", Rect{X: 0, Y: 55, W: 100, H: 20}, FocusTypeLeaf)
+	registry.EndScope()
+	registry.Register(4, "After", Rect{X: 0, Y: 100, W: 100, H: 20}, FocusTypeLeaf)
+
+	registry.ResetForFrame(2)
+	registry.SetFocus(1)
+
+	// Focus outside the scope jumps to the scope's first item
+	if !registry.NavigateWithin(100, NavDown) {
+		t.Fatal("NavigateWithin should focus the first item in scope")
+	}
+	if registry.CurrentFocusID() != 2 {
+		t.Errorf("Expected focus on ID 2, got %d", registry.CurrentFocusID())
+	}
+
+	registry.NavigateWithin(100, NavDown)
+	if registry.CurrentFocusID() != 3 {
+		t.Errorf("Expected focus on ID 3, got %d", registry.CurrentFocusID())
+	}
+
+	// Boundary of the scope stops navigation
+	if registry.NavigateWithin(100, NavDown) {
+		t.Error("NavigateWithin should stop at the end of the scope")
+	}
+	if registry.CurrentFocusID() != 3 {
+		t.Errorf("Expected focus to stay on ID 3, got %d", registry.CurrentFocusID())
+	}
+
+	// Unknown scope doesn't navigate
+	if registry.NavigateWithin(999, NavDown) {
+		t.Error("NavigateWithin should fail for an unregistered scope")
+	}
+}
+
+// zPanel is a mock panel with bounds that records the order it was drawn in.
+type zPanel struct {
+	mockPanel
+	rect  Rect
+	drawn *[]string
+}
+
+func (p *zPanel) Bounds() Rect      { return p.rect }
+func (p *zPanel) Draw(ctx *Context) { *p.drawn = append(*p.drawn, p.name) }
+
+func TestPanelRegistry_BringToFront(t *testing.T) {
+	registry := NewPanelRegistry()
+	registry.SetExclusive(false)
+
+	var drawn []string
+	back := &zPanel{mockPanel: mockPanel{open: true, canOpen: true, name: "back"}, rect: Rect{X: 0, Y: 0, W: 100, H: 100}, drawn: &drawn}
+	front := &zPanel{mockPanel: mockPanel{open: true, canOpen: true, name: "front"}, rect: Rect{X: 50, Y: 50, W: 100, H: 100}, drawn: &drawn}
+	registry.Register("front", front, KeyNone, 10)
+	registry.Register("back", back, KeyNone, 0)
+
+	ctx := NewContext()
+	ctx.Input = NewInputState()
+	draw := func() []string {
+		drawn = drawn[:0]
+		registry.Draw(ctx)
+		ctx.Input.Reset()
+		return drawn
+	}
+
+	registry.FocusManager().Update()
+	if got := draw(); got[0] != "back" || got[1] != "front" {
+		t.Fatalf("initial order = %v, want [back front]", got)
+	}
+
+	// Clicking the part of "back" not covered by "front" raises it
+	ctx.Input.SetMousePos(10, 10)
+	ctx.Input.SetMouseButton(MouseButtonLeft, true)
+	if got := draw(); got[0] != "front" || got[1] != "back" {
+		t.Fatalf("after click order = %v, want [front back]", got)
+	}
+	if registry.FocusedPanel() != back {
+		t.Error("raised panel should become focused")
+	}
+
+	// Order persists across frames
+	ctx.Input.SetMouseButton(MouseButtonLeft, false)
+	if got := draw(); got[1] != "back" {
+		t.Errorf("order should persist, got %v", got)
+	}
+
+	// Clicking the overlap raises the topmost panel only (no change)
+	ctx.Input.SetMousePos(60, 60)
+	ctx.Input.SetMouseButton(MouseButtonLeft, true)
+	if got := draw(); got[1] != "back" {
+		t.Errorf("click on overlap should keep topmost, got %v", got)
+	}
+}
+
+// TestPopupFocusTrap verifies Tab cycling is trapped inside the active popup,
+// that nested popups trap within the innermost, and that closing a popup
+// returns focus to the widget that opened it.
+func TestPopupFocusTrap(t *testing.T) {
+	ctx := NewContext()
+	item := func(id ID, y float32) {
+		ctx.RegisterFocusable(id, "item", Rect{X: 0, Y: y, W: 100, H: 20}, FocusTypeLeaf)
+	}
+	outerOpen, innerOpen := false, false
+	frame := func() {
+		ctx.FrameCount++
+		ctx.Reset(Vec2{X: 800, Y: 600}, 0.016)
+		item(1, 0)
+		if outerOpen {
+			ctx.BeginFocusGroup(100, "outer", Rect{})
+			item(2, 30)
+			item(3, 60)
+			if innerOpen {
+				ctx.BeginFocusGroup(200, "inner", Rect{})
+				item(4, 90)
+				item(5, 120)
+				ctx.EndFocusGroup()
+				ctx.SetActivePopup(200)
+			}
+			ctx.EndFocusGroup()
+			// The outer popup claims after its child: innermost still wins
+			ctx.SetActivePopup(100)
+		}
+		item(6, 150)
+	}
+
+	frame()
+	ctx.SetRegistryFocus(1)
+	outerOpen = true
+	frame()
+	frame()
+
+	// Tab wraps within the outer popup: 2 -> 3 -> 2
+	for _, want := range []ID{2, 3, 2} {
+		ctx.NavigateTab(true)
+		if got := ctx.focusRegistry.CurrentFocusID(); got != want {
+			t.Fatalf("outer popup: focus = %d, want %d", got, want)
+		}
+	}
+
+	innerOpen = true
+	frame()
+	frame()
+	for _, want := range []ID{4, 5, 4} {
+		ctx.NavigateTab(true)
+		if got := ctx.focusRegistry.CurrentFocusID(); got != want {
+			t.Fatalf("inner popup: focus = %d, want %d", got, want)
+		}
+	}
+
+	// Closing the inner popup restores focus to item 2 (focused when it opened)
+	innerOpen = false
+	frame()
+	frame()
+	if got := ctx.focusRegistry.CurrentFocusID(); got != 2 {
+		t.Errorf("after closing inner popup: focus = %d, want 2", got)
+	}
+
+	// Closing the outer popup restores focus to the opener
+	outerOpen = false
+	frame()
+	frame()
+	if got := ctx.focusRegistry.CurrentFocusID(); got != 1 {
+		t.Errorf("after closing outer popup: focus = %d, want 1", got)
+	}
+
+	// Without a popup Tab reaches every item
+	ctx.NavigateTab(true)
+	if got := ctx.focusRegistry.CurrentFocusID(); got != 6 {
+		t.Errorf("no popup: focus = %d, want 6", got)
+	}
+}
@@ -8,40 +8,90 @@ type Cleanable interface {
 	Cleanup(currentFrame uint64)
 }
 
+// storeSpace is one GUI instance's share of every FrameStore. Each Context
+// owns a space with its own entries and frame counter, so two GUIs drawing
+// widgets with the same IDs don't share state, and one GUI's frames don't
+// age out the other's entries.
+type storeSpace struct {
+	frame uint64
+}
+
+// spaceStore is a registered store holding entries per storeSpace.
+type spaceStore interface {
+	Cleanable
+	cleanupSpace(sp *storeSpace)
+	releaseSpace(sp *storeSpace)
+}
+
 // Global registry for automatic cleanup of all FrameStores.
 // Uses a mutex for thread-safety during registration.
 var (
-	registeredStores []Cleanable
+	registeredStores []spaceStore
 	registryMu       sync.Mutex
-	currentFrame     uint64
+
+	// sharedSpace holds the entries of the FrameStore methods that take no
+	// Context. Like the package frame counter, it is shared by every GUI.
+	sharedSpace = &storeSpace{}
 )
 
 // registerStore adds a store to the global cleanup registry.
 // Called automatically by NewFrameStore.
-func registerStore(store Cleanable) {
+func registerStore(store spaceStore) {
 	registryMu.Lock()
 	registeredStores = append(registeredStores, store)
 	registryMu.Unlock()
 }
 
-// NextFrame advances the frame counter and cleans all registered stores.
-// Call this once at the start of each GUI frame (typically in Context.Reset).
-// Stale entries (not accessed in the previous frame) are removed automatically.
-func NextFrame() {
-	currentFrame++
+// advanceSpace advances sp's frame counter and cleans its entries in all
+// registered stores.
+func advanceSpace(sp *storeSpace) {
 	registryMu.Lock()
+	sp.frame++
 	stores := registeredStores // Copy slice under lock
 	registryMu.Unlock()
 
 	for _, store := range stores {
-		store.Cleanup(currentFrame)
+		store.cleanupSpace(sp)
+	}
+}
+
+// releaseSpace drops sp's entries from all registered stores. NewContext
+// arranges for it to run once the Context is garbage collected.
+func releaseSpace(sp *storeSpace) {
+	registryMu.Lock()
+	stores := registeredStores
+	registryMu.Unlock()
+
+	for _, store := range stores {
+		store.releaseSpace(sp)
 	}
 }
 
-// CurrentFrameCount returns the current frame counter.
+// NextFrame advances the shared frame counter and cleans the entries of the
+// FrameStore methods that take no Context. Context.Reset calls it once per
+// frame, alongside Context.NextFrame.
+// Stale entries (not accessed in the previous frame) are removed automatically.
+func NextFrame() {
+	advanceSpace(sharedSpace)
+}
+
+// CurrentFrameCount returns the shared frame counter.
 // Useful for debugging or advanced use cases.
 func CurrentFrameCount() uint64 {
-	return currentFrame
+	return sharedSpace.frame
+}
+
+// NextFrame advances ctx's frame counter and cleans its entries in all
+// registered stores (those of the ...For methods). Context.Reset calls it at
+// the start of each frame; call it directly only to drive stores without a
+// GUI.
+func (ctx *Context) NextFrame() {
+	advanceSpace(ctx.stores)
+}
+
+// CurrentFrameCount returns ctx's frame counter.
+func (ctx *Context) CurrentFrameCount() uint64 {
+	return ctx.stores.frame
 }
 
 // stateEntry wraps a state value with frame tracking for staleness detection.
@@ -65,7 +115,7 @@ type stateEntry[T any] struct {
 //	// In widget code - get state with compile-time type safety
 //	func (ctx *Context) Section(label string) {
 //	    id := ctx.GetID(label)
-//	    state := sectionStore.Get(id, SectionState{Open: false})
+//	    state := sectionStore.GetFor(ctx, id, SectionState{Open: false})
 //	    // state is *SectionState - no type assertion needed
 //	    state.Open = !state.Open  // Direct modification
 //	}
@@ -73,9 +123,27 @@ type stateEntry[T any] struct {
 // For user-defined widgets, create your own FrameStore without modifying gui:
 //
 //	var myStore = gui.NewFrameStore[MyWidgetState]()
+//
+// Every method comes in two forms. Get, Set and the others without a
+// Context use one set of entries shared by all GUI instances. GetFor,
+// SetFor and the other ...For methods keep separate entries per Context,
+// which age only by that Context's frames. The built-in widgets use the
+// ...For forms; use them too when several GUIs draw the same widget.
+//
+// Entries are kept one frame past their last access by default. Stores for
+// state that should survive a widget being hidden for a while (scroll
+// positions, column widths) can keep entries longer with SetRetention, or
+// keep specific IDs indefinitely with Pin.
 type FrameStore[T any] struct {
+	spaces    map[*storeSpace]*storeEntries[T]
+	retention uint64       // Extra frames an entry survives without access
+	mu        sync.RWMutex // Protects concurrent access
+}
+
+// storeEntries is a FrameStore's entries for one storeSpace.
+type storeEntries[T any] struct {
 	states map[ID]*stateEntry[T]
-	mu     sync.RWMutex // Protects concurrent access
+	pinned map[ID]struct{} // IDs exempt from cleanup until Unpin
 }
 
 // NewFrameStore creates a new type-safe state store and registers it
@@ -87,44 +155,70 @@ type FrameStore[T any] struct {
 //	var sliderStore = gui.NewFrameStore[SliderState]()
 func NewFrameStore[T any]() *FrameStore[T] {
 	store := &FrameStore[T]{
-		states: make(map[ID]*stateEntry[T]),
+		spaces: make(map[*storeSpace]*storeEntries[T]),
 	}
 	registerStore(store)
 	return store
 }
 
+// entries returns the entries for sp, creating them if create is set.
+// The caller must hold s.mu (for writing when create is set).
+func (s *FrameStore[T]) entries(sp *storeSpace, create bool) *storeEntries[T] {
+	e := s.spaces[sp]
+	if e == nil && create {
+		e = &storeEntries[T]{states: make(map[ID]*stateEntry[T])}
+		s.spaces[sp] = e
+	}
+	return e
+}
+
 // Get retrieves state for the given ID, or creates it with defaultVal if not found.
 // Returns a pointer to the state, allowing direct modification.
 // The state is automatically marked as "used this frame" to prevent cleanup.
+// The entry is shared by all GUI instances; GetFor keeps one per Context.
 //
 // This method is safe for concurrent use.
 func (s *FrameStore[T]) Get(id ID, defaultVal T) *T {
+	return s.get(sharedSpace, id, defaultVal)
+}
+
+// GetFor is Get for ctx's own entries.
+func (s *FrameStore[T]) GetFor(ctx *Context, id ID, defaultVal T) *T {
+	return s.get(ctx.stores, id, defaultVal)
+}
+
+func (s *FrameStore[T]) get(sp *storeSpace, id ID, defaultVal T) *T {
 	s.mu.RLock()
-	entry, ok := s.states[id]
+	var entry *stateEntry[T]
+	ok := false
+	if e := s.spaces[sp]; e != nil {
+		entry, ok = e.states[id]
+	}
 	s.mu.RUnlock()
 
 	if ok {
 		// Fast path: entry exists, just update frame
 		s.mu.Lock()
-		entry.lastFrame = currentFrame
+		entry.lastFrame = sp.frame
 		s.mu.Unlock()
 		return &entry.value
 	}
 
 	// Slow path: create new entry
 	s.mu.Lock()
+	e := s.entries(sp, true)
 	// Double-check after acquiring write lock
-	if entry, ok = s.states[id]; ok {
-		entry.lastFrame = currentFrame
+	if entry, ok = e.states[id]; ok {
+		entry.lastFrame = sp.frame
 		s.mu.Unlock()
 		return &entry.value
 	}
 
 	entry = &stateEntry[T]{
 		value:     defaultVal,
-		lastFrame: currentFrame,
+		lastFrame: sp.frame,
 	}
-	s.states[id] = entry
+	e.states[id] = entry
 	s.mu.Unlock()
 	return &entry.value
 }
@@ -133,11 +227,22 @@ func (s *FrameStore[T]) Get(id ID, defaultVal T) *T {
 // Returns nil if no state exists for this ID.
 // Does NOT create default state or mark as used.
 func (s *FrameStore[T]) GetIfExists(id ID) *T {
+	return s.getIfExists(sharedSpace, id)
+}
+
+// GetIfExistsFor is GetIfExists for ctx's own entries.
+func (s *FrameStore[T]) GetIfExistsFor(ctx *Context, id ID) *T {
+	return s.getIfExists(ctx.stores, id)
+}
+
+func (s *FrameStore[T]) getIfExists(sp *storeSpace, id ID) *T {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if entry, ok := s.states[id]; ok {
-		return &entry.value
+	if e := s.spaces[sp]; e != nil {
+		if entry, ok := e.states[id]; ok {
+			return &entry.value
+		}
 	}
 	return nil
 }
@@ -145,56 +250,166 @@ func (s *FrameStore[T]) GetIfExists(id ID) *T {
 // Set explicitly sets state for an ID.
 // Creates or updates the entry and marks it as used this frame.
 func (s *FrameStore[T]) Set(id ID, value T) {
+	s.set(sharedSpace, id, value)
+}
+
+// SetFor is Set for ctx's own entries.
+func (s *FrameStore[T]) SetFor(ctx *Context, id ID, value T) {
+	s.set(ctx.stores, id, value)
+}
+
+func (s *FrameStore[T]) set(sp *storeSpace, id ID, value T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if entry, ok := s.states[id]; ok {
+	e := s.entries(sp, true)
+	if entry, ok := e.states[id]; ok {
 		entry.value = value
-		entry.lastFrame = currentFrame
+		entry.lastFrame = sp.frame
 	} else {
-		s.states[id] = &stateEntry[T]{
+		e.states[id] = &stateEntry[T]{
 			value:     value,
-			lastFrame: currentFrame,
+			lastFrame: sp.frame,
 		}
 	}
 }
 
 // Delete explicitly removes state for an ID.
 // Use this when you know state is no longer needed (e.g., widget destroyed).
+// Also releases the ID if it was pinned.
 func (s *FrameStore[T]) Delete(id ID) {
+	s.delete(sharedSpace, id)
+}
+
+// DeleteFor is Delete for ctx's own entries.
+func (s *FrameStore[T]) DeleteFor(ctx *Context, id ID) {
+	s.delete(ctx.stores, id)
+}
+
+func (s *FrameStore[T]) delete(sp *storeSpace, id ID) {
+	s.mu.Lock()
+	if e := s.spaces[sp]; e != nil {
+		delete(e.states, id)
+		delete(e.pinned, id)
+	}
+	s.mu.Unlock()
+}
+
+// SetRetention keeps entries for the given number of extra frames after
+// their last access before Cleanup evicts them. The default of 0 removes
+// entries not accessed in the previous frame.
+func (s *FrameStore[T]) SetRetention(frames uint64) {
+	s.mu.Lock()
+	s.retention = frames
+	s.mu.Unlock()
+}
+
+// Pin exempts the entry for id from cleanup until Unpin, Delete or Clear is
+// called, however long it goes without access. The ID may be pinned before
+// its entry exists.
+func (s *FrameStore[T]) Pin(id ID) {
+	s.pin(sharedSpace, id)
+}
+
+// PinFor is Pin for ctx's own entries.
+func (s *FrameStore[T]) PinFor(ctx *Context, id ID) {
+	s.pin(ctx.stores, id)
+}
+
+func (s *FrameStore[T]) pin(sp *storeSpace, id ID) {
 	s.mu.Lock()
-	delete(s.states, id)
+	e := s.entries(sp, true)
+	if e.pinned == nil {
+		e.pinned = make(map[ID]struct{})
+	}
+	e.pinned[id] = struct{}{}
 	s.mu.Unlock()
 }
 
-// Cleanup removes all entries that weren't accessed in the previous frame.
-// This is called automatically by NextFrame() - don't call it manually.
+// Unpin releases a pinned ID. Its entry is then cleaned up as usual,
+// counting from its last access.
+func (s *FrameStore[T]) Unpin(id ID) {
+	s.unpin(sharedSpace, id)
+}
+
+// UnpinFor is Unpin for ctx's own entries.
+func (s *FrameStore[T]) UnpinFor(ctx *Context, id ID) {
+	s.unpin(ctx.stores, id)
+}
+
+func (s *FrameStore[T]) unpin(sp *storeSpace, id ID) {
+	s.mu.Lock()
+	if e := s.spaces[sp]; e != nil {
+		delete(e.pinned, id)
+	}
+	s.mu.Unlock()
+}
+
+// Cleanup removes the shared entries that weren't accessed in the previous
+// frame (or within the retention window set by SetRetention). Pinned entries
+// are kept. This is called automatically by NextFrame() - don't call it
+// manually. Each Context's entries are cleaned by Context.NextFrame.
 func (s *FrameStore[T]) Cleanup(frame uint64) {
+	s.cleanup(sharedSpace, frame)
+}
+
+func (s *FrameStore[T]) cleanupSpace(sp *storeSpace) {
+	s.cleanup(sp, sp.frame)
+}
+
+func (s *FrameStore[T]) cleanup(sp *storeSpace, frame uint64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Remove entries not used in the previous frame
-	// (frame-1 because we just incremented in NextFrame)
-	threshold := frame - 1
-	for id, entry := range s.states {
-		if entry.lastFrame < threshold {
-			delete(s.states, id)
+	e := s.spaces[sp]
+	if e == nil {
+		return
+	}
+
+	// Remove entries not used in the previous frame, plus the retention window
+	// (+1 because we just incremented in NextFrame)
+	for id, entry := range e.states {
+		if _, ok := e.pinned[id]; ok {
+			continue
+		}
+		if frame-entry.lastFrame > s.retention+1 {
+			delete(e.states, id)
 		}
 	}
 }
 
-// Len returns the number of stored entries.
+// releaseSpace drops all of sp's entries, pinned ones included.
+func (s *FrameStore[T]) releaseSpace(sp *storeSpace) {
+	s.mu.Lock()
+	delete(s.spaces, sp)
+	s.mu.Unlock()
+}
+
+// Len returns the number of shared entries.
 // Useful for debugging and monitoring.
 func (s *FrameStore[T]) Len() int {
+	return s.len(sharedSpace)
+}
+
+// LenFor returns the number of entries stored for ctx.
+func (s *FrameStore[T]) LenFor(ctx *Context) int {
+	return s.len(ctx.stores)
+}
+
+func (s *FrameStore[T]) len(sp *storeSpace) int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.states)
+	if e := s.spaces[sp]; e != nil {
+		return len(e.states)
+	}
+	return 0
 }
 
-// Clear removes all entries immediately.
+// Clear removes all entries, including pinned ones and those of every GUI
+// instance, immediately.
 // Useful for resetting state (e.g., when switching scenes).
 func (s *FrameStore[T]) Clear() {
 	s.mu.Lock()
-	s.states = make(map[ID]*stateEntry[T])
+	s.spaces = make(map[*storeSpace]*storeEntries[T])
 	s.mu.Unlock()
 }
@@ -0,0 +1,28 @@
+package gui
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFrameStoreReleasesCollectedContext(t *testing.T) {
+	store := NewFrameStore[int]()
+	ctx := NewContext()
+	store.SetFor(ctx, 1, 1)
+	store.PinFor(ctx, 2)
+	ctx = nil
+
+	count := func() int {
+		store.mu.RLock()
+		defer store.mu.RUnlock()
+		return len(store.spaces)
+	}
+	for i := 0; i < 100 && count() > 0; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("store still holds %d spaces after the context was collected", n)
+	}
+}
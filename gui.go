@@ -14,6 +14,14 @@ type GUI struct {
 	style        Style
 	ctx          *Context
 	fontProvider FontProvider
+
+	// Deterministic clock (see SetTime)
+	fixedTime bool
+	time      float32
+
+	// Previous frames of the main and foreground lists (see DrawList.DamageRect)
+	damageTracking bool
+	damage         [2]damageTracker
 }
 
 // GUIOption configures a GUI instance.
@@ -29,6 +37,14 @@ func WithStateStore(store StateStore) GUIOption {
 	return func(g *GUI) { g.stateStore = store }
 }
 
+// WithDamageTracking makes GUI.End compare each frame's draw lists with
+// the previous frame's and report the changed area through
+// DrawList.DamageRect. The comparison hashes every triangle, so it is off
+// by default and lists then report the whole surface.
+func WithDamageTracking() GUIOption {
+	return func(g *GUI) { g.damageTracking = true }
+}
+
 // New creates a new GUI instance.
 func New(renderer Renderer, opts ...GUIOption) *GUI {
 	g := &GUI{
@@ -55,7 +71,18 @@ func (g *GUI) Begin(input *InputState, displaySize Vec2, deltaTime float32) *Con
 	ctx.ForegroundDrawList = AcquireDrawList() // For popups, dropdowns (drawn on top)
 
 	// Set frame state
+	// Advance the clock (the fixed clock overrides the caller's delta)
+	if g.fixedTime {
+		deltaTime = maxf(0, g.time-ctx.Time)
+		ctx.Time = g.time
+	} else {
+		ctx.Time += deltaTime
+	}
+
 	ctx.Input = input
+	if input != nil {
+		input.countClicks(ctx.Time)
+	}
 	ctx.stateStore = g.stateStore
 	ctx.DisplaySize = displaySize
 	ctx.DeltaTime = deltaTime
@@ -80,6 +107,11 @@ func (g *GUI) End() error {
 		return nil
 	}
 
+	g.ctx.HandleEscape()
+	if g.damageTracking {
+		g.trackDamage()
+	}
+
 	// Render main draw list
 	err := g.renderer.Render(g.ctx.DrawList)
 	if err != nil {
@@ -102,6 +134,55 @@ func (g *GUI) End() error {
 	return err
 }
 
+// InvalidateDamage makes the next frame report the whole display as
+// damaged. Damage tracking only sees the draw lists, so call it after
+// changing what a texture holds (an image upload, a font atlas rebuild):
+// geometry drawn with it is unchanged, but its pixels are not.
+func (g *GUI) InvalidateDamage() {
+	for i := range g.damage {
+		g.damage[i].valid = false
+	}
+}
+
+// trackDamage compares this frame's draw lists with the previous frame's
+// and stores the union of their changes on both (see DrawList.DamageRect).
+// A change in either list needs both redrawn in that area.
+func (g *GUI) trackDamage() {
+	ctx := g.ctx
+	rect, damaged := g.damage[0].update(ctx.DrawList, ctx.DisplaySize)
+	if fg := ctx.ForegroundDrawList; fg != nil {
+		if fgRect, fgDamaged := g.damage[1].update(fg, ctx.DisplaySize); fgDamaged {
+			if damaged {
+				rect = rect.Union(fgRect)
+			} else {
+				rect = fgRect
+			}
+			damaged = true
+		}
+	}
+	for _, dl := range []*DrawList{ctx.DrawList, ctx.ForegroundDrawList} {
+		if dl != nil {
+			dl.damage, dl.damaged = rect, damaged
+		}
+	}
+}
+
+// SetTime switches the GUI to a deterministic clock and sets its time in
+// seconds. While set, Begin ignores its deltaTime argument: Context.Time is t
+// and DeltaTime is the difference from the previous frame's time. Animations
+// (caret blink, ProgressBarSmooth, scroll easing) therefore depend only on the
+// times supplied, so screenshot and golden tests render identically. Holding
+// t constant freezes all animation.
+func (g *GUI) SetTime(t float32) {
+	g.fixedTime = true
+	g.time = t
+}
+
+// SetRealTime returns to advancing the clock by Begin's deltaTime.
+func (g *GUI) SetRealTime() {
+	g.fixedTime = false
+}
+
 // Context returns the current GUI context.
 // Only valid between Begin() and End() calls.
 func (g *GUI) Context() *Context {
@@ -59,6 +59,13 @@ const (
 	KeyRepeatInterval float32 = 0.03 // Repeat interval once repeating (seconds)
 )
 
+// Double-click detection: presses of the same button count as consecutive
+// clicks when they are this close in time and position.
+const (
+	MouseDoubleClickTime     float32 = 0.3 // Maximum time between presses (seconds)
+	MouseDoubleClickDistance float32 = 6   // Maximum distance between presses (pixels)
+)
+
 // InputState holds input state for the current frame.
 // This is typically populated by the application from GLFW or similar.
 type InputState struct {
@@ -70,6 +77,15 @@ type InputState struct {
 	mouseClicked [MouseButtonCount]bool // True on the frame button was pressed
 	mouseUp      [MouseButtonCount]bool // True on the frame button was released
 
+	// Mouse drag tracking - valid while held and on the release frame
+	mouseDownPos  [MouseButtonCount]Vec2    // Mouse position when the button was pressed
+	mouseDragDist [MouseButtonCount]float32 // Furthest distance from the press point
+
+	// Click counting - consecutive presses, updated by GUI.Begin
+	mouseClickCount [MouseButtonCount]int     // 1 = single, 2 = double, ...
+	lastClickTime   [MouseButtonCount]float32 // GUI clock at the last press
+	lastClickPos    [MouseButtonCount]Vec2    // Mouse position at the last press
+
 	// Mouse wheel
 	MouseWheelX float32
 	MouseWheelY float32
@@ -109,6 +125,12 @@ func (s *InputState) Reset() {
 	for i := range s.mouseUp {
 		s.mouseUp[i] = false
 	}
+	for i := range s.mouseDragDist {
+		if !s.mouseDown[i] {
+			s.mouseDownPos[i] = Vec2{}
+			s.mouseDragDist[i] = 0
+		}
+	}
 	for i := range s.keyPressed {
 		s.keyPressed[i] = false
 	}
@@ -124,6 +146,13 @@ func (s *InputState) Reset() {
 func (s *InputState) SetMousePos(x, y float32) {
 	s.MouseX = x
 	s.MouseY = y
+
+	for i := range s.mouseDown {
+		if s.mouseDown[i] {
+			d := Vec2{x, y}.Sub(s.mouseDownPos[i])
+			s.mouseDragDist[i] = maxf(s.mouseDragDist[i], sqrtf(d.X*d.X+d.Y*d.Y))
+		}
+	}
 }
 
 // SetMouseButton sets mouse button state.
@@ -137,12 +166,33 @@ func (s *InputState) SetMouseButton(button MouseButton, down bool) {
 
 	if down && !wasDown {
 		s.mouseClicked[button] = true
+		s.mouseDownPos[button] = Vec2{s.MouseX, s.MouseY}
+		s.mouseDragDist[button] = 0
 	}
 	if !down && wasDown {
 		s.mouseUp[button] = true
 	}
 }
 
+// countClicks updates the click counts of the buttons pressed this frame.
+// now is the GUI clock; GUI.Begin calls it once per frame.
+func (s *InputState) countClicks(now float32) {
+	for i := range s.mouseClicked {
+		if !s.mouseClicked[i] {
+			continue
+		}
+		d := s.mouseDownPos[i].Sub(s.lastClickPos[i])
+		if s.mouseClickCount[i] > 0 && now-s.lastClickTime[i] <= MouseDoubleClickTime &&
+			sqrtf(d.X*d.X+d.Y*d.Y) <= MouseDoubleClickDistance {
+			s.mouseClickCount[i]++
+		} else {
+			s.mouseClickCount[i] = 1
+		}
+		s.lastClickTime[i] = now
+		s.lastClickPos[i] = s.mouseDownPos[i]
+	}
+}
+
 // SetKey sets key state.
 func (s *InputState) SetKey(key Key, down bool) {
 	if key < 0 || key >= KeyCount {
@@ -199,6 +249,23 @@ func (s *InputState) MouseClicked(button MouseButton) bool {
 	return s.mouseClicked[button]
 }
 
+// MouseDoubleClicked returns true if a mouse button was pressed this frame
+// as the second click of a double-click: within MouseDoubleClickTime and
+// MouseDoubleClickDistance of the previous press.
+func (s *InputState) MouseDoubleClicked(button MouseButton) bool {
+	return s.MouseClickCount(button) == 2
+}
+
+// MouseClickCount returns how many consecutive clicks the press this frame
+// completes (1 = single, 2 = double, 3 = triple, ...), or 0 if the button
+// wasn't pressed this frame.
+func (s *InputState) MouseClickCount(button MouseButton) int {
+	if button < 0 || button >= MouseButtonCount || !s.mouseClicked[button] {
+		return 0
+	}
+	return s.mouseClickCount[button]
+}
+
 // MouseReleased returns true if a mouse button was just released.
 func (s *InputState) MouseReleased(button MouseButton) bool {
 	if button < 0 || button >= MouseButtonCount {
@@ -207,6 +274,30 @@ func (s *InputState) MouseReleased(button MouseButton) bool {
 	return s.mouseUp[button]
 }
 
+// MouseDragDelta returns the mouse offset from where the button was pressed.
+// Valid while the button is held and on the frame it is released; zero otherwise.
+func (s *InputState) MouseDragDelta(button MouseButton) Vec2 {
+	if button < 0 || button >= MouseButtonCount {
+		return Vec2{}
+	}
+	if !s.mouseDown[button] && !s.mouseUp[button] {
+		return Vec2{}
+	}
+	return Vec2{s.MouseX, s.MouseY}.Sub(s.mouseDownPos[button])
+}
+
+// MouseDragDistance returns the furthest the mouse has moved from the press
+// point since the button was pressed. Valid while held and on the release frame.
+func (s *InputState) MouseDragDistance(button MouseButton) float32 {
+	if button < 0 || button >= MouseButtonCount {
+		return 0
+	}
+	if !s.mouseDown[button] && !s.mouseUp[button] {
+		return 0
+	}
+	return s.mouseDragDist[button]
+}
+
 // KeyDown returns true if a key is currently held.
 func (s *InputState) KeyDown(key Key) bool {
 	if key < 0 || key >= KeyCount {
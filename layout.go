@@ -38,6 +38,16 @@ type Layout struct {
 	// Panel-specific options
 	Hotkey           string  // Keyboard shortcut to display (e.g., "T" -> "Title [T]")
 	HeightConstraint float32 // Maximum height constraint (0 = no limit, > 0 = limit)
+	ClipContent      bool    // Clip contents to the panel's content region
+	ScrollLines      int     // ListBox: lines per wheel notch (0 = Style.ScrollSpeed pixels)
+
+	// Wrap (HStack): items that don't fit start a new row
+	Wrap       bool
+	rowY       float32   // Top of the current row
+	rowHeight  float32   // Tallest item in the current row
+	rowItems   int       // Items in the current row
+	itemWidths []float32 // Item widths measured this frame
+	prevWidths []float32 // Item widths from last frame, to wrap before overflowing
 }
 
 // Alignment values (like Tailwind items-*)
@@ -60,6 +70,21 @@ const (
 	JustifyBetween                      // justify-between
 )
 
+// Anchor is a point of the screen that AnchoredPanel pins a panel to.
+type Anchor uint8
+
+const (
+	AnchorTopLeft     Anchor = iota // Top-left corner
+	AnchorTop                       // Top edge, centered horizontally
+	AnchorTopRight                  // Top-right corner
+	AnchorLeft                      // Left edge, centered vertically
+	AnchorCenter                    // Screen center
+	AnchorRight                     // Right edge, centered vertically
+	AnchorBottomLeft                // Bottom-left corner
+	AnchorBottom                    // Bottom edge, centered horizontally
+	AnchorBottomRight               // Bottom-right corner
+)
+
 // LayoutOption configures a layout container.
 type LayoutOption func(*Layout)
 
@@ -124,6 +149,31 @@ func MaxHeight(h float32) LayoutOption {
 	return func(l *Layout) { l.HeightConstraint = h }
 }
 
+// ClipContent clips panel contents to the panel's content region.
+// Content past MaxHeight (or a fixed Height) is hidden and doesn't receive input.
+func ClipContent() LayoutOption {
+	return func(l *Layout) { l.ClipContent = true }
+}
+
+// ScrollLines makes each mouse wheel notch scroll a ListBox by n lines of
+// text instead of Style.ScrollSpeed pixels. It is the layout form of
+// WithScrollLines.
+func ScrollLines(n int) LayoutOption {
+	return func(l *Layout) { l.ScrollLines = n }
+}
+
+// Wrap makes an HStack start a new row when the next item doesn't fit in
+// its width, like CSS flex-wrap. Rows are GapY (or Gap) apart and as tall
+// as their tallest item. Items are wrapped using their width from the last
+// frame; on the first frame an item that overflows pushes the next one to
+// a new row.
+func Wrap() LayoutOption {
+	return func(l *Layout) { l.Wrap = true }
+}
+
+// layoutWrapStore keeps each wrapping HStack's item widths for the next frame.
+var layoutWrapStore = NewFrameStore[[]float32]()
+
 // pushLayout creates and pushes a new layout onto the stack.
 func (ctx *Context) pushLayout(layoutType LayoutType) *Layout {
 	layout := &Layout{
@@ -139,6 +189,9 @@ func (ctx *Context) pushLayout(layoutType LayoutType) *Layout {
 
 // pushLayoutWith creates a layout with options and pushes it.
 func (ctx *Context) pushLayoutWith(layout *Layout) {
+	if parent := ctx.currentLayout(); parent != nil && parent.Wrap && parent.Type == LayoutHorizontal {
+		ctx.wrapItem(parent)
+	}
 	layout.StartX = ctx.cursor.X
 	layout.StartY = ctx.cursor.Y
 	if layout.Width == 0 {
@@ -203,6 +256,10 @@ func (ctx *Context) popLayout() Rect {
 			ctx.cursor.Y = layout.StartY + layout.MaxHeight
 			parent.MaxWidth = maxf(parent.MaxWidth, childSize.X)
 			parent.MaxHeight = ctx.cursor.Y - parent.StartY
+		} else if parent.Wrap {
+			ctx.cursor.X = layout.StartX
+			ctx.cursor.Y = parent.rowY
+			ctx.wrapAdvance(parent, childSize)
 		} else {
 			ctx.cursor.X = layout.StartX + layout.MaxWidth
 			ctx.cursor.Y = parent.StartY + parent.Padding + parent.PaddingY
@@ -273,12 +330,38 @@ func (ctx *Context) Panel(title string, opts ...LayoutOption) func(func()) {
 		ctx.cursor.X += padX
 		ctx.cursor.Y += padY + headerH
 
+		// Reserve this panel's slot in draw order (bounds filled in below)
+		panelIndex := len(ctx.panelRects)
+		ctx.panelRects = append(ctx.panelRects, Rect{})
+		ctx.panelStack = append(ctx.panelStack, panelIndex)
+
 		// Push layout (this may auto-fill Width/Height to display size)
 		ctx.pushLayoutWith(layout)
 
+		// Clip contents to the content region (inside padding, below header)
+		if layout.ClipContent {
+			// Auto-sized panels grow to fit, so only explicit sizes clip
+			clipW := float32(1e9)
+			if userWidth > 0 {
+				clipW = userWidth - padX*2
+			}
+			clipH := float32(1e9)
+			if userHeight > 0 {
+				clipH = userHeight - padY*2 - headerH
+			}
+			if layout.HeightConstraint > 0 {
+				clipH = minf(clipH, layout.HeightConstraint-padY*2-headerH)
+			}
+			ctx.pushClipRect(Rect{X: ctx.cursor.X, Y: ctx.cursor.Y, W: maxf(0, clipW), H: maxf(0, clipH)})
+		}
+
 		// Execute contents (title is drawn separately in the header)
 		contents()
 
+		if layout.ClipContent {
+			ctx.popClipRect()
+		}
+
 		// Pop layout and get bounds
 		bounds := ctx.popLayout()
 
@@ -301,8 +384,16 @@ func (ctx *Context) Panel(title string, opts ...LayoutOption) func(func()) {
 			panelH = layout.HeightConstraint
 		}
 
+		ctx.panelRects[panelIndex] = Rect{X: startX, Y: startY, W: panelW, H: panelH}
+		ctx.panelStack = ctx.panelStack[:len(ctx.panelStack)-1]
+
 		// Insert background (drawn first, behind content)
-		ctx.DrawList.InsertRect(startX, startY, panelW, panelH, ctx.style.PanelColor)
+		if ctx.style.PanelGradient {
+			ctx.DrawList.InsertRectRoundedGradientV(startX, startY, panelW, panelH,
+				lightenColor(ctx.style.PanelColor, surfaceGradientLighten), ctx.style.PanelColor, ctx.style.CornerRadius)
+		} else {
+			ctx.DrawList.InsertRectRounded(startX, startY, panelW, panelH, ctx.style.PanelColor, ctx.style.CornerRadius)
+		}
 
 		// Draw header background and title if provided
 		if title != "" {
@@ -311,7 +402,13 @@ func (ctx *Context) Panel(title string, opts ...LayoutOption) func(func()) {
 			if headerBg == 0 {
 				headerBg = ctx.style.ButtonColor
 			}
-			ctx.DrawList.AddRect(startX, startY, panelW, headerH, headerBg)
+			radius := ctx.style.CornerRadius
+			if ctx.style.HeaderGradient {
+				ctx.DrawList.AddRectRoundedCornersGradientV(startX, startY, panelW, headerH,
+					lightenColor(headerBg, surfaceGradientLighten), headerBg, radius, radius, 0, 0)
+			} else {
+				ctx.DrawList.AddRectRoundedCorners(startX, startY, panelW, headerH, headerBg, radius, radius, 0, 0)
+			}
 
 			// Header text color
 			headerTextColor := ctx.style.PanelHeaderTextColor
@@ -332,8 +429,8 @@ func (ctx *Context) Panel(title string, opts ...LayoutOption) func(func()) {
 
 		// Draw border if style has one
 		if ctx.style.BorderSize > 0 {
-			ctx.DrawList.AddRectOutline(startX, startY, panelW, panelH,
-				ctx.style.PanelBorderColor, ctx.style.BorderSize)
+			ctx.DrawList.AddRectRoundedOutline(startX, startY, panelW, panelH,
+				ctx.style.PanelBorderColor, ctx.style.CornerRadius, ctx.style.BorderSize)
 		}
 
 		// Check if mouse is inside panel and set capture flag
@@ -383,6 +480,61 @@ func (ctx *Context) CenteredPanel(id string, opts ...LayoutOption) func(func())
 	}
 }
 
+// AnchoredPanel draws a panel pinned to a corner, edge or the center of
+// the screen, margin pixels in from the anchored edges (margin is ignored
+// along centered axes). Like CenteredPanel it positions the panel using its
+// size measured on the previous frame, so right and bottom anchors keep the
+// panel's far edge at the margin. The position is recomputed from
+// DisplaySize every frame, so the panel follows window resizes.
+//
+// The panel floats over the surrounding layout: the cursor is restored
+// afterwards.
+//
+// Usage:
+//
+//	ctx.AnchoredPanel("minimap", AnchorBottomRight, Vec2{X: 16, Y: 16})(func() {
+//	    drawMinimap(ctx)
+//	})
+func (ctx *Context) AnchoredPanel(id string, anchor Anchor, margin Vec2, opts ...LayoutOption) func(func()) {
+	return func(contents func()) {
+		panelID := ctx.GetID(id)
+
+		// Get cached size from previous frame (or default)
+		size := GetState(ctx, panelID, Vec2{200, 100})
+
+		var x, y float32
+		switch anchor {
+		case AnchorTopLeft, AnchorLeft, AnchorBottomLeft:
+			x = margin.X
+		case AnchorTopRight, AnchorRight, AnchorBottomRight:
+			x = ctx.DisplaySize.X - size.X - margin.X
+		default:
+			x = (ctx.DisplaySize.X - size.X) / 2
+		}
+		switch anchor {
+		case AnchorTopLeft, AnchorTop, AnchorTopRight:
+			y = margin.Y
+		case AnchorBottomLeft, AnchorBottom, AnchorBottomRight:
+			y = ctx.DisplaySize.Y - size.Y - margin.Y
+		default:
+			y = (ctx.DisplaySize.Y - size.Y) / 2
+		}
+
+		saved := ctx.cursor
+		ctx.cursor = Vec2{X: x, Y: y}
+
+		panelIndex := len(ctx.panelRects)
+		ctx.Panel("", opts...)(contents)
+
+		// Store measured size for next frame
+		if panelIndex < len(ctx.panelRects) {
+			r := ctx.panelRects[panelIndex]
+			SetState(ctx, panelID, Vec2{X: r.W, Y: r.H})
+		}
+		ctx.cursor = saved
+	}
+}
+
 // VStack creates a vertical layout container.
 //
 // Usage:
@@ -417,17 +569,202 @@ func (ctx *Context) HStack(opts ...LayoutOption) func(func()) {
 		for _, opt := range opts {
 			opt(layout)
 		}
+		var widths *[]float32
+		if layout.Wrap {
+			widths = layoutWrapStore.GetFor(ctx, ctx.GetID("hstack_wrap"), nil)
+			layout.prevWidths = *widths
+		}
 		ctx.pushLayoutWith(layout)
+		layout.rowY = layout.StartY
 		contents()
+		if widths != nil {
+			*widths = layout.itemWidths
+		}
 		ctx.popLayout()
 	}
 }
 
+// wrapItem moves the cursor of a wrapping layout to a new row when the
+// next item, at last frame's width, doesn't fit in the current one. The
+// gap before the item has already been added.
+func (ctx *Context) wrapItem(layout *Layout) {
+	if layout.rowItems == 0 {
+		return
+	}
+	w := float32(0)
+	if layout.ItemCount < len(layout.prevWidths) {
+		w = layout.prevWidths[layout.ItemCount]
+	}
+	if ctx.cursor.X+w <= layout.StartX+ctx.currentLayoutWidth() {
+		return
+	}
+	gap := layout.GapY
+	if gap == 0 {
+		gap = layout.Gap
+	}
+	if gap == 0 {
+		gap = ctx.style.ItemSpacing
+	}
+	layout.rowY += layout.rowHeight + gap
+	layout.rowHeight = 0
+	layout.rowItems = 0
+	ctx.cursor = Vec2{X: layout.StartX + layout.Padding + layout.PaddingX, Y: layout.rowY}
+}
+
+// wrapAdvance moves the cursor of a wrapping layout past an item of the
+// given size drawn at the cursor.
+func (ctx *Context) wrapAdvance(layout *Layout, size Vec2) {
+	ctx.cursor.X += size.X
+	layout.MaxWidth = maxf(layout.MaxWidth, ctx.cursor.X-layout.StartX)
+	layout.rowHeight = maxf(layout.rowHeight, size.Y)
+	layout.MaxHeight = maxf(layout.MaxHeight, layout.rowY+layout.rowHeight-layout.StartY)
+	layout.rowItems++
+	layout.itemWidths = append(layout.itemWidths, size.X)
+}
+
 // Row creates a horizontal layout for its contents (alias for HStack).
 func (ctx *Context) Row(contents func()) {
 	ctx.HStack()(contents)
 }
 
+// Columns splits the remaining width of the current layout into count equal
+// columns side by side and calls contents once per column with its index,
+// left to right; widgets flow top to bottom within each column. Columns are
+// GapX (or Gap) apart, ItemSpacing by default, and the cursor then moves
+// below the tallest column. Lighter than a table for two-up forms.
+//
+// Usage:
+//
+//	ctx.Columns(2)(func(col int) {
+//	    switch col {
+//	    case 0:
+//	        ctx.InputText("Name", &name)
+//	        ctx.InputText("Email", &email)
+//	    case 1:
+//	        ctx.Checkbox("Subscribed", &subscribed)
+//	    }
+//	})
+func (ctx *Context) Columns(count int, opts ...LayoutOption) func(func(col int)) {
+	weights := make([]float32, count)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return ctx.WeightedColumns(weights, opts...)
+}
+
+// WeightedColumns is Columns with one column per weight, each getting its
+// share of the width (weights 1, 2 make the second column twice as wide).
+func (ctx *Context) WeightedColumns(weights []float32, opts ...LayoutOption) func(func(col int)) {
+	return func(contents func(col int)) {
+		if len(weights) == 0 {
+			return
+		}
+		ctx.HStack(opts...)(func() {
+			layout := ctx.currentLayout()
+			gap := layout.GapX
+			if gap == 0 {
+				gap = layout.Gap
+			}
+			if gap == 0 {
+				gap = ctx.style.ItemSpacing
+			}
+			total := float32(0)
+			for _, w := range weights {
+				total += maxf(w, 0)
+			}
+			avail := maxf(ctx.ContentRegionAvail().X-gap*float32(len(weights)-1), 0)
+
+			for i, weight := range weights {
+				w := float32(0)
+				if total > 0 {
+					w = avail * maxf(weight, 0) / total
+				}
+				ctx.beginItem() // Nested stacks don't get the row's gap on their own
+				ctx.VStack(Width(maxf(w, 1)))(func() {
+					contents(i)
+					// Keep the column's width however narrow its contents
+					col := ctx.currentLayout()
+					col.MaxWidth = maxf(col.MaxWidth, w)
+				})
+			}
+		})
+	}
+}
+
+// defaultControlWidthFrac is the share of a LabeledRow taken by the control
+// when the caller doesn't give one.
+const defaultControlWidthFrac = float32(0.65)
+
+// LabeledRow lays out an inspector row: label on the left, control on the
+// right. The control column is controlWidthFrac of the available width (0 =
+// 0.65), unless a PushLabelWidth scope fixes the label column, in which case
+// the control gets the rest; use the scope to line rows up across sections.
+//
+// Widgets in the control column that size their box (InputText,
+// NumberInput, sliders, ComboBox) fill it unless given WithWidth. The label
+// is vertically centered on the control and truncated to its column, with
+// the full text in a tooltip on hover.
+//
+// Usage:
+//
+//	ctx.PushLabelWidth(100)
+//	ctx.LabeledRow("Position X", 0)(func() {
+//	    ctx.NumberInputFloat("", &pos.X, gui.WithID("px"))
+//	})
+//	ctx.LabeledRow("Name", 0)(func() {
+//	    ctx.InputText("", &name, gui.WithID("name"))
+//	})
+//	ctx.PopLabelWidth()
+func (ctx *Context) LabeledRow(label string, controlWidthFrac float32) func(func()) {
+	return func(contents func()) {
+		gap := ctx.style.ItemSpacing
+		ctx.HStack(Gap(gap))(func() {
+			w := ctx.ContentRegionAvail().X
+			labelW := w * (1 - defaultControlWidthFrac)
+			if controlWidthFrac > 0 && controlWidthFrac < 1 {
+				labelW = w * (1 - controlWidthFrac)
+			}
+			if n := len(ctx.labelWidthStack); n > 0 {
+				labelW = ctx.labelWidthStack[n-1]
+			}
+			labelW = minf(maxf(labelW-gap, 0), w)
+			lh := ctx.lineHeight()
+
+			// The label is drawn once the control's height is known
+			labelPos := ctx.ItemPos()
+			ctx.advanceCursor(Vec2{X: labelW, Y: lh})
+
+			savedFill := ctx.fillLayout
+			ctx.beginItem() // Nested stacks don't get the row's gap on their own
+			ctx.VStack(Width(maxf(w-labelW-gap, 1)))(func() {
+				ctx.fillLayout = ctx.currentLayout()
+				contents()
+			})
+			ctx.fillLayout = savedFill
+
+			rowH := ctx.currentLayout().MaxHeight
+			text := TruncateText(ctx, label, labelW)
+			ctx.addText(labelPos.X, labelPos.Y+(rowH-lh)/2, text, ctx.style.TextColor)
+			if text != label {
+				ctx.tooltipFor(Rect{X: labelPos.X, Y: labelPos.Y, W: labelW, H: rowH}, label, ctx.DrawList)
+			}
+		})
+	}
+}
+
+// PushLabelWidth fixes the label column of the LabeledRows that follow, in
+// pixels, until the matching PopLabelWidth.
+func (ctx *Context) PushLabelWidth(w float32) {
+	ctx.labelWidthStack = append(ctx.labelWidthStack, w)
+}
+
+// PopLabelWidth restores the previous label column width.
+func (ctx *Context) PopLabelWidth() {
+	if n := len(ctx.labelWidthStack); n > 0 {
+		ctx.labelWidthStack = ctx.labelWidthStack[:n-1]
+	}
+}
+
 // Spacing adds vertical space.
 func (ctx *Context) Spacing(pixels float32) {
 	ctx.cursor.Y += pixels
@@ -441,6 +778,34 @@ func (ctx *Context) Separator() {
 	ctx.cursor.Y += 4
 }
 
+// separatorTextLead is the length of the line before a SeparatorText label.
+const separatorTextLead = SpaceLG
+
+// SeparatorText draws a horizontal line across the layout with label near
+// its left end, the line broken around the text, to group the widgets that
+// follow without a collapsing header. The line uses Style.SeparatorColor
+// and the label Style.TextColor.
+//
+// Usage:
+//
+//	ctx.SeparatorText("Engine")
+//	ctx.SliderFloat("Power", &power, 0, 100)
+func (ctx *Context) SeparatorText(label string) {
+	pos := ctx.ItemPos()
+	w := ctx.currentLayoutWidth()
+	lh := ctx.lineHeight()
+	gap := ctx.style.ItemSpacing
+	y := pos.Y + lh/2
+
+	textX := pos.X + separatorTextLead + gap
+	ctx.DrawList.AddLine(pos.X, y, pos.X+separatorTextLead, y, ctx.style.SeparatorColor, 1)
+	ctx.addText(textX, pos.Y, label, ctx.style.TextColor)
+	if rest := textX + ctx.MeasureText(label).X + gap; rest < pos.X+w {
+		ctx.DrawList.AddLine(rest, y, pos.X+w, y, ctx.style.SeparatorColor, 1)
+	}
+	ctx.advanceCursor(Vec2{X: w, Y: lh})
+}
+
 // ListBox draws a scrollable list area with smooth scrolling.
 // height specifies the visible height; contents can be larger.
 //
@@ -458,14 +823,14 @@ func (ctx *Context) ListBox(id string, height float32, opts ...LayoutOption) fun
 		scrollState := GetState(ctx, scrollID, ScrollState{})
 
 		// Update smooth scrolling
-		scrollState.UpdateSmooth(ctx.DeltaTime)
+		scrollState.updateSmooth(ctx.DeltaTime, ctx.style.SmoothScrollMinStep, ctx.style.SmoothScrollSnap)
 
 		// Save position
 		x, y := ctx.cursor.X, ctx.cursor.Y
 		w := ctx.currentLayoutWidth()
 
 		// Push clip rect for visible area
-		ctx.DrawList.PushClipRect(x, y, x+w, y+height)
+		ctx.pushClipRect(Rect{X: x, Y: y, W: w, H: height})
 
 		// Offset cursor by scroll (use current position, not target)
 		ctx.cursor.Y -= scrollState.ScrollY
@@ -490,15 +855,19 @@ func (ctx *Context) ListBox(id string, height float32, opts ...LayoutOption) fun
 		contentHeight := bounds.H
 
 		// Pop clip rect
-		ctx.DrawList.PopClipRect()
+		ctx.popClipRect()
 
 		// Handle scroll input (update target for smooth scrolling)
 		if ctx.Input != nil && ctx.Input.MouseWheelY != 0 {
 			mouseRect := Rect{X: x, Y: y, W: w, H: height}
 			if mouseRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY}) {
 				maxScroll := maxf(0, contentHeight-height)
-				newTarget := clampf(scrollState.TargetScrollY-ctx.Input.MouseWheelY*30, 0, maxScroll)
+				scroll, precise := ctx.wheelScrollLines(ctx.Input.MouseWheelY, layout.ScrollLines, ctx.lineHeight())
+				newTarget := clampf(scrollState.TargetScrollY-scroll, 0, maxScroll)
 				scrollState.TargetScrollY = newTarget
+				if precise {
+					scrollState.ScrollY = newTarget
+				}
 				scrollState.ContentHeight = contentHeight
 			}
 		}
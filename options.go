@@ -123,6 +123,22 @@ type RangeValue struct {
 	HasRange bool
 }
 
+// TimeFormat selects how a NumberInput shows a value in seconds as a
+// duration.
+type TimeFormat uint8
+
+const (
+	TimeFormatNone   TimeFormat = iota // Plain number (WithFormat applies)
+	TimeFormatMMSS                     // "M:SS", minutes unbounded ("90:00")
+	TimeFormatHHMMSS                   // "H:MM:SS"
+)
+
+// AutoWidthValue sizes a ComboBox to its widest item, up to Max (0 = no cap).
+type AutoWidthValue struct {
+	Enabled bool
+	Max     float32
+}
+
 // FocusValue holds focus Y position and padding for auto-scroll.
 type FocusValue struct {
 	Y       float32
@@ -138,22 +154,57 @@ var (
 	OptForceFocus = NewOptKey("forceFocus", false) // Actually grab keyboard focus
 	OptWidth      = NewOptKey[float32]("width", 0)
 	OptHeight     = NewOptKey[float32]("height", 0)
+	OptNoEscape   = NewOptKey("noEscape", false) // Ignore Escape; it goes to the next element in the chain
+)
+
+// --- Button Options ---
+var (
+	OptActivateOnPress = NewOptKey("activateOnPress", false) // Fire on press instead of release
 )
 
 // --- Slider/NumberInput Options ---
 var (
-	OptFormat    = NewOptKey("format", "")
-	OptStep      = NewOptKey[float32]("step", 0)
-	OptRange     = NewOptKey("range", RangeValue{})
-	OptDragSpeed = NewOptKey[float32]("dragSpeed", 0)
-	OptPrefix    = NewOptKey("prefix", "")
-	OptSuffix    = NewOptKey("suffix", "")
+	OptFormat     = NewOptKey("format", "")
+	OptStep       = NewOptKey[float32]("step", 0)
+	OptRange      = NewOptKey("range", RangeValue{})
+	OptDragSpeed  = NewOptKey[float32]("dragSpeed", 0)
+	OptPrefix     = NewOptKey("prefix", "")
+	OptSuffix     = NewOptKey("suffix", "")
+	OptTypeable   = NewOptKey("typeable", false)            // Double-click a slider to type a value
+	OptTimeFormat = NewOptKey("timeFormat", TimeFormatNone) // NumberInput value is a duration in seconds
+	OptVertical   = NewOptKey("vertical", false)            // Slider track runs top (max) to bottom (min)
+	OptDefault    = NewOptKey[float32]("default", 0)        // Knob value restored by double-click
+
+	optIntValue = NewOptKey("intValue", false) // Set by the Int variants: report changes as int
+)
+
+// --- Change Notification Options ---
+var (
+	OptOnChange = NewOptKey[func()]("onChange", nil) // Called once when the value changes
+	OptDebounce = NewOptKey("debounce", false)       // Report drag/edit changes only when they end
+)
+
+// --- Validation Options ---
+var (
+	OptError = NewOptKey("error", "") // Validation message shown beneath the field ("" = valid)
+)
+
+// --- InputText Options ---
+var (
+	OptGhostCompletion = NewOptKey[func(string) string]("ghostCompletion", nil) // Returns the suggested suffix for the current text
+	OptMask            = NewOptKey[rune]("mask", 0)                             // Glyph drawn in place of every character (0 = show text)
+	OptMaskAllowCopy   = NewOptKey("maskAllowCopy", false)                      // Ctrl+C/Ctrl+X copy the real text of a masked field
+	OptCharFilter      = NewOptKey[func(rune) bool]("charFilter", nil)          // Accepts a typed or pasted character
+	OptMaxLength       = NewOptKey("maxLength", 0)                              // Maximum length in characters (0 = unlimited)
+
+	optPlaceholder = NewOptKey("placeholder", "") // Shown dimmed while empty and not editing (composite widgets)
 )
 
 // --- ComboBox Options ---
 var (
 	OptSearchable        = NewOptKey("searchable", false)
 	OptMaxDropdownHeight = NewOptKey[float32]("maxDropdownHeight", 0)
+	OptAutoWidth         = NewOptKey("autoWidth", AutoWidthValue{})
 )
 
 // --- RadioGroup Options ---
@@ -168,6 +219,7 @@ var (
 	OptHorizontalScroll    = NewOptKey("horizontalScroll", false)
 	OptClampToContent      = NewOptKey("clampToContent", false)
 	OptFocus               = NewOptKey("focus", FocusValue{})
+	OptScrollLines         = NewOptKey("scrollLines", 0) // Lines per wheel notch (0 = Style.ScrollSpeed pixels)
 )
 
 // --- List Options ---
@@ -175,6 +227,7 @@ var (
 	OptFilterPlaceholder = NewOptKey("filterPlaceholder", "")
 	OptMultiSelect       = NewOptKey("multiSelect", false)
 	OptDefaultOpen       = NewOptKey("defaultOpen", false)
+	OptReorder           = NewOptKey[func(from, to int)]("reorder", nil) // Called when a List item is dragged to a new index
 )
 
 // OpenValue wraps a boolean pointer for controlled section state.
@@ -190,6 +243,43 @@ var (
 	OptOpen       = NewOptKey("open", OpenValue{})      // Controlled open state via pointer
 )
 
+// --- CodeBlock Options ---
+var (
+	OptWrap = NewOptKey("wrap", false) // Wrap long lines instead of scrolling horizontally
+)
+
+// --- Alert Options ---
+var (
+	OptDismissible = NewOptKey("dismissible", false)    // Show a dismiss "x" on an Alert
+	OptAction      = NewOptKey("action", AlertAction{}) // Link beneath an Alert's message
+)
+
+// --- Rating Options ---
+var (
+	OptHalfStars = NewOptKey("halfStars", false) // Rating value counts half stars
+)
+
+// --- SegmentedProgress Options ---
+var (
+	OptSegmentColors = NewOptKey[[]uint32]("segmentColors", nil) // Per-segment colors (0 = default)
+)
+
+// --- ColorPicker Options ---
+var (
+	OptAlpha    = NewOptKey("alpha", false)    // Show an alpha bar (ColorEdit: an A row)
+	OptHexInput = NewOptKey("hexInput", false) // ColorEdit shows a hex text field
+)
+
+// --- DatePicker Options ---
+var (
+	OptDateFormat = NewOptKey("dateFormat", "2006-01-02") // time.Format layout of the displayed date
+)
+
+// --- StepIndicator Options ---
+var (
+	OptStepNavigation = NewOptKey("stepNavigation", false) // Completed steps can be clicked
+)
+
 // --- Graph Options ---
 var (
 	OptGraphYMin      = NewOptKey[float32]("graphYMin", 0)
@@ -234,6 +324,14 @@ func WithWidth(width float32) Option { return WithOpt(OptWidth, width) }
 // WithHeight sets a specific height for the widget.
 func WithHeight(height float32) Option { return WithOpt(OptHeight, height) }
 
+// WithNoEscape makes a widget ignore Escape (a text field keeps editing, a
+// ComboBox stays open); the press goes to the next element in the Escape
+// chain instead (see Context.HandleEscape).
+func WithNoEscape() Option { return WithOpt(OptNoEscape, true) }
+
+// WithActivateOnPress makes a button fire on mouse press instead of release.
+func WithActivateOnPress() Option { return WithOpt(OptActivateOnPress, true) }
+
 // WithFormat sets the display format for numeric values.
 func WithFormat(format string) Option { return WithOpt(OptFormat, format) }
 
@@ -248,12 +346,124 @@ func WithRange(minVal, maxVal float32) Option {
 // WithDragSpeed sets the drag sensitivity (pixels per unit change).
 func WithDragSpeed(speed float32) Option { return WithOpt(OptDragSpeed, speed) }
 
+// WithDefault sets the value a Knob returns to when double-clicked.
+func WithDefault(v float32) Option { return WithOpt(OptDefault, v) }
+
 // WithPrefix sets a prefix text displayed before the value.
 func WithPrefix(prefix string) Option { return WithOpt(OptPrefix, prefix) }
 
 // WithSuffix sets a suffix text displayed after the value.
 func WithSuffix(suffix string) Option { return WithOpt(OptSuffix, suffix) }
 
+// WithTypeable lets the user double-click a slider to type an exact value.
+func WithTypeable() Option { return WithOpt(OptTypeable, true) }
+
+// WithTimeFormat makes a NumberInput show its value (seconds) as a duration
+// such as "1:30" and parse typed durations back.
+func WithTimeFormat(format TimeFormat) Option { return WithOpt(OptTimeFormat, format) }
+
+// WithOnChange sets a callback fired once each time the widget's value changes.
+// The callback runs after the new value has been written.
+func WithOnChange(fn func()) Option { return WithOpt(OptOnChange, fn) }
+
+// WithDebounce delays WithOnChange until a drag or text edit finishes.
+func WithDebounce() Option { return WithOpt(OptDebounce, true) }
+
+// WithError shows a validation error on an input: the field gets an
+// error-colored border and the message is drawn on a line beneath it.
+// An empty message means the value is valid and nothing extra is drawn.
+func WithError(msg string) Option { return WithOpt(OptError, msg) }
+
+// WithGhostCompletion shows a dimmed completion after the caret of an
+// InputText. complete receives the current text and returns the suffix to
+// suggest ("" for none); Tab, or Right with the caret at the end, accepts it.
+// The value is unchanged until the suggestion is accepted.
+func WithGhostCompletion(complete func(current string) string) Option {
+	return WithOpt(OptGhostCompletion, complete)
+}
+
+// WithPasswordMode masks an InputText, drawing every character as '•'.
+// The value keeps the real text; copying from the field is disabled.
+func WithPasswordMode() Option { return WithOpt(OptMask, '•') }
+
+// WithMask masks an InputText, drawing every character as mask.
+func WithMask(mask rune) Option { return WithOpt(OptMask, mask) }
+
+// WithPassword is an alias of WithPasswordMode.
+func WithPassword() Option { return WithPasswordMode() }
+
+// WithPasswordMask is an alias of WithMask.
+func WithPasswordMask(mask rune) Option { return WithMask(mask) }
+
+// WithMaskAllowCopy lets Ctrl+C and Ctrl+X copy the real text out of a
+// masked InputText. By default they leave the clipboard and value untouched.
+func WithMaskAllowCopy() Option { return WithOpt(OptMaskAllowCopy, true) }
+
+// WithCharFilter restricts what can be typed or pasted into an InputText
+// to the characters accept returns true for; the rest are dropped.
+//
+//	ctx.InputText("Color", &hex, gui.WithCharFilter(func(r rune) bool {
+//	    return strings.ContainsRune("0123456789abcdefABCDEF", r)
+//	}), gui.WithMaxLength(6))
+func WithCharFilter(accept func(r rune) bool) Option { return WithOpt(OptCharFilter, accept) }
+
+// WithMaxLength limits an InputText to n characters (runes): typing stops
+// at the limit and pasted text is truncated to fit.
+func WithMaxLength(n int) Option { return WithOpt(OptMaxLength, n) }
+
+// WithWrap wraps long lines in a CodeBlock instead of scrolling them
+// horizontally.
+func WithWrap() Option { return WithOpt(OptWrap, true) }
+
+// WithHalfStars lets a Rating be set in half stars; its value then counts
+// half stars (0 to 2*max).
+func WithHalfStars() Option { return WithOpt(OptHalfStars, true) }
+
+// WithDismissible adds a dismiss "x" to an Alert; Alert returns true when
+// it is clicked.
+func WithDismissible() Option { return WithOpt(OptDismissible, true) }
+
+// WithAction adds a link beneath an Alert's message that calls onClick.
+func WithAction(label string, onClick func()) Option {
+	return WithOpt(OptAction, AlertAction{Label: label, OnClick: onClick})
+}
+
+// WithSegmentColors colors SegmentedProgress segments individually, e.g. to
+// show done/failed/pending status. A zero entry, or a missing one, keeps the
+// default fill for completed segments and background for the rest.
+func WithSegmentColors(colors ...uint32) Option { return WithOpt(OptSegmentColors, colors) }
+
+// WithHexInput adds a "#RRGGBB" text field to a ColorEdit.
+func WithHexInput() Option { return WithOpt(OptHexInput, true) }
+
+// WithVertical makes a SliderFloat or SliderInt a vertical fader: the
+// track runs from max at the top to min at the bottom. Size it with
+// WithHeight (track length) and WithWidth (thickness).
+func WithVertical() Option { return WithOpt(OptVertical, true) }
+
+// WithAlpha adds an alpha bar to a ColorPicker or ColorButton popup, and
+// an A row to a ColorEdit. Without it the color's alpha is left unchanged.
+func WithAlpha() Option { return WithOpt(OptAlpha, true) }
+
+// WithDateFormat sets the time.Format layout a DatePicker displays its
+// date with (default "2006-01-02").
+func WithDateFormat(layout string) Option { return WithOpt(OptDateFormat, layout) }
+
+// WithStepNavigation makes a StepIndicator's completed steps clickable, so
+// the user can go back to them.
+func WithStepNavigation() Option { return WithOpt(OptStepNavigation, true) }
+
+// AutoWidth sizes a ComboBox's box and dropdown to fit its widest item,
+// capped at maxWidth if given. Without it the box is at least 150px wide.
+// WithWidth takes precedence.
+func AutoWidth(maxWidth ...float32) Option {
+	v := AutoWidthValue{Enabled: true}
+	if len(maxWidth) > 0 {
+		v.Max = maxWidth[0]
+	}
+	return WithOpt(OptAutoWidth, v)
+}
+
 // WithSearchable enables typing to filter items in a ComboBox.
 func WithSearchable() Option { return WithOpt(OptSearchable, true) }
 
@@ -289,12 +499,20 @@ func FocusY(y float32, padding ...float32) Option {
 	return WithOpt(OptFocus, v)
 }
 
+// WithScrollLines makes each mouse wheel notch scroll n lines of text in a
+// Scrollable or ListBox, instead of Style.ScrollSpeed pixels.
+func WithScrollLines(n int) Option { return WithOpt(OptScrollLines, n) }
+
 // WithFilter enables a search filter input.
 func WithFilter(placeholder string) Option { return WithOpt(OptFilterPlaceholder, placeholder) }
 
 // WithMultiSelect enables selecting multiple items in a list.
 func WithMultiSelect() Option { return WithOpt(OptMultiSelect, true) }
 
+// WithReorderable lets List items be dragged to a new position. On drop,
+// onReorder gets the item's index and the index it should end up at.
+func WithReorderable(onReorder func(from, to int)) Option { return WithOpt(OptReorder, onReorder) }
+
 // DefaultOpen makes sections start in the expanded state.
 func DefaultOpen() Option { return WithOpt(OptDefaultOpen, true) }
 
@@ -35,6 +35,10 @@ type PanelGroup struct {
 
 	// onClose is called when the group is closed.
 	onClose func()
+
+	// ctxEscape is set once drawn: Escape then comes through the context's
+	// Escape chain (see Context.HandleEscape) instead of HandleInput.
+	ctxEscape bool
 }
 
 // groupedPanel holds a panel with its tab name.
@@ -184,6 +188,15 @@ func (pg *PanelGroup) Draw(ctx *Context) {
 		return
 	}
 
+	// Escape closes the group unless a text field or popup takes it first
+	pg.ctxEscape = true
+	escapeID := ctx.GetID("##group_" + pg.ID)
+	if ctx.escapeFor(escapeModal, escapeID) {
+		pg.Close()
+		return
+	}
+	ctx.claimEscape(escapeModal, escapeID)
+
 	// Handle drag
 	pg.HandleDrag(ctx)
 
@@ -191,6 +204,7 @@ func (pg *PanelGroup) Draw(ctx *Context) {
 	ctx.SetCursorPos(pg.Position.X, pg.Position.Y)
 
 	// Draw container panel
+	panelIndex := len(ctx.panelRects)
 	ctx.Panel(pg.ID, Padding(ctx.Style().PanelPadding))(func() {
 		// Draw tab bar
 		ctx.HStack(Gap(SpaceXS))(func() {
@@ -223,8 +237,9 @@ func (pg *PanelGroup) Draw(ctx *Context) {
 		}
 	})
 
-	// Update our size based on what was drawn
-	// (In a real implementation, we'd measure the content)
+	// Update our size based on what was drawn (used for dragging and z-order)
+	r := ctx.panelRects[panelIndex]
+	pg.Size = Vec2{X: r.W, Y: r.H}
 }
 
 // HandleInput processes input for the panel group.
@@ -245,8 +260,8 @@ func (pg *PanelGroup) HandleInput(input *InputState) bool {
 		}
 	}
 
-	// Escape closes the group
-	if input.KeyPressed(KeyEscape) {
+	// Escape closes the group (once drawn, Draw handles it instead)
+	if !pg.ctxEscape && input.KeyPressed(KeyEscape) {
 		pg.Close()
 		return true
 	}
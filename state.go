@@ -1,5 +1,7 @@
 package gui
 
+import "time"
+
 // StateStore persists widget state between frames.
 // Unlike ImGui's hidden state, this is explicit and inspectable.
 type StateStore interface {
@@ -57,21 +59,54 @@ type ScrollState struct {
 	ContentHeight float32 // Total content height
 }
 
+// Smooth scrolling eases exponentially toward the target, which on its own
+// never quite arrives and leaves a fractional offset that blurs text. Each
+// frame therefore moves at least Style.SmoothScrollMinStep pixels, and once
+// within Style.SmoothScrollSnap pixels the position snaps exactly to the
+// target. Both only matter near the end of a scroll; larger moves keep the
+// eased motion. These are the values used when the style leaves them 0.
+const (
+	defaultSmoothScrollMinStep float32 = 1
+	defaultSmoothScrollSnap    float32 = 1
+)
+
+// smoothScrollSpeed controls convergence: higher = faster.
+const smoothScrollSpeed = 15.0
+
+// smoothScrollStep advances pos one frame toward target, moving at least
+// minStep and snapping once within snap. Returns the new position and true
+// if still animating.
+func smoothScrollStep(pos, target, deltaTime, minStep, snap float32) (float32, bool) {
+	diff := target - pos
+	if absf32(diff) <= snap {
+		return target, false
+	}
+	step := diff * minf(1, deltaTime*smoothScrollSpeed)
+	if absf32(step) < minStep {
+		step = minStep
+		if diff < 0 {
+			step = -step
+		}
+	}
+	if absf32(step) >= absf32(diff) {
+		return target, false
+	}
+	return pos + step, true
+}
+
 // UpdateSmooth smoothly interpolates scroll position toward target.
-// Call this each frame with the frame's delta time.
+// Call this each frame with the frame's delta time. It uses the default
+// SmoothScrollMinStep and SmoothScrollSnap; widgets use their style's.
 // Returns true if still animating.
 func (s *ScrollState) UpdateSmooth(deltaTime float32) bool {
-	const smoothSpeed = 15.0 // Higher = faster convergence
-	const threshold = 0.5    // Stop animating when this close
-
-	diff := s.TargetScrollY - s.ScrollY
-	if absf32(diff) < threshold {
-		s.ScrollY = s.TargetScrollY
-		return false
-	}
+	return s.updateSmooth(deltaTime, defaultSmoothScrollMinStep, defaultSmoothScrollSnap)
+}
 
-	s.ScrollY += diff * deltaTime * smoothSpeed
-	return true
+// updateSmooth is UpdateSmooth with the given minimum step and snap distance.
+func (s *ScrollState) updateSmooth(deltaTime, minStep, snap float32) bool {
+	var animating bool
+	s.ScrollY, animating = smoothScrollStep(s.ScrollY, s.TargetScrollY, deltaTime, minStep, snap)
+	return animating
 }
 
 // absf32 returns the absolute value of a float32.
@@ -102,6 +137,25 @@ type InputTextState struct {
 	// Horizontal scroll offset for long text that exceeds input width
 	ScrollOffset float32
 
+	// InputTextMultiline: visual row and column of the cursor after
+	// wrapping, and the vertical scroll offset
+	CursorRow int
+	CursorCol int
+	ScrollY   float32
+
+	// Caret X kept while moving with Up/Down
+	preferredX    float32
+	hasPreferredX bool
+
+	// Mouse selection while the button is held: the press's click count
+	// (1 = by character, 2 = by word, 3 = all) and the range it selected
+	dragClicks         int
+	dragStart, dragEnd int
+
+	// InputTextMultiline scrollbar thumb drag
+	scrollDragging                          bool
+	scrollDragStartY, scrollDragStartScroll float32
+
 	// Undo/redo stack
 	UndoStack []string // Previous text states
 	UndoIndex int      // Current position in undo stack
@@ -217,6 +271,87 @@ type SliderState struct {
 	Dragging       bool    // True when the grab handle is being dragged
 	DragStartX     float32 // Mouse X position when drag started
 	DragStartValue float32 // Value when drag started
+	Editing        bool    // True while a typed value is being entered (WithTypeable)
+	EditText       string  // Text being typed
+	EditSelected   bool    // True while the pre-filled value is selected (typing replaces it)
+	DragHigh       bool    // SliderFloatRange: the high handle is dragged (or moved by the keys)
+}
+
+// KnobState tracks a Knob's drag.
+type KnobState struct {
+	Dragging       bool    // True while the knob is being dragged
+	DragStartValue float32 // Value when the drag started
+}
+
+// DatePickerState tracks a DatePicker's calendar popup.
+type DatePickerState struct {
+	Open   bool      // True while the calendar is shown
+	Month  time.Time // First day of the month shown
+	Cursor time.Time // Day highlighted for keyboard selection
+}
+
+// ColorPickerState tracks a ColorPicker's color in HSV. Keeping HSV across
+// frames preserves the hue of grays and the saturation of black, and stops
+// round-trips through the packed color from drifting while dragging.
+type ColorPickerState struct {
+	H, S, V  float32 // Current color (0.0-1.0)
+	Color    uint32  // Packed color H, S and V were last synced with
+	Synced   bool    // Color (ColorEdit: HexColor) is valid
+	Dragging int     // Region being dragged: 0 = none, 1 = saturation/value square, 2 = hue bar, 3 = alpha bar
+	Open     bool    // ColorButton popup is open
+	HexText  string  // ColorEdit hex field text
+	HexColor uint32  // Color HexText was last formatted from or parsed to
+}
+
+// ProgressBarState tracks the displayed fill of a smoothed progress bar, or
+// the moving block of an indeterminate one.
+type ProgressBarState struct {
+	Displayed   float32 // Fraction currently drawn (eases toward the target)
+	Initialized bool    // False until the first frame sets Displayed
+	Marquee     float32 // ProgressBarIndeterminate: block position, 0-1 across the track
+}
+
+// EditableLabelState tracks state for EditableLabel widgets.
+type EditableLabelState struct {
+	Editing bool   // True while the inline InputText is shown
+	Buffer  string // Text being edited (committed to the value on Enter/blur)
+	Rect    Rect   // Widget bounds from the last frame (for click-outside)
+}
+
+// CodeBlockState tracks state for CodeBlock widgets.
+type CodeBlockState struct {
+	ScrollX  float32 // Horizontal scroll offset (unwrapped blocks)
+	Copied   bool    // True while the copy button shows "Copied!"
+	CopiedAt float32 // Context.Time when the text was last copied
+}
+
+// FormState tracks state for Form builders.
+type FormState struct {
+	Touched  map[string]bool // Field labels the user has changed (errors are shown)
+	TabbedTo ID              // Field focused by Tab, to start editing if it is a text field
+}
+
+// OverflowMenuState tracks state for OverflowMenu rows.
+type OverflowMenuState struct {
+	Open          bool      // True while the overflow dropdown is shown
+	KeyboardIndex int       // Keyboard-selected dropdown entry (-1 = none)
+	Widths        []float32 // Inline width of each item last frame (for the fit)
+	Overflowed    int       // Items in the dropdown last frame
+	MenuWidth     float32   // Widest dropdown entry last frame
+}
+
+// PopupState tracks a context menu opened by BeginPopupContextItem.
+type PopupState struct {
+	Open          bool // True while the popup is shown
+	Pos           Vec2 // Top-left corner: where the item was right-clicked
+	Size          Vec2 // Size measured last frame (zero until first measured)
+	KeyboardIndex int  // Keyboard-selected entry (-1 = none)
+	Submenu       ID   // Open submenu (0 = none)
+}
+
+// MenuBarState tracks which of a menu bar's menus is open.
+type MenuBarState struct {
+	OpenMenu ID // Open menu (0 = none)
 }
 
 // ComboBoxState tracks state for combo box widgets.
@@ -226,6 +361,14 @@ type ComboBoxState struct {
 	HoveredIndex  int     // Currently hovered item index (-1 = none)
 	KeyboardIndex int     // Currently keyboard-selected index (-1 = none)
 	SearchText    string  // Text typed for filtering (when searchable)
+	OpenUpward    bool    // Dropdown drawn above the header (no room below when opened)
+
+	// Cached widest item width (see comboItemsWidth)
+	ItemsMeasured   bool
+	ItemsHash       uint64
+	ItemsLineHeight float32
+	ItemsTextGen    uint64 // Context's InvalidateTextCache count when measured
+	ItemsWidth      float32
 }
 
 // ScrollableState tracks state for scrollable areas.
@@ -248,32 +391,13 @@ type ScrollableState struct {
 }
 
 // UpdateSmoothScroll smoothly interpolates scroll positions toward targets.
-// Call this each frame. Returns true if still animating.
+// Call this each frame. Like ScrollState.UpdateSmooth it uses the default
+// SmoothScrollMinStep and SmoothScrollSnap. Returns true if still animating.
 func (s *ScrollableState) UpdateSmoothScroll(deltaTime float32) bool {
-	const smoothSpeed = 15.0
-	const threshold = 0.5
-
-	animating := false
-
-	// Vertical
-	diffY := s.TargetScrollY - s.ScrollY
-	if absf32(diffY) < threshold {
-		s.ScrollY = s.TargetScrollY
-	} else {
-		s.ScrollY += diffY * deltaTime * smoothSpeed
-		animating = true
-	}
-
-	// Horizontal
-	diffX := s.TargetScrollX - s.ScrollX
-	if absf32(diffX) < threshold {
-		s.ScrollX = s.TargetScrollX
-	} else {
-		s.ScrollX += diffX * deltaTime * smoothSpeed
-		animating = true
-	}
-
-	return animating
+	var animatingY, animatingX bool
+	s.ScrollY, animatingY = smoothScrollStep(s.ScrollY, s.TargetScrollY, deltaTime, defaultSmoothScrollMinStep, defaultSmoothScrollSnap)
+	s.ScrollX, animatingX = smoothScrollStep(s.ScrollX, s.TargetScrollX, deltaTime, defaultSmoothScrollMinStep, defaultSmoothScrollSnap)
+	return animatingY || animatingX
 }
 
 // ListState tracks state for list components.
@@ -285,6 +409,27 @@ type ListState struct {
 	SelectedIndex     int             // Currently selected item index
 }
 
+// VirtualTreeState tracks state for virtualized tree widgets.
+type VirtualTreeState struct {
+	ScrollY  float32 // Scroll position
+	Selected int     // Selected visible row index (-1 = none)
+}
+
+// LogViewState tracks state for log views.
+type LogViewState struct {
+	ScrollY float32    // Scroll position
+	Pinned  bool       // True while following the newest line
+	Filter  TextFilter // Filter typed into the filter box
+	Anchor  int        // Line index where the selection started (-1 = none)
+	Cursor  int        // Line index where the selection ends (-1 = none)
+
+	// Cached indices of the lines passing Filter, extended as lines are
+	// appended and rebuilt when the filter changes or lines are removed
+	visible     []int
+	visibleFor  string
+	visibleUpTo int
+}
+
 // NumberInputState tracks state for number input widgets.
 type NumberInputState struct {
 	Editing        bool    // True when in text edit mode
@@ -21,17 +21,25 @@ type Style struct {
 	TextDisabledColor  uint32
 	TextHighlightColor uint32
 
+	// Text shadow, for readability over the game scene
+	TextShadow       bool   // Draw all text over a shadow copy
+	TextShadowColor  uint32 // Shadow color (0 = translucent black)
+	TextShadowOffset Vec2   // Shadow offset (zero = 1px down and right)
+
 	// Panel colors
 	PanelColor           uint32
 	PanelBorderColor     uint32
 	PanelHeaderBgColor   uint32 // Header background (0 = use ButtonColor)
 	PanelHeaderTextColor uint32 // Header text (0 = use TextColor)
+	PanelGradient        bool   // Shade panel backgrounds from a lighter top down to PanelColor
+	HeaderGradient       bool   // Shade panel, section, list and table headers from a lighter top
 
 	// Button colors
 	ButtonColor         uint32
 	ButtonHoveredColor  uint32
 	ButtonActiveColor   uint32
 	ButtonDisabledColor uint32
+	ButtonGradient      bool // Shade buttons from a lighter top down to their state color
 
 	// Selection colors
 	SelectedBgColor   uint32
@@ -64,18 +72,35 @@ type Style struct {
 	SliderGrabHovered uint32 // Handle when hovered
 	SliderGrabActive  uint32 // Handle when dragging
 
+	// Progress bar
+	ProgressBarGradient bool // Shade ProgressBar fills from a lighter top down to SelectedBgColor
+
 	// Dropdown/ComboBox colors
 	DropdownBgColor uint32 // Dropdown menu background
 	ComboArrowColor uint32 // Arrow indicator color
 
+	// Checkbox
+	CheckmarkColor uint32 // Check mark in checked boxes (0 = use TextColor)
+
 	// Focus indicator
 	FocusColor uint32
 
+	// Text editing
+	CaretColor     uint32 // Text cursor in inputs (0 = use TextColor)
+	SelectionColor uint32 // Selected text highlight in inputs (0 = use SelectedBgColor)
+
+	// Accessibility
+	HighContrast bool // Replace focus, selection and caret colors with HighContrast* values and thicken borders
+
 	// Toast notification colors
 	ToastInfoColor    uint32
 	ToastSuccessColor uint32
 	ToastWarningColor uint32
 	ToastErrorColor   uint32
+	AlertTextColor    uint32 // Text, icon and controls on Alert banners (0 = white)
+
+	// Validation
+	ErrorColor uint32 // Border and message color for inputs with WithError
 
 	// Font
 	FontName string // Font name for use with FontManager (e.g., "font1", "plate")
@@ -84,17 +109,92 @@ type Style struct {
 	FontScale     float32
 	CharWidth     float32
 	CharHeight    float32
+	TabWidth      float32 // Tab stop spacing in pixels for '\t' in text (0 = no expansion)
 	ItemSpacing   float32 // Default gap between items
 	PanelPadding  float32
 	ButtonPadding float32
 	InputPadding  float32
+	CellPaddingX  float32 // Horizontal padding inside table cells (0 = ItemSpacing)
+	CellPaddingY  float32 // Vertical padding inside table cells (added to row height)
+
+	// Lines
+	AntiAliasedLines bool // Feather the edges of lines (DrawList.AddLineAA)
 
 	// Border
-	BorderSize float32
-	Rounding   float32 // Corner rounding (0 = sharp corners)
+	BorderSize   float32
+	CornerRadius float32 // Corner radius of panels and buttons (0 = sharp corners)
+
+	// Deprecated: Rounding is the old name of CornerRadius, used when
+	// CornerRadius is 0.
+	Rounding float32
 
 	// Scrollbar
 	ScrollbarSize float32
+
+	// Smooth scrolling eases toward its target; these keep it from ending
+	// on a fractional offset that blurs text (see smoothScrollStep)
+	SmoothScrollMinStep float32 // Minimum pixels moved per frame while animating (0 = 1)
+	SmoothScrollSnap    float32 // Snap to the target when this close, in pixels (0 = 1)
+
+	// Mouse
+	DragThreshold float32 // Pixels the mouse must move from the press point to count as a drag
+	ScrollSpeed   float32 // Pixels scrolled per mouse wheel notch
+}
+
+// defaultTextShadowColor is the shadow color when Style.TextShadowColor is 0.
+var defaultTextShadowColor = RGBA(0, 0, 0, 192)
+
+// High-contrast colors used in place of the style's own when
+// Style.HighContrast is set.
+var (
+	HighContrastFocusColor        = ColorYellow
+	HighContrastCaretColor        = ColorYellow
+	HighContrastSelectionColor    = RGBA(0, 255, 255, 255)
+	HighContrastSelectedTextColor = ColorBlack
+)
+
+// highContrastBorderSize is the minimum border thickness in high-contrast mode.
+const highContrastBorderSize = float32(2)
+
+// Effective returns the style widgets actually draw with: CaretColor,
+// SelectionColor, CheckmarkColor, AlertTextColor and the SmoothScroll* steps
+// are filled in from their fallbacks and CornerRadius from the deprecated
+// Rounding. With HighContrast the focus, selection and caret colors are
+// replaced by the HighContrast* values and BorderSize is raised to at least
+// 2. The Context applies it on every SetStyle/PushStyle, so toggling
+// HighContrast takes effect on the next frame. Applying it twice gives the
+// same result.
+func (s Style) Effective() Style {
+	if s.HighContrast {
+		s.FocusColor = HighContrastFocusColor
+		s.CaretColor = HighContrastCaretColor
+		s.SelectionColor = HighContrastSelectionColor
+		s.SelectedBgColor = HighContrastSelectionColor
+		s.SelectedTextColor = HighContrastSelectedTextColor
+		s.BorderSize = maxf(s.BorderSize, highContrastBorderSize)
+	}
+	if s.CaretColor == 0 {
+		s.CaretColor = s.TextColor
+	}
+	if s.SelectionColor == 0 {
+		s.SelectionColor = s.SelectedBgColor
+	}
+	if s.CheckmarkColor == 0 {
+		s.CheckmarkColor = s.TextColor
+	}
+	if s.CornerRadius == 0 {
+		s.CornerRadius = s.Rounding
+	}
+	if s.AlertTextColor == 0 {
+		s.AlertTextColor = ColorWhite
+	}
+	if s.SmoothScrollMinStep == 0 {
+		s.SmoothScrollMinStep = defaultSmoothScrollMinStep
+	}
+	if s.SmoothScrollSnap == 0 {
+		s.SmoothScrollSnap = defaultSmoothScrollSnap
+	}
+	return s
 }
 
 // DefaultStyle returns the default style with sensible defaults.
@@ -161,21 +261,30 @@ func DefaultStyle() Style {
 		ToastWarningColor: RGBA(180, 130, 40, 230),
 		ToastErrorColor:   RGBA(180, 60, 60, 230),
 
+		// Validation
+		ErrorColor: RGBA(220, 80, 80, 255),
+
 		// Sizing
 		FontScale:     1.0,
 		CharWidth:     8,
 		CharHeight:    8,
+		TabWidth:      32,
 		ItemSpacing:   4,
 		PanelPadding:  8,
 		ButtonPadding: 6,
 		InputPadding:  4,
+		CellPaddingX:  4,
 
 		// Border
-		BorderSize: 1,
-		Rounding:   0,
+		BorderSize:   1,
+		CornerRadius: 0,
 
 		// Scrollbar
 		ScrollbarSize: 12,
+
+		// Mouse
+		DragThreshold: 3,
+		ScrollSpeed:   30,
 	}
 }
 
@@ -244,6 +353,9 @@ func GTAStyle() Style {
 		ToastWarningColor: RGBA(200, 150, 0, 230),
 		ToastErrorColor:   RGBA(180, 40, 40, 230),
 
+		// Validation
+		ErrorColor: RGBA(230, 60, 60, 255),
+
 		// Font
 		FontName: "font1", // Use GTA's font1 when loaded
 
@@ -251,17 +363,23 @@ func GTAStyle() Style {
 		FontScale:     1.5,
 		CharWidth:     8,
 		CharHeight:    8,
+		TabWidth:      48,
 		ItemSpacing:   6,
 		PanelPadding:  12,
 		ButtonPadding: 8,
 		InputPadding:  6,
+		CellPaddingX:  6,
 
 		// Border
-		BorderSize: 1,
-		Rounding:   0, // Sharp corners like GTA menus
+		BorderSize:   1,
+		CornerRadius: 0, // Sharp corners like GTA menus
 
 		// Scrollbar
 		ScrollbarSize: 14,
+
+		// Mouse
+		DragThreshold: 3,
+		ScrollSpeed:   30,
 	}
 }
 
@@ -324,17 +442,25 @@ func LightStyle() Style {
 		DropdownBgColor: RGBA(255, 255, 255, 255),
 		ComboArrowColor: RGBA(80, 80, 80, 255),
 
+		// Validation (light theme)
+		ErrorColor: RGBA(200, 40, 40, 255),
+
 		FontScale:     1.0,
 		CharWidth:     8,
 		CharHeight:    8,
+		TabWidth:      32,
 		ItemSpacing:   4,
 		PanelPadding:  8,
 		ButtonPadding: 6,
 		InputPadding:  4,
+		CellPaddingX:  4,
 
-		BorderSize: 1,
-		Rounding:   0,
+		BorderSize:   1,
+		CornerRadius: 0,
 
 		ScrollbarSize: 12,
+
+		DragThreshold: 3,
+		ScrollSpeed:   30,
 	}
 }
@@ -0,0 +1,89 @@
+package gui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// SaveStyle writes s to w as a JSON object with one key per Style field,
+// in declaration order. Colors are written as "#RRGGBBAA" hex strings;
+// sizes, flags, names and offsets use their plain JSON form.
+//
+// Usage:
+//
+//	f, _ := os.Create("theme.json")
+//	defer f.Close()
+//	err := gui.SaveStyle(f, gui.GTAStyle())
+func SaveStyle(w io.Writer, s Style) error {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	v := reflect.ValueOf(s)
+	t := v.Type()
+	for i := range t.NumField() {
+		f := v.Field(i)
+		var value any = f.Interface()
+		if f.Kind() == reflect.Uint32 {
+			value = formatHexColor(uint32(f.Uint()), true)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("style field %s: %w", t.Field(i).Name, err)
+		}
+		fmt.Fprintf(&buf, "  %q: %s", t.Field(i).Name, data)
+		if i < t.NumField()-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("}\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// LoadStyle reads a style written by SaveStyle. Fields missing from the
+// JSON keep their DefaultStyle value, so a theme file only needs the
+// fields it changes; unknown fields are an error. Colors may be given as
+// "#RRGGBB" (opaque) or "#RRGGBBAA", with or without the '#'.
+//
+// Usage:
+//
+//	f, err := os.Open("theme.json")
+//	...
+//	style, err := gui.LoadStyle(f)
+//	if err == nil {
+//	    ui.SetStyle(style)
+//	}
+func LoadStyle(r io.Reader) (Style, error) {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&fields); err != nil {
+		return Style{}, fmt.Errorf("style: %w", err)
+	}
+
+	s := DefaultStyle()
+	v := reflect.ValueOf(&s).Elem()
+	for name, data := range fields {
+		f := v.FieldByName(name)
+		if !f.IsValid() {
+			return Style{}, fmt.Errorf("style: unknown field %q", name)
+		}
+		if f.Kind() != reflect.Uint32 {
+			if err := json.Unmarshal(data, f.Addr().Interface()); err != nil {
+				return Style{}, fmt.Errorf("style field %s: %w", name, err)
+			}
+			continue
+		}
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return Style{}, fmt.Errorf("style field %s: want a hex color string: %w", name, err)
+		}
+		c, ok := parseHexColor(text, ColorBlack, true)
+		if !ok {
+			return Style{}, fmt.Errorf("style field %s: invalid color %q", name, text)
+		}
+		f.SetUint(uint64(c))
+	}
+	return s, nil
+}
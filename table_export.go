@@ -0,0 +1,136 @@
+package gui
+
+import (
+	"encoding/csv"
+	"slices"
+	"strings"
+)
+
+// capturedRow is the text drawn in one table row's cells.
+type capturedRow struct {
+	index int      // Row index
+	cells []string // Text per column
+}
+
+// TableToCSV formats rows as CSV, with a header line of the column labels.
+// Columns marked Hidden are left out; each row holds one string per column
+// (missing cells are empty). Fields are quoted where needed.
+//
+// Usage:
+//
+//	if ctx.Button("Copy") {
+//	    gui.ClipboardSetText(gui.TableToCSV(columns, rows))
+//	}
+func TableToCSV(columns []TableColumn, rows [][]string) string {
+	return tableToDelimited(columns, rows, ',', true)
+}
+
+// TableToTSV is TableToCSV with tab-separated fields, which pastes into
+// spreadsheets as cells.
+func TableToTSV(columns []TableColumn, rows [][]string) string {
+	return tableToDelimited(columns, rows, '\t', true)
+}
+
+// tableToDelimited writes the rows, and the header if asked, with the given
+// separator.
+func tableToDelimited(columns []TableColumn, rows [][]string, sep rune, header bool) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Comma = sep
+
+	record := make([]string, 0, len(columns))
+	if header {
+		for _, col := range columns {
+			if !col.Hidden {
+				record = append(record, col.Label)
+			}
+		}
+		_ = w.Write(record) // strings.Builder doesn't fail
+	}
+
+	for _, row := range rows {
+		record = record[:0]
+		for i, col := range columns {
+			if col.Hidden {
+				continue
+			}
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			record = append(record, cell)
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	return sb.String()
+}
+
+// ExportCSV returns the cells drawn so far this frame as CSV, with the
+// visible columns' labels as the header. The table must be begun with
+// TableOptions{CaptureText: true}; call it after drawing the rows.
+// Text cells export their text, TableInputText its value and TableCheckbox
+// "true" or "false". Virtualized tables only export the rows drawn.
+//
+// Usage:
+//
+//	t := ctx.BeginTableEx("vehicles", columns, flags, 0, 0, gui.TableOptions{CaptureText: true})
+//	// ... rows ...
+//	if copyPressed {
+//	    gui.ClipboardSetText(t.ExportCSV())
+//	}
+//	t.EndTable()
+func (t *Table) ExportCSV() string {
+	return TableToCSV(t.columns, t.capturedCells(nil))
+}
+
+// ExportTSV is ExportCSV with tab-separated fields.
+func (t *Table) ExportTSV() string {
+	return TableToTSV(t.columns, t.capturedCells(nil))
+}
+
+// CopySelectedRows copies the visible cells of the selected rows to the
+// clipboard, tab-separated with one line per row. It needs
+// TableFlagsRowSelect and is called automatically by EndTable when Ctrl+C
+// is pressed while a row is selected (and no widget has the keyboard).
+// Call it after drawing the rows.
+func (t *Table) CopySelectedRows() {
+	if len(t.selectedRows) == 0 {
+		return
+	}
+	ClipboardSetText(tableToDelimited(t.columns, t.capturedCells(t.selectedRows), '\t', false))
+}
+
+// capturedCells returns the captured rows in draw order, only those listed
+// in rows unless it is nil.
+func (t *Table) capturedCells(rows []int) [][]string {
+	cells := make([][]string, 0, len(t.captured))
+	for _, row := range t.captured {
+		if rows == nil || slices.Contains(rows, row.index) {
+			cells = append(cells, row.cells)
+		}
+	}
+	return cells
+}
+
+// captureCell records the current cell's text when the table captures text
+// (TableOptions.CaptureText), or for CopySelectedRows when the row is
+// selected.
+func (t *Table) captureCell(text string) {
+	if !t.options.CaptureText && !t.rowSelected || t.currentRow < 0 || t.currentColumn < 0 {
+		return
+	}
+	if n := len(t.captured); n == 0 || t.captured[n-1].index != t.currentRow {
+		t.captured = append(t.captured, capturedRow{index: t.currentRow, cells: make([]string, len(t.columns))})
+	}
+	t.captured[len(t.captured)-1].cells[t.currentColumn] = text
+}
+
+// selectRow records whether the row being started is selected, for
+// CopySelectedRows.
+func (t *Table) selectRow(selected bool) {
+	t.rowSelected = selected
+	if selected {
+		t.selectedRows = append(t.selectedRows, t.currentRow)
+	}
+}
@@ -297,3 +297,65 @@ func MeasureWrappedText(ctx *Context, text string, maxWidth float32, mode TextWr
 		Y: float32(len(lines)) * lineHeight,
 	}
 }
+
+// TextFilter matches text against a comma-separated filter such as
+// "error,warn" or "-debug". Text passes when it contains any include term
+// (or there are none) and no exclude term (prefixed with '-'). Matching
+// ignores case and surrounding spaces in terms. The zero value passes
+// everything.
+type TextFilter struct {
+	Text string // Filter expression
+
+	parsed  string // Text the terms were parsed from
+	ready   bool   // parsed is valid
+	include []string
+	exclude []string
+}
+
+// IsActive returns true if the filter has any terms.
+func (f *TextFilter) IsActive() bool {
+	f.parse()
+	return len(f.include) > 0 || len(f.exclude) > 0
+}
+
+// PassFilter returns true if text matches the filter.
+func (f *TextFilter) PassFilter(text string) bool {
+	f.parse()
+	if len(f.include) == 0 && len(f.exclude) == 0 {
+		return true
+	}
+	lower := strings.ToLower(text)
+	for _, term := range f.exclude {
+		if strings.Contains(lower, term) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, term := range f.include {
+		if strings.Contains(lower, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse splits Text into terms when it has changed.
+func (f *TextFilter) parse() {
+	if f.ready && f.parsed == f.Text {
+		return
+	}
+	f.parsed, f.ready = f.Text, true
+	f.include, f.exclude = f.include[:0], f.exclude[:0]
+	for _, term := range strings.Split(strings.ToLower(f.Text), ",") {
+		term = strings.TrimSpace(term)
+		if exclude, ok := strings.CutPrefix(term, "-"); ok {
+			if exclude = strings.TrimSpace(exclude); exclude != "" {
+				f.exclude = append(f.exclude, exclude)
+			}
+		} else if term != "" {
+			f.include = append(f.include, term)
+		}
+	}
+}
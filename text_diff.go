@@ -0,0 +1,101 @@
+package gui
+
+import "unicode"
+
+// TextSpan colors a byte range [Start, End) of a string drawn with
+// TextHighlighted.
+type TextSpan struct {
+	Start, End int
+	Color      uint32 // Text color (0 = Style.TextColor)
+	Bg         uint32 // Background fill (0 = none)
+}
+
+// Diff colors: text in red/green over a faint fill of the same hue, so
+// changed whitespace is visible too.
+var (
+	diffRemovedColor = ColorRed
+	diffRemovedBg    = RGBA(255, 0, 0, 60)
+	diffAddedColor   = ColorGreen
+	diffAddedBg      = RGBA(0, 255, 0, 60)
+)
+
+// DiffSpans computes a word-level diff of a and b and returns spans for
+// TextHighlighted: aSpans mark text removed from a (red), bSpans text added
+// in b (green).
+//
+// The strings are split into runs of words and runs of whitespace, and the
+// runs are matched with a longest common subsequence, so a change to the
+// spacing between words is reported like any other change. Ties are always
+// broken the same way, making the result deterministic. Either string may be
+// empty, in which case the whole of the other is reported.
+//
+// Usage:
+//
+//	oldSpans, newSpans := gui.DiffSpans(before, after)
+//	ctx.TextHighlighted(before, oldSpans)
+//	ctx.TextHighlighted(after, newSpans)
+func DiffSpans(a, b string) (aSpans, bSpans []TextSpan) {
+	ta, tb := diffTokens(a), diffTokens(b)
+	n, m := len(ta), len(tb)
+
+	// lcs[i][j] is the LCS length of ta[i:] and tb[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[ta[i][0]:ta[i][1]] == b[tb[j][0]:tb[j][1]] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[ta[i][0]:ta[i][1]] == b[tb[j][0]:tb[j][1]]:
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			aSpans = appendDiffSpan(aSpans, ta[i], diffRemovedColor, diffRemovedBg)
+			i++
+		default:
+			bSpans = appendDiffSpan(bSpans, tb[j], diffAddedColor, diffAddedBg)
+			j++
+		}
+	}
+	return aSpans, bSpans
+}
+
+// diffTokens splits s into byte ranges of alternating whitespace and
+// non-whitespace runs.
+func diffTokens(s string) [][2]int {
+	var tokens [][2]int
+	start := 0
+	prevSpace := false
+	for i, r := range s {
+		space := unicode.IsSpace(r)
+		if i > 0 && space != prevSpace {
+			tokens = append(tokens, [2]int{start, i})
+			start = i
+		}
+		prevSpace = space
+	}
+	if start < len(s) {
+		tokens = append(tokens, [2]int{start, len(s)})
+	}
+	return tokens
+}
+
+// appendDiffSpan adds tok as a span, extending the last span when the two
+// are adjacent.
+func appendDiffSpan(spans []TextSpan, tok [2]int, color, bg uint32) []TextSpan {
+	if n := len(spans); n > 0 && spans[n-1].End == tok[0] {
+		spans[n-1].End = tok[1]
+		return spans
+	}
+	return append(spans, TextSpan{Start: tok[0], End: tok[1], Color: color, Bg: bg})
+}
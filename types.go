@@ -3,6 +3,8 @@
 // and type safety.
 package gui
 
+import "math"
+
 // Vec2 represents a 2D vector for positions and sizes.
 type Vec2 struct {
 	X, Y float32
@@ -40,6 +42,25 @@ func (r Rect) Intersects(other Rect) bool {
 		r.Y < other.Y+other.H && r.Y+r.H > other.Y
 }
 
+// Intersect returns the overlapping area of two rectangles.
+// Returns a zero-size rect if they don't overlap.
+func (r Rect) Intersect(other Rect) Rect {
+	x1 := maxf(r.X, other.X)
+	y1 := maxf(r.Y, other.Y)
+	x2 := minf(r.X+r.W, other.X+other.W)
+	y2 := minf(r.Y+r.H, other.Y+other.H)
+	return Rect{X: x1, Y: y1, W: maxf(0, x2-x1), H: maxf(0, y2-y1)}
+}
+
+// Union returns the smallest rectangle containing both rectangles.
+func (r Rect) Union(other Rect) Rect {
+	x1 := minf(r.X, other.X)
+	y1 := minf(r.Y, other.Y)
+	x2 := maxf(r.X+r.W, other.X+other.W)
+	y2 := maxf(r.Y+r.H, other.Y+other.H)
+	return Rect{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}
+}
+
 // Vertex represents a vertex for UI rendering.
 // Memory layout matches OpenGL vertex attribute expectations.
 type Vertex struct {
@@ -94,6 +115,77 @@ func UnpackRGBA(c uint32) (r, g, b, a uint8) {
 	return uint8(c), uint8(c >> 8), uint8(c >> 16), uint8(c >> 24)
 }
 
+// lightenColor mixes c toward white by t (0.0-1.0), keeping its alpha.
+func lightenColor(c uint32, t float32) uint32 {
+	r, g, b, a := UnpackRGBA(c)
+	mix := func(v uint8) uint8 { return v + uint8(float32(255-v)*t) }
+	return RGBA(mix(r), mix(g), mix(b), a)
+}
+
+// withAlpha returns c with its alpha replaced by a.
+func withAlpha(c uint32, a uint8) uint32 {
+	return c&0x00FFFFFF | uint32(a)<<24
+}
+
+// lerpColor mixes a toward b by t (0.0-1.0), alpha included.
+func lerpColor(a, b uint32, t float32) uint32 {
+	ar, ag, ab, aa := UnpackRGBA(a)
+	br, bg, bb, ba := UnpackRGBA(b)
+	mix := func(x, y uint8) uint8 { return uint8(float32(x) + (float32(y)-float32(x))*t + 0.5) }
+	return RGBA(mix(ar, br), mix(ag, bg), mix(ab, bb), mix(aa, ba))
+}
+
+// HSVToRGB converts hue, saturation and value (0.0-1.0) to RGB (0.0-1.0).
+// Hue wraps around, so 0 and 1 are both red.
+func HSVToRGB(h, s, v float32) (r, g, b float32) {
+	h = (h - float32(math.Floor(float64(h)))) * 6
+	i := int(h) % 6
+	f := h - float32(int(h))
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+	switch i {
+	case 0:
+		return v, t, p
+	case 1:
+		return q, v, p
+	case 2:
+		return p, v, t
+	case 3:
+		return p, q, v
+	case 4:
+		return t, p, v
+	default:
+		return v, p, q
+	}
+}
+
+// RGBToHSV converts RGB (0.0-1.0) to hue, saturation and value (0.0-1.0).
+// Grays have no hue and report 0.
+func RGBToHSV(r, g, b float32) (h, s, v float32) {
+	v = maxf(r, maxf(g, b))
+	delta := v - minf(r, minf(g, b))
+	if v > 0 {
+		s = delta / v
+	}
+	if delta == 0 {
+		return 0, s, v
+	}
+	switch v {
+	case r:
+		h = (g - b) / delta
+	case g:
+		h = 2 + (b-r)/delta
+	default:
+		h = 4 + (r-g)/delta
+	}
+	h /= 6
+	if h < 0 {
+		h++
+	}
+	return h, s, v
+}
+
 // clampf clamps a float32 value to a range.
 func clampf(v, minVal, maxVal float32) float32 {
 	if v < minVal {
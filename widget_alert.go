@@ -0,0 +1,155 @@
+package gui
+
+// AlertType selects an Alert banner's color and icon.
+type AlertType uint8
+
+const (
+	AlertInfo AlertType = iota
+	AlertSuccess
+	AlertWarning
+	AlertError
+)
+
+// AlertAction is a link shown beneath an Alert's message.
+type AlertAction struct {
+	Label   string
+	OnClick func()
+}
+
+// Alert banner layout.
+const (
+	alertPaddingX = float32(12)
+	alertPaddingY = float32(8)
+)
+
+// Alert draws a persistent inline banner: a box colored by typ holding an
+// icon and the message, word-wrapped to the layout width (or WithWidth).
+// Unlike toasts it stays where it is drawn until the caller stops drawing it.
+//
+// WithDismissible() adds an "x" in the top-right corner; Alert returns true
+// on the frame it is clicked, and the caller decides whether to hide the
+// banner. WithAction(label, fn) adds a link beneath the message that calls fn
+// when clicked. Both controls are focusable and activate with Enter or Space
+// like a Button. Text uses Style.AlertTextColor. The cursor advances by the
+// banner's full height.
+//
+// The dismiss button and link IDs derive from the message; pass WithID when
+// the same message can appear twice in one scope.
+//
+// Usage:
+//
+//	if showWarning && ctx.Alert(gui.AlertWarning, "Unsaved changes", gui.WithDismissible(),
+//	    gui.WithAction("Save now", save)) {
+//	    showWarning = false
+//	}
+func (ctx *Context) Alert(typ AlertType, message string, opts ...Option) (dismissed bool) {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	idStr := message
+	if optID := GetOpt(o, OptID); optID != "" {
+		idStr = optID
+	}
+	dismissID := ctx.GetID(idStr + "_dismiss")
+	actionID := ctx.GetID(idStr + "_action")
+
+	w := ctx.currentLayoutWidth()
+	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
+		w = optWidth
+	}
+	lh := ctx.lineHeight()
+	dismissible := GetOpt(o, OptDismissible)
+	action := GetOpt(o, OptAction)
+
+	// Message area: between the icon and the dismiss button
+	icon := alertIcon(typ)
+	iconW := ctx.MeasureText(icon + " ").X
+	textX := pos.X + alertPaddingX + iconW
+	textW := w - alertPaddingX*2 - iconW
+	if dismissible {
+		textW -= lh + SpaceXS
+	}
+	lines := wrapByWord(ctx, message, maxf(textW, 1))
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+
+	h := float32(len(lines))*lh + alertPaddingY*2
+	if action.Label != "" {
+		h += lh + SpaceXS
+	}
+
+	textColor := ctx.style.AlertTextColor
+	bg := ctx.alertColor(typ)
+	ctx.DrawList.AddRect(pos.X, pos.Y, w, h, bg)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, h, withAlpha(textColor, 60), 1)
+
+	ctx.addText(pos.X+alertPaddingX, pos.Y+alertPaddingY, icon, textColor)
+	y := pos.Y + alertPaddingY
+	for _, line := range lines {
+		ctx.addText(textX, y, line, textColor)
+		y += lh
+	}
+
+	// Action link, underlined like a hyperlink
+	if action.Label != "" {
+		y += SpaceXS
+		size := ctx.MeasureText(action.Label)
+		linkRect := Rect{X: textX, Y: y, W: size.X, H: lh}
+		ctx.RegisterFocusable(actionID, action.Label, linkRect, FocusTypeLeaf)
+		hovered, _, clicked := ctx.buttonBehavior(actionID, linkRect, false, false)
+		if (clicked || ctx.keyActivated(actionID)) && action.OnClick != nil {
+			action.OnClick()
+		}
+		linkColor := textColor
+		if !hovered && !ctx.IsRegistryFocused(actionID) {
+			linkColor = withAlpha(textColor, 200)
+		}
+		ctx.addText(linkRect.X, linkRect.Y, action.Label, linkColor)
+		ctx.DrawList.AddRect(linkRect.X, linkRect.Y+size.Y, size.X, 1, linkColor)
+	}
+
+	// Dismiss button in the top-right corner
+	if dismissible {
+		btnRect := Rect{X: pos.X + w - alertPaddingX - lh, Y: pos.Y + alertPaddingY, W: lh, H: lh}
+		ctx.RegisterFocusable(dismissID, "Dismiss", btnRect, FocusTypeLeaf)
+		hovered, _, clicked := ctx.buttonBehavior(dismissID, btnRect, false, false)
+		if hovered || ctx.IsRegistryFocused(dismissID) {
+			ctx.DrawList.AddRect(btnRect.X, btnRect.Y, btnRect.W, btnRect.H, withAlpha(textColor, 40))
+		}
+		xSize := ctx.MeasureText("x")
+		ctx.addText(btnRect.X+(btnRect.W-xSize.X)/2, btnRect.Y+(btnRect.H-xSize.Y)/2, "x", textColor)
+		dismissed = clicked || ctx.keyActivated(dismissID)
+	}
+
+	ctx.advanceCursor(Vec2{X: w, Y: h})
+	return dismissed
+}
+
+// alertColor returns the banner color for an alert type, shared with toasts.
+func (ctx *Context) alertColor(t AlertType) uint32 {
+	switch t {
+	case AlertSuccess:
+		return ctx.style.ToastSuccessColor
+	case AlertWarning:
+		return ctx.style.ToastWarningColor
+	case AlertError:
+		return ctx.style.ToastErrorColor
+	default:
+		return ctx.style.ToastInfoColor
+	}
+}
+
+// alertIcon returns the icon character for an alert type.
+func alertIcon(t AlertType) string {
+	switch t {
+	case AlertSuccess:
+		return "+"
+	case AlertWarning:
+		return "!"
+	case AlertError:
+		return "X"
+	default:
+		return "i"
+	}
+}
@@ -1,32 +1,85 @@
 package gui
 
-import "strings"
-
-// Text draws text at the current cursor position.
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// Text draws text at the current cursor position. Each "\n" starts a new
+// line.
 func (ctx *Context) Text(text string) {
-	pos := ctx.ItemPos()
-	ctx.addText(pos.X, pos.Y, text, ctx.style.TextColor)
-	ctx.advanceCursor(ctx.MeasureText(text))
+	ctx.textLines(text, ctx.style.TextColor)
 }
 
 // TextColored draws text with a specific color.
 func (ctx *Context) TextColored(text string, color uint32) {
-	pos := ctx.ItemPos()
-	ctx.addText(pos.X, pos.Y, text, color)
-	ctx.advanceCursor(ctx.MeasureText(text))
+	ctx.textLines(text, color)
 }
 
 // TextDisabled draws text with the disabled color.
 func (ctx *Context) TextDisabled(text string) {
+	ctx.textLines(text, ctx.style.TextDisabledColor)
+}
+
+// textLines draws text one line per "\n"-separated part and advances the
+// cursor past the widest line and all the lines' height.
+func (ctx *Context) textLines(text string, color uint32) {
 	pos := ctx.ItemPos()
-	ctx.addText(pos.X, pos.Y, text, ctx.style.TextDisabledColor)
-	ctx.advanceCursor(ctx.MeasureText(text))
+	if !strings.Contains(text, "\n") {
+		ctx.addText(pos.X, pos.Y, text, color)
+		ctx.advanceCursor(ctx.MeasureText(text))
+		return
+	}
+	lh := ctx.lineHeight()
+	size := Vec2{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		ctx.addText(pos.X, pos.Y+float32(i)*lh, line, color)
+		size.X = maxf(size.X, ctx.MeasureText(line).X)
+		size.Y += lh
+	}
+	ctx.advanceCursor(size)
+}
+
+// TextHighlighted draws a line of text with byte ranges recolored by spans
+// (e.g. from DiffSpans). Spans must be sorted and not overlap; text outside
+// them uses the normal text color.
+func (ctx *Context) TextHighlighted(text string, spans []TextSpan) {
+	pos := ctx.ItemPos()
+	h := ctx.lineHeight()
+	x := pos.X
+	drawn := 0
+	draw := func(end int, color, bg uint32) {
+		if end <= drawn {
+			return
+		}
+		segment := text[drawn:end]
+		w := ctx.MeasureText(segment).X
+		if bg != 0 {
+			ctx.DrawList.AddRect(x, pos.Y, w, h, bg)
+		}
+		ctx.addText(x, pos.Y, segment, color)
+		x += w
+		drawn = end
+	}
+	for _, span := range spans {
+		start, end := min(max(span.Start, drawn), len(text)), min(span.End, len(text))
+		draw(start, ctx.style.TextColor, 0)
+		color := span.Color
+		if color == 0 {
+			color = ctx.style.TextColor
+		}
+		draw(end, color, span.Bg)
+	}
+	draw(len(text), ctx.style.TextColor, 0)
+	ctx.advanceCursor(Vec2{X: x - pos.X, Y: h})
 }
 
 // SelectableRow wraps content with selection highlighting.
 // Use this to create custom selectable rows with consistent styling.
 // The content function renders the row's contents.
-// Pass rowWidth to specify the highlight width (0 = use default 200px).
+// Pass rowWidth to specify the highlight width (0 = span the current layout width).
 //
 // Example:
 //
@@ -40,7 +93,7 @@ func (ctx *Context) SelectableRow(selected bool, rowWidth float32) func(func())
 		h := ctx.lineHeight()
 
 		if rowWidth <= 0 {
-			rowWidth = 200 // Default width
+			rowWidth = ctx.currentLayoutWidth()
 		}
 
 		// Draw selection highlight first (background)
@@ -150,8 +203,8 @@ func (ctx *Context) Button(label string, opts ...Option) bool {
 
 	// State-based coloring
 	bgColor := ctx.style.ButtonColor
-	hovered := ctx.isHovered(id, rect) && !disabled
-	pressed := ctx.isPressed(id, rect) && !disabled
+	hovered, pressed, clicked := ctx.buttonBehavior(id, rect, GetOpt(o, OptActivateOnPress), disabled)
+	hovered = hovered && !disabled
 	focused := ctx.IsRegistryFocused(id)
 
 	if focused {
@@ -167,7 +220,12 @@ func (ctx *Context) Button(label string, opts ...Option) bool {
 	}
 
 	// Draw background
-	ctx.DrawList.AddRect(pos.X, pos.Y, size.X, size.Y, bgColor)
+	if ctx.style.ButtonGradient {
+		ctx.DrawList.AddRectRoundedGradientV(pos.X, pos.Y, size.X, size.Y,
+			lightenColor(bgColor, surfaceGradientLighten), bgColor, ctx.style.CornerRadius)
+	} else {
+		ctx.DrawList.AddRectRounded(pos.X, pos.Y, size.X, size.Y, bgColor, ctx.style.CornerRadius)
+	}
 
 	// Draw text (centered in button)
 	textX := pos.X + (size.X-textSize.X)/2
@@ -178,8 +236,6 @@ func (ctx *Context) Button(label string, opts ...Option) bool {
 	}
 	ctx.addText(textX, textY, label, textColor)
 
-	// Check for click
-	clicked := !disabled && ctx.isClicked(id, rect)
 	ctx.advanceCursor(size)
 
 	return clicked
@@ -274,7 +330,6 @@ func (ctx *Context) Selectable(label string, selected bool, opts ...Option) bool
 // Checkbox draws a checkbox with label.
 // Returns true if the value changed.
 func (ctx *Context) Checkbox(label string, value *bool, opts ...Option) bool {
-	pos := ctx.ItemPos()
 	o := applyOptions(opts)
 
 	id := ctx.GetID(label)
@@ -282,6 +337,79 @@ func (ctx *Context) Checkbox(label string, value *bool, opts ...Option) bool {
 		id = ctx.GetID(optID)
 	}
 
+	return ctx.checkbox(id, label, value, o)
+}
+
+// checkbox implements Checkbox for an already-resolved widget ID.
+func (ctx *Context) checkbox(id ID, label string, value *bool, o options) bool {
+	state := Unchecked
+	if *value {
+		state = Checked
+	}
+	changed, size := ctx.checkboxBehavior(id, label, state, o)
+	if changed {
+		*value = !*value
+	}
+	ctx.notifyChange(id, o, BuiltinComponents.Checkbox, label, *value, changed, false)
+
+	ctx.advanceCursor(size)
+	return changed
+}
+
+// CheckState is the value of a CheckboxTristate.
+type CheckState uint8
+
+const (
+	Unchecked CheckState = iota
+	Checked
+	Indeterminate // Some but not all of a group checked; drawn as a dash
+)
+
+// CheckboxTristate draws a checkbox with a third, indeterminate state,
+// for "select all" boxes over partially selected lists. Clicking a checked
+// box unchecks it; clicking an unchecked or indeterminate one checks it.
+// The caller sets Indeterminate itself.
+// Returns true if the state changed.
+//
+// Usage:
+//
+//	all := gui.Indeterminate
+//	if n := countSelected(items); n == 0 {
+//	    all = gui.Unchecked
+//	} else if n == len(items) {
+//	    all = gui.Checked
+//	}
+//	if ctx.CheckboxTristate("Select all", &all) {
+//	    selectAll(items, all == gui.Checked)
+//	}
+func (ctx *Context) CheckboxTristate(label string, state *CheckState, opts ...Option) bool {
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+
+	changed, size := ctx.checkboxBehavior(id, label, *state, o)
+	if changed {
+		if *state == Checked {
+			*state = Unchecked
+		} else {
+			*state = Checked
+		}
+	}
+	ctx.notifyChange(id, o, BuiltinComponents.CheckboxTri, label, *state, changed, false)
+
+	ctx.advanceCursor(size)
+	return changed
+}
+
+// checkboxBehavior draws a checkbox showing state at the cursor and handles
+// focus and clicks. Returns whether it was clicked and its size; the caller
+// updates its value and advances the cursor.
+func (ctx *Context) checkboxBehavior(id ID, label string, state CheckState, o options) (bool, Vec2) {
+	pos := ctx.ItemPos()
+
 	// Size of checkbox box
 	boxSize := ctx.lineHeight()
 	totalWidth := boxSize + ctx.style.ItemSpacing + ctx.MeasureText(label).X
@@ -311,14 +439,13 @@ func (ctx *Context) Checkbox(label string, value *bool, opts ...Option) bool {
 	ctx.DrawList.AddRectOutline(pos.X, pos.Y, boxSize, boxSize,
 		ctx.style.InputBorderColor, 1)
 
-	// Draw checkmark if checked
-	if *value {
-		// Simple X checkmark
-		padding := boxSize * 0.2
-		x1, y1 := pos.X+padding, pos.Y+padding
-		x2, y2 := pos.X+boxSize-padding, pos.Y+boxSize-padding
-		ctx.DrawList.AddLine(x1, y1, x2, y2, ctx.style.TextColor, 2)
-		ctx.DrawList.AddLine(x1, y2, x2, y1, ctx.style.TextColor, 2)
+	// Draw checkmark if checked, a dash if indeterminate
+	switch state {
+	case Checked:
+		addCheckmark(ctx.DrawList, pos.X, pos.Y, boxSize, ctx.style.CheckmarkColor)
+	case Indeterminate:
+		dashH := maxf(2, boxSize*0.15)
+		ctx.DrawList.AddRect(pos.X+boxSize*0.2, pos.Y+(boxSize-dashH)/2, boxSize*0.6, dashH, ctx.style.CheckmarkColor)
 	}
 
 	// Draw label
@@ -330,14 +457,20 @@ func (ctx *Context) Checkbox(label string, value *bool, opts ...Option) bool {
 	ctx.addText(textX, pos.Y, label, textColor)
 
 	// Handle click
-	changed := false
-	if !disabled && ctx.isClicked(id, rect) {
-		*value = !*value
-		changed = true
-	}
+	clicked := !disabled && ctx.isClicked(id, rect)
+	return clicked, Vec2{totalWidth, boxSize}
+}
 
-	ctx.advanceCursor(Vec2{totalWidth, boxSize})
-	return changed
+// addCheckmark draws a check mark in the box of the given size at (x, y):
+// a short stroke down to the right, then a long one up to the right. The
+// stroke thickens with the box.
+func addCheckmark(dl *DrawList, x, y, size float32, color uint32) {
+	thickness := maxf(2, size*0.12)
+	x1, y1 := x+size*0.22, y+size*0.52
+	x2, y2 := x+size*0.42, y+size*0.72
+	x3, y3 := x+size*0.78, y+size*0.28
+	dl.AddLine(x1, y1, x2, y2, color, thickness)
+	dl.AddLine(x2, y2, x3, y3, color, thickness)
 }
 
 // RadioButton draws a radio button.
@@ -369,24 +502,21 @@ func (ctx *Context) RadioButton(label string, active bool, opts ...Option) bool
 	hovered := ctx.isHovered(id, rect) && !disabled
 	focused := ctx.IsRegistryFocused(id)
 
-	// Draw outer circle (as square for simplicity - could use actual circle)
+	// Draw outer circle with its border ring
 	boxColor := ctx.style.InputBgColor
 	if focused {
 		boxColor = ctx.style.InputFocusedBgColor
 	} else if hovered {
 		boxColor = ctx.style.InputFocusedBgColor
 	}
-	ctx.DrawList.AddRect(pos.X, pos.Y, circleSize, circleSize, boxColor)
-	ctx.DrawList.AddRectOutline(pos.X, pos.Y, circleSize, circleSize,
-		ctx.style.InputBorderColor, 1)
+	radius := circleSize / 2
+	cx, cy := pos.X+radius, pos.Y+radius
+	ctx.DrawList.AddCircleFilled(cx, cy, radius, boxColor, 0)
+	ctx.DrawList.AddCircleOutline(cx, cy, radius, ctx.style.InputBorderColor, 0, 1)
 
 	// Draw inner filled circle if active
 	if active {
-		padding := circleSize * 0.25
-		ctx.DrawList.AddRect(
-			pos.X+padding, pos.Y+padding,
-			circleSize-padding*2, circleSize-padding*2,
-			ctx.style.SelectedBgColor)
+		ctx.DrawList.AddCircleFilled(cx, cy, radius*0.5, ctx.style.SelectedBgColor, 0)
 	}
 
 	// Draw label
@@ -404,34 +534,201 @@ func (ctx *Context) RadioButton(label string, active bool, opts ...Option) bool
 	return clicked
 }
 
+// progressGradientLighten is how much lighter than the fill color the top
+// of a Style.ProgressBarGradient fill is.
+const progressGradientLighten = 0.35
+
+// surfaceGradientLighten is how much lighter than its color the top of a
+// Style.PanelGradient, HeaderGradient or ButtonGradient surface is. It is
+// subtler than a progress bar fill, since these surfaces are larger.
+const surfaceGradientLighten = 0.12
+
+// fillRectV draws a filled rectangle, shaded from a lighter top down to
+// color when shaded is set.
+func (ctx *Context) fillRectV(x, y, w, h float32, color uint32, shaded bool) {
+	if shaded {
+		ctx.DrawList.AddRectGradientV(x, y, w, h, lightenColor(color, surfaceGradientLighten), color)
+	} else {
+		ctx.DrawList.AddRect(x, y, w, h, color)
+	}
+}
+
 // ProgressBar draws a progress bar.
-// fraction should be between 0.0 and 1.0.
+// fraction should be between 0.0 and 1.0. With Style.ProgressBarGradient
+// the fill shades from a lighter top down to SelectedBgColor.
 func (ctx *Context) ProgressBar(fraction float32, opts ...Option) {
 	pos := ctx.ItemPos()
 	o := applyOptions(opts)
 
-	w := ctx.currentLayoutWidth()
+	w, h := ctx.progressBarSize(o)
+	fraction = clampf(fraction, 0, 1)
+
+	// Background
+	ctx.DrawList.AddRect(pos.X, pos.Y, w, h, ctx.style.InputBgColor)
+
+	// Fill
+	ctx.progressBarFill(pos.X, pos.Y, w*fraction, h)
+
+	// Border
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
+
+	ctx.advanceCursor(Vec2{w, h})
+}
+
+// progressBarSize returns a progress bar's size: the layout width and the
+// line height unless WithWidth/WithHeight set them.
+func (ctx *Context) progressBarSize(o options) (w, h float32) {
+	w = ctx.currentLayoutWidth()
 	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
 		w = optWidth
 	}
-	h := ctx.lineHeight()
+	h = ctx.lineHeight()
 	if optHeight := GetOpt(o, OptHeight); optHeight > 0 {
 		h = optHeight
 	}
+	return w, h
+}
+
+// progressBarFill draws a progress bar's fill, shaded with
+// Style.ProgressBarGradient.
+func (ctx *Context) progressBarFill(x, y, w, h float32) {
+	if w <= 0 {
+		return
+	}
+	fill := ctx.style.SelectedBgColor
+	if ctx.style.ProgressBarGradient {
+		ctx.DrawList.AddRectGradientV(x, y, w, h, lightenColor(fill, progressGradientLighten), fill)
+	} else {
+		ctx.DrawList.AddRect(x, y, w, h, fill)
+	}
+}
 
+// progressStore holds displayed values for ProgressBarSmooth.
+var progressStore = NewFrameStore[ProgressBarState]()
+
+// progressSmoothRate controls how fast ProgressBarSmooth eases toward its
+// target. Higher values ease faster.
+const progressSmoothRate = 8
+
+// ProgressBarSmooth draws a progress bar whose fill eases toward fraction
+// instead of jumping. The id keeps the displayed value between frames.
+// The bar starts at the first fraction it is given.
+//
+// Usage:
+//
+//	ctx.ProgressBarSmooth("download", bytesRead/float32(total))
+func (ctx *Context) ProgressBarSmooth(id string, fraction float32, opts ...Option) {
+	state := progressStore.GetFor(ctx, ctx.GetID(id), ProgressBarState{})
 	fraction = clampf(fraction, 0, 1)
 
-	// Background
+	if !state.Initialized {
+		state.Displayed = fraction
+		state.Initialized = true
+	} else {
+		t := clampf(ctx.DeltaTime*progressSmoothRate, 0, 1)
+		state.Displayed += (fraction - state.Displayed) * t
+		if absf(fraction-state.Displayed) < 0.001 {
+			state.Displayed = fraction
+		}
+	}
+
+	ctx.ProgressBar(state.Displayed, opts...)
+}
+
+// Indeterminate progress bar animation.
+const (
+	marqueeBlockFrac = 0.3 // Width of the moving block, as a fraction of the track
+	marqueeSpeed     = 0.6 // Passes across the track per second
+)
+
+// ProgressBarIndeterminate draws a progress bar for work of unknown length:
+// a block slides across the track, entering on the left and leaving on the
+// right, at a steady pace driven by DeltaTime. WithID keys the animation
+// when several are drawn in the same ID scope. It uses the ProgressBar
+// colors, sizes and options.
+//
+// Usage:
+//
+//	if loading {
+//	    ctx.ProgressBarIndeterminate(gui.WithWidth(200))
+//	}
+func (ctx *Context) ProgressBarIndeterminate(opts ...Option) {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID("progress_indeterminate")
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+	state := progressStore.GetFor(ctx, id, ProgressBarState{})
+	state.Marquee += ctx.DeltaTime * marqueeSpeed
+	state.Marquee -= float32(math.Floor(float64(state.Marquee)))
+
+	w, h := ctx.progressBarSize(o)
 	ctx.DrawList.AddRect(pos.X, pos.Y, w, h, ctx.style.InputBgColor)
 
-	// Fill
-	fillW := w * fraction
-	if fillW > 0 {
-		ctx.DrawList.AddRect(pos.X, pos.Y, fillW, h, ctx.style.SelectedBgColor)
+	// The block travels from just left of the track to just right of it,
+	// clipped to the track
+	blockW := w * marqueeBlockFrac
+	x0 := pos.X - blockW + (w+blockW)*state.Marquee
+	x1 := minf(x0+blockW, pos.X+w)
+	x0 = maxf(x0, pos.X)
+	ctx.progressBarFill(x0, pos.Y, x1-x0, h)
+
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	ctx.advanceCursor(Vec2{w, h})
+}
+
+// Segment layout for SegmentedProgress.
+const (
+	segmentGap      = 2 // Pixels between segments
+	segmentMinWidth = 4 // Narrowest segment before segments are merged
+)
+
+// SegmentedProgress draws total equal-width segments separated by gaps, the
+// first completed filled with SelectedBgColor. WithSegmentColors colors
+// segments individually (e.g. done/failed/pending); a zero entry keeps the
+// default. When total segments would be narrower than a few pixels, runs of
+// adjacent segments are merged into one cell, which is filled in proportion
+// to its completed segments and takes the color of its first segment.
+//
+// Usage:
+//
+//	ctx.SegmentedProgress(tasksDone, len(tasks))
+func (ctx *Context) SegmentedProgress(completed, total int, opts ...Option) {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	w := ctx.itemWidth(o, ctx.currentLayoutWidth(), 0)
+	h := ctx.lineHeight()
+	if optHeight := GetOpt(o, OptHeight); optHeight > 0 {
+		h = optHeight
+	}
+	if total <= 0 {
+		ctx.advanceCursor(Vec2{w, h})
+		return
 	}
+	completed = min(max(completed, 0), total)
+	colors := GetOpt(o, OptSegmentColors)
 
-	// Border
-	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, h, ctx.style.InputBorderColor, 1)
+	// Cap the number of drawn cells so each stays at least segmentMinWidth wide
+	cells := min(total, max(1, int((w+segmentGap)/(segmentMinWidth+segmentGap))))
+	cellW := (w - segmentGap*float32(cells-1)) / float32(cells)
+
+	for c := 0; c < cells; c++ {
+		first, end := c*total/cells, (c+1)*total/cells
+		x := pos.X + float32(c)*(cellW+segmentGap)
+
+		bg, fill := ctx.style.InputBgColor, ctx.style.SelectedBgColor
+		if first < len(colors) && colors[first] != 0 {
+			bg, fill = colors[first], colors[first]
+		}
+		ctx.DrawList.AddRect(x, pos.Y, cellW, h, bg)
+		if done := min(max(completed-first, 0), end-first); done > 0 && fill != bg {
+			ctx.DrawList.AddRect(x, pos.Y, cellW*float32(done)/float32(end-first), h, fill)
+		}
+		ctx.DrawList.AddRectOutline(x, pos.Y, cellW, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	}
 
 	ctx.advanceCursor(Vec2{w, h})
 }
@@ -439,9 +736,9 @@ func (ctx *Context) ProgressBar(fraction float32, opts ...Option) {
 // InputText draws a text input field with full editing support.
 // Features: cursor positioning, text selection, clipboard (Ctrl+C/V/X),
 // undo/redo (Ctrl+Z/Y), and keyboard navigation (arrows, Home/End).
+// WithPasswordMode or WithMask hides the characters behind a mask glyph.
 // Returns true if the value changed.
 func (ctx *Context) InputText(label string, value *string, opts ...Option) bool {
-	pos := ctx.ItemPos()
 	o := applyOptions(opts)
 
 	id := ctx.GetID(label)
@@ -449,6 +746,14 @@ func (ctx *Context) InputText(label string, value *string, opts ...Option) bool
 		id = ctx.GetID(optID)
 	}
 
+	return ctx.inputText(id, label, value, o)
+}
+
+// inputText implements InputText for an already-resolved widget ID.
+// Composite widgets use it to embed a text field under their own ID.
+func (ctx *Context) inputText(id ID, label string, value *string, o options) bool {
+	pos := ctx.ItemPos()
+
 	// Get or create state
 	state := GetState(ctx, id, InputTextState{
 		CursorPos:      len([]rune(*value)),
@@ -475,10 +780,7 @@ func (ctx *Context) InputText(label string, value *string, opts ...Option) bool
 	}
 
 	// Input box dimensions
-	w := float32(200)
-	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
-		w = optWidth
-	}
+	w := ctx.itemWidth(o, 200, drawX-startX)
 	h := ctx.lineHeight() + ctx.style.InputPadding*2
 
 	// Interaction rect
@@ -503,7 +805,7 @@ func (ctx *Context) InputText(label string, value *string, opts ...Option) bool
 		bgColor = ctx.style.InputFocusedBgColor
 	}
 	ctx.DrawList.AddRect(drawX, pos.Y, w, h, bgColor)
-	ctx.DrawList.AddRectOutline(drawX, pos.Y, w, h, ctx.style.InputBorderColor, 1)
+	ctx.DrawList.AddRectOutline(drawX, pos.Y, w, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
 
 	// Convert to runes for proper Unicode handling
 	runes := []rune(*value)
@@ -517,13 +819,21 @@ func (ctx *Context) InputText(label string, value *string, opts ...Option) bool
 		state.CursorPos = 0
 	}
 
+	// Masked fields draw and measure one mask glyph per character, so the
+	// caret, selection and scroll line up with what is shown
+	mask := GetOpt(o, OptMask)
+	shown := runes
+	if mask != 0 {
+		shown = maskRunes(runes, mask)
+	}
+
 	// Calculate text metrics
 	textX := drawX + ctx.style.InputPadding
 	textY := pos.Y + ctx.style.InputPadding
 	maxWidth := w - ctx.style.InputPadding*2
 
 	// Calculate scroll offset to keep cursor visible
-	cursorTextWidth := ctx.MeasureText(string(runes[:state.CursorPos])).X
+	cursorTextWidth := ctx.MeasureText(string(shown[:state.CursorPos])).X
 	if cursorTextWidth-state.ScrollOffset > maxWidth {
 		state.ScrollOffset = cursorTextWidth - maxWidth + 10
 	}
@@ -535,49 +845,115 @@ func (ctx *Context) InputText(label string, value *string, opts ...Option) bool
 	}
 
 	// Push clip rect for text area
-	ctx.DrawList.PushClipRect(textX, pos.Y, textX+maxWidth, pos.Y+h)
+	ctx.pushClipRect(Rect{X: textX, Y: pos.Y, W: maxWidth, H: h})
 
 	// Draw selection highlight if active
 	if state.Editing && state.HasSelection() {
 		selStart, selEnd := state.GetSelectedRange()
-		selStartX := ctx.MeasureText(string(runes[:selStart])).X - state.ScrollOffset
-		selEndX := ctx.MeasureText(string(runes[:selEnd])).X - state.ScrollOffset
-		ctx.DrawList.AddRect(textX+selStartX, pos.Y+2, selEndX-selStartX, h-4, ctx.style.SelectedBgColor)
+		selStartX := ctx.MeasureText(string(shown[:selStart])).X - state.ScrollOffset
+		selEndX := ctx.MeasureText(string(shown[:selEnd])).X - state.ScrollOffset
+		ctx.DrawList.AddRect(textX+selStartX, pos.Y+2, selEndX-selStartX, h-4, ctx.style.SelectionColor)
 	}
 
 	// Draw text
-	ctx.addText(textX-state.ScrollOffset, textY, *value, ctx.style.TextColor)
+	ctx.addText(textX-state.ScrollOffset, textY, string(shown), ctx.style.TextColor)
+	if placeholder := GetOpt(o, optPlaceholder); *value == "" && !state.Editing && placeholder != "" {
+		ctx.addText(textX, textY, placeholder, ctx.style.TextDisabledColor)
+	}
+
+	// Ghost completion: suggested suffix after the caret, only when the caret
+	// is at the end; clipped with the text and not part of the value
+	ghost := ""
+	if complete := GetOpt(o, OptGhostCompletion); complete != nil && mask == 0 && state.Editing &&
+		state.CursorPos == textLen && !state.HasSelection() {
+		ghost = complete(*value)
+		if ghost != "" {
+			ctx.addText(textX+cursorTextWidth-state.ScrollOffset, textY, ghost, ctx.style.TextDisabledColor)
+		}
+	}
 
 	// Pop clip rect
-	ctx.DrawList.PopClipRect()
+	ctx.popClipRect()
 
 	// Draw cursor when in edit mode
 	if state.Editing {
 		state.CursorBlinkTime += ctx.DeltaTime
 		if int(state.CursorBlinkTime*2)%2 == 0 { // Blink every 0.5s
 			cursorX := textX + cursorTextWidth - state.ScrollOffset
-			ctx.DrawList.AddLine(cursorX, pos.Y+2, cursorX, pos.Y+h-2, ctx.style.TextColor, 1)
+			ctx.DrawList.AddLine(cursorX, pos.Y+2, cursorX, pos.Y+h-2, ctx.style.CaretColor, 1)
 		}
 	}
 
 	// Handle click to enter edit mode
 	// RegisterFocusable handles setting registry focus on click, but we also enter edit mode
-	if ctx.isClicked(id, rect) {
-		state.Editing = true
-		state.CursorBlinkTime = 0
-
-		// Calculate cursor position from click
-		clickX := ctx.Input.MouseX - textX + state.ScrollOffset
-		newCursorPos := 0
+	runeAt := func(mouseX float32) int {
+		clickX := mouseX - textX + state.ScrollOffset
+		n := 0
 		for i := 0; i <= textLen; i++ {
-			charX := ctx.MeasureText(string(runes[:i])).X
+			charX := ctx.MeasureText(string(shown[:i])).X
 			if charX > clickX {
 				break
 			}
-			newCursorPos = i
+			n = i
+		}
+		return n
+	}
+	// wordAt is the range a double-click selects at pos (masked fields
+	// select everything, so the selection doesn't reveal spaces)
+	wordAt := func(pos int) (int, int) {
+		if mask != 0 {
+			return 0, textLen
 		}
+		return wordRangeAt(runes, pos)
+	}
+	if ctx.isClicked(id, rect) {
+		state.Editing = true
+		state.CursorBlinkTime = 0
+
+		// Calculate cursor position from click
+		newCursorPos := runeAt(ctx.Input.MouseX)
 		state.CursorPos = newCursorPos
 		state.ClearSelection()
+
+		// Double-click selects the word under the mouse, triple-click
+		// the whole text
+		clicks := ctx.Input.MouseClickCount(MouseButtonLeft)
+		start, end := newCursorPos, newCursorPos
+		switch {
+		case clicks >= 3:
+			start, end = 0, textLen
+		case clicks == 2:
+			start, end = wordAt(newCursorPos)
+		}
+		if start < end {
+			state.SelectionStart, state.SelectionEnd = start, end
+			state.CursorPos = end
+		}
+		state.dragClicks = min(clicks, 3)
+		state.dragStart, state.dragEnd = start, end
+	} else if state.dragClicks > 0 {
+		// Dragging extends the selection from the clicked range, by
+		// character after a click and by word after a double-click
+		if ctx.Input == nil || !ctx.Input.MouseDown(MouseButtonLeft) {
+			state.dragClicks = 0
+		} else if state.dragClicks < 3 {
+			p := runeAt(ctx.Input.MouseX)
+			start, end := p, p
+			if state.dragClicks == 2 {
+				start, end = wordAt(p)
+			}
+			if start < state.dragStart {
+				state.SelectionStart, state.SelectionEnd = state.dragEnd, start
+				state.CursorPos = start
+			} else {
+				end = max(end, state.dragEnd)
+				state.SelectionStart, state.SelectionEnd = state.dragStart, end
+				state.CursorPos = end
+			}
+			if !state.HasSelection() {
+				state.ClearSelection()
+			}
+		}
 	}
 
 	// Exit edit mode if registry focus moved to a different widget
@@ -594,14 +970,37 @@ func (ctx *Context) InputText(label string, value *string, opts ...Option) bool
 		// Skip keyboard processing on the frame we just started editing via ForceFocus
 		// This prevents the Enter key that triggered editing from also closing the input
 		if !justStartedEditing {
-			changed = ctx.processInputTextKeyboard(value, &state, &runes)
+			// A bare Tab or Right at the end accepts the ghost completion shown
+			// this frame; Shift+Tab and Ctrl+Tab keep moving focus
+			input := ctx.Input
+			bare := !input.ModShift && !input.ModCtrl && !input.ModAlt
+			acceptTab := bare && input.KeyPressed(KeyTab)
+			if ghost != "" && (acceptTab || bare && input.KeyRepeated(KeyRight)) {
+				state.PushUndo(*value)
+				*value += ghost
+				runes = []rune(*value)
+				state.CursorPos = len(runes)
+				ctx.tabConsumed = acceptTab
+				changed = true
+			}
+			escape := !GetOpt(o, OptNoEscape) && ctx.escapeFor(escapeText, id)
+			noCopy := mask != 0 && !GetOpt(o, OptMaskAllowCopy)
+			if ctx.processInputTextKeyboard(value, &state, &runes, escape, noCopy, false, o) {
+				changed = true
+			}
 		}
 	}
+	if state.Editing && !GetOpt(o, OptNoEscape) {
+		ctx.claimEscape(escapeText, id)
+	}
 
 	// Save state
 	SetState(ctx, id, state)
 
-	// Advance cursor
+	ctx.notifyChange(id, o, BuiltinComponents.InputText, label, *value, changed, state.Editing)
+
+	// Advance cursor (past the error message, if any)
+	h += ctx.drawFieldError(o, rect)
 	ctx.cursor.X = startX
 	ctx.advanceCursor(Vec2{w + (drawX - startX), h})
 
@@ -609,12 +1008,30 @@ func (ctx *Context) InputText(label string, value *string, opts ...Option) bool
 }
 
 // processInputTextKeyboard handles keyboard input for InputText.
+// escape is true when this frame's Escape press is for this field.
+// noCopy disables Ctrl+C and Ctrl+X (masked fields).
+// multiline leaves Home, End and Enter to InputTextMultiline.
 // Returns true if the value changed.
-func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextState, runes *[]rune) bool {
+func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextState, runes *[]rune, escape, noCopy, multiline bool, o options) bool {
 	changed := false
 	textLen := len(*runes)
 	input := ctx.Input
 
+	// accepted returns the runes of ins the field takes in place of the
+	// selection: those WithCharFilter accepts, up to WithMaxLength
+	filter, maxLen := GetOpt(o, OptCharFilter), GetOpt(o, OptMaxLength)
+	accepted := func(ins []rune) []rune {
+		if filter != nil {
+			ins = slices.DeleteFunc(ins, func(r rune) bool { return !filter(r) })
+		}
+		if maxLen > 0 {
+			start, end := state.GetSelectedRange()
+			room := maxLen - (len(*runes) - (end - start))
+			ins = ins[:max(0, min(len(ins), room))]
+		}
+		return ins
+	}
+
 	// Helper to delete selected text
 	deleteSelection := func() bool {
 		if !state.HasSelection() {
@@ -637,7 +1054,7 @@ func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextStat
 
 	// Ctrl+C: Copy
 	if input.ModCtrl && input.KeyPressed(KeyC) {
-		if state.HasSelection() {
+		if state.HasSelection() && !noCopy {
 			start, end := state.GetSelectedRange()
 			ClipboardSetText(string((*runes)[start:end]))
 		}
@@ -646,7 +1063,7 @@ func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextStat
 
 	// Ctrl+X: Cut
 	if input.ModCtrl && input.KeyPressed(KeyX) {
-		if state.HasSelection() {
+		if state.HasSelection() && !noCopy {
 			start, end := state.GetSelectedRange()
 			ClipboardSetText(string((*runes)[start:end]))
 			deleteSelection()
@@ -657,11 +1074,10 @@ func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextStat
 
 	// Ctrl+V: Paste
 	if input.ModCtrl && input.KeyPressed(KeyV) {
-		clipboard := ClipboardGetText()
-		if clipboard != "" {
+		clipRunes := accepted([]rune(ClipboardGetText()))
+		if len(clipRunes) > 0 {
 			deleteSelection() // Delete selection if any
 			state.PushUndo(*value)
-			clipRunes := []rune(clipboard)
 			*runes = append((*runes)[:state.CursorPos], append(clipRunes, (*runes)[state.CursorPos:]...)...)
 			*value = string(*runes)
 			state.CursorPos += len(clipRunes)
@@ -749,7 +1165,7 @@ func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextStat
 	}
 
 	// Home: jump to start
-	if input.KeyPressed(KeyHome) {
+	if !multiline && input.KeyPressed(KeyHome) {
 		state.CursorPos = 0
 		if !input.ModShift {
 			state.ClearSelection()
@@ -763,7 +1179,7 @@ func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextStat
 	}
 
 	// End: jump to end
-	if input.KeyPressed(KeyEnd) {
+	if !multiline && input.KeyPressed(KeyEnd) {
 		state.CursorPos = textLen
 		if !input.ModShift {
 			state.ClearSelection()
@@ -806,20 +1222,20 @@ func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextStat
 	}
 
 	// Escape: exit edit mode
-	if input.KeyPressed(KeyEscape) {
+	if escape {
 		state.Editing = false
 		return changed
 	}
 
 	// Enter: exit edit mode
-	if input.KeyPressed(KeyEnter) {
+	if !multiline && input.KeyPressed(KeyEnter) {
 		state.Editing = false
 		return changed
 	}
 
 	// Text input (printable characters)
 	for _, ch := range input.InputChars {
-		if ch >= 32 { // Printable character
+		if ch >= 32 && len(accepted([]rune{ch})) > 0 { // Printable character
 			deleteSelection() // Delete selection if any
 			state.PushUndo(*value)
 			*runes = append((*runes)[:state.CursorPos], append([]rune{ch}, (*runes)[state.CursorPos:]...)...)
@@ -832,6 +1248,15 @@ func (ctx *Context) processInputTextKeyboard(value *string, state *InputTextStat
 	return changed
 }
 
+// maskRunes returns a slice of len(runes) mask glyphs.
+func maskRunes(runes []rune, mask rune) []rune {
+	shown := make([]rune, len(runes))
+	for i := range shown {
+		shown[i] = mask
+	}
+	return shown
+}
+
 // findWordBoundaryLeft finds the start of the word to the left of pos.
 func findWordBoundaryLeft(runes []rune, pos int) int {
 	if pos <= 0 {
@@ -866,20 +1291,90 @@ func findWordBoundaryRight(runes []rune, pos int) int {
 	return pos
 }
 
+// wordRangeAt returns the word containing the character at pos, or the
+// word ending at pos when pos is after it. start == end when there is none.
+func wordRangeAt(runes []rune, pos int) (start, end int) {
+	if pos >= len(runes) || isWhitespace(runes[pos]) {
+		if pos == 0 || pos > len(runes) || isWhitespace(runes[pos-1]) {
+			return pos, pos
+		}
+		pos--
+	}
+	start = findWordBoundaryLeft(runes, pos+1)
+	end = findWordBoundaryRight(runes, pos)
+	for end > start && isWhitespace(runes[end-1]) {
+		end--
+	}
+	return start, end
+}
+
 // isWhitespace returns true if the rune is a whitespace character.
 func isWhitespace(r rune) bool {
 	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
 }
 
-// Tooltip shows a tooltip at the mouse position.
-// Should be called right after the widget you want to add a tooltip to.
+// tooltipStore holds how long each tooltip's item has been hovered.
+var tooltipStore = NewFrameStore[float32]()
+
+// defaultTooltipDelay is the hover time before a tooltip shows, in seconds.
+const defaultTooltipDelay = float32(0.5)
+
+// SetTooltipDelay sets how long the mouse must rest on an item before its
+// Tooltip shows, in seconds (default 0.5). Zero shows tooltips at once.
+func (ctx *Context) SetTooltipDelay(seconds float32) {
+	ctx.tooltipDelay = maxf(seconds, 0)
+}
+
+// Tooltip shows a tooltip at the mouse position once the previous widget
+// has been hovered for the tooltip delay (see SetTooltipDelay).
+// Call it right after the widget you want to add a tooltip to. It draws
+// at once, so later widgets can cover it; SetItemTooltip draws on top.
 func (ctx *Context) Tooltip(text string) {
-	if ctx.Input == nil {
+	ctx.tooltipFor(ctx.lastItemRect, text, ctx.DrawList)
+}
+
+// SetItemTooltip sets a tooltip for the last item (the widget drawn just
+// before the call). Like Tooltip it shows once the mouse has rested on the
+// item for the tooltip delay, restarting when the mouse leaves it, but it
+// draws on the ForegroundDrawList, above everything drawn after the item.
+//
+// Usage:
+//
+//	ctx.Button("Save")
+//	ctx.SetItemTooltip("Save the current file (Ctrl+S)")
+func (ctx *Context) SetItemTooltip(text string) {
+	ctx.tooltipFor(ctx.lastItemRect, text, ctx.foregroundDrawList())
+}
+
+// tooltipFor draws text as a tooltip on dl once rect has been hovered for
+// the tooltip delay. The hover time resets when the mouse leaves rect.
+func (ctx *Context) tooltipFor(rect Rect, text string, dl *DrawList) {
+	elapsed := tooltipStore.GetFor(ctx, ctx.tooltipKey(rect), 0)
+	if !ctx.isHovered(0, rect) {
+		*elapsed = 0
 		return
 	}
+	*elapsed += ctx.DeltaTime
+	if *elapsed < ctx.tooltipDelay {
+		return
+	}
+	ctx.drawTooltip(dl, text)
+}
+
+// tooltipKey identifies the hover timer of the item at rect. It hashes the
+// rect instead of calling GetID, so a tooltip requested only while its item
+// is hovered doesn't shift the IDs of the widgets after it.
+func (ctx *Context) tooltipKey(rect Rect) ID {
+	h := uint64(14695981039346656037) ^ uint64(ctx.CurrentID()) // FNV-1a offset basis
+	for _, v := range [4]float32{rect.X, rect.Y, rect.W, rect.H} {
+		h ^= uint64(math.Float32bits(v))
+		h *= 1099511628211 // FNV-1a prime
+	}
+	return ID(h)
+}
 
-	// Only show if previous widget was hovered
-	// (This is a simplified implementation - a real one would track the last widget)
+// drawTooltip draws text in a tooltip box beside the mouse, kept on screen.
+func (ctx *Context) drawTooltip(dl *DrawList, text string) {
 	mx, my := ctx.Input.MouseX, ctx.Input.MouseY
 
 	// Draw tooltip background
@@ -898,9 +1393,9 @@ func (ctx *Context) Tooltip(text string) {
 		y = ctx.DisplaySize.Y - h
 	}
 
-	ctx.DrawList.AddRect(x, y, w, h, ctx.style.PanelColor)
-	ctx.DrawList.AddRectOutline(x, y, w, h, ctx.style.PanelBorderColor, 1)
-	ctx.addText(x+padding, y+padding, text, ctx.style.TextColor)
+	dl.AddRect(x, y, w, h, ctx.style.PanelColor)
+	dl.AddRectOutline(x, y, w, h, ctx.style.PanelBorderColor, ctx.style.BorderSize)
+	ctx.addTextTo(dl, x+padding, y+padding, text, ctx.style.TextColor)
 }
 
 // CollapsingHeader draws a collapsible header.
@@ -990,8 +1485,7 @@ func (ctx *Context) Bullet() {
 	x := pos.X + size
 	y := pos.Y + ctx.lineHeight()/2
 
-	// Draw circle as small square (could enhance to actual circle)
-	ctx.DrawList.AddRect(x-size/2, y-size/2, size, size, ctx.style.TextColor)
+	ctx.DrawList.AddCircleFilled(x, y, size/2, ctx.style.TextColor, 0)
 
 	// Bullet is an inline element - advance horizontally
 	ctx.cursor.X = pos.X + size*2 + ctx.style.ItemSpacing
@@ -0,0 +1,86 @@
+package gui
+
+import "math"
+
+// changeStore tracks the last reported value of widgets using OptOnChange.
+var changeStore = NewFrameStore[changeState]()
+
+// changeState is the per-widget record used by notifyChange.
+type changeState struct {
+	Last    any  // Value at the time of the last notification (or last idle frame)
+	HasLast bool // Last has been recorded at least once
+	Pending bool // Change seen during a debounced interaction, not yet reported
+}
+
+// ChangeObserver receives every value change made through a widget; see
+// Context.SetChangeObserver.
+type ChangeObserver func(id ID, name string, kind string, value any)
+
+// SetChangeObserver installs a hook called on every frame a value widget
+// reports a change (Checkbox, InputText, NumberInputFloat/Int,
+// SliderFloat/Int, ComboBox, Rating, RadioGroup, and the Form fields built
+// on them), after the new value is written. Pass nil to remove it.
+//
+// name is the widget's label, or its WithID when the label is empty; kind is
+// the widget's BuiltinComponents name (e.g. "component_slider_int"); value
+// is the new value as float32, int, string or bool, never a pointer. Unlike
+// WithOnChange it sees every widget, fires on each frame of a drag rather
+// than once per change, and ignores WithDebounce, which suits recording
+// input for replay. With no observer set the cost is a nil check.
+func (ctx *Context) SetChangeObserver(fn ChangeObserver) {
+	ctx.changeObserver = fn
+}
+
+// notifyChange reports a widget's change to the change observer and fires
+// its OptOnChange callback once per logical change.
+//
+// Widgets call this after writing the new value, so the callback never
+// observes a stale value. changed is the widget's own change flag for this
+// frame; interacting reports an ongoing drag or edit. When OptDebounce is set,
+// changes made while interacting are held back and reported once the
+// interaction ends, and only if the value differs from where it started.
+func (ctx *Context) notifyChange(id ID, o options, kind, name string, value any, changed, interacting bool) {
+	if changed && ctx.changeObserver != nil {
+		ctx.observeChange(id, o, kind, name, value)
+	}
+
+	onChange := GetOpt(o, OptOnChange)
+	if onChange == nil {
+		return
+	}
+
+	state := changeStore.GetFor(ctx, id, changeState{})
+
+	if interacting && GetOpt(o, OptDebounce) {
+		if changed {
+			state.Pending = true
+		}
+		return
+	}
+
+	fire := changed || state.Pending
+	state.Pending = false
+	if fire && (!state.HasLast || value != state.Last) {
+		onChange()
+	}
+
+	// Track the value every idle frame so programmatic changes don't
+	// trigger the callback later.
+	state.Last = value
+	state.HasLast = true
+}
+
+// observeChange passes a change to the change observer, naming unlabeled
+// widgets by their WithID and reporting the Int variants' values as int.
+func (ctx *Context) observeChange(id ID, o options, kind, name string, value any) {
+	if name == "" {
+		name = GetOpt(o, OptID)
+	}
+	if f, ok := value.(float32); ok && GetOpt(o, optIntValue) {
+		value = int(math.Round(float64(f)))
+		if kind == BuiltinComponents.Slider {
+			kind = BuiltinComponents.SliderInt
+		}
+	}
+	ctx.changeObserver(id, name, kind, value)
+}
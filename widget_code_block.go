@@ -0,0 +1,221 @@
+package gui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// codeBlockStore is the type-safe store for CodeBlock state.
+var codeBlockStore = NewFrameStore[CodeBlockState]()
+
+// codeBlockCopiedDuration is how long (seconds) the copy button shows "Copied!".
+const codeBlockCopiedDuration = 1.5
+
+// codeBlockScrollbarHeight is the height of the horizontal scroll indicator.
+const codeBlockScrollbarHeight = 3
+
+// codeBlockTabColumns is the number of columns between tab stops in code.
+const codeBlockTabColumns = 4
+
+// CodeBlock draws text in a bordered box under a header row holding a
+// "Copy" button in the top-right corner. Clicking the button (or pressing
+// Enter or Space while it has focus) puts the whole text on the
+// clipboard and the button reads "Copied!" for codeBlockCopiedDuration
+// seconds of Context.Time, so the flash lasts the same regardless of
+// frame rate.
+//
+// The text is monospace: every character takes one cell as wide as the
+// active font's "M", with the glyph centred in it, so columns line up even
+// with a proportional font. Tabs advance to the next multiple of
+// codeBlockTabColumns columns.
+//
+// Lines longer than the box scroll horizontally with the horizontal wheel
+// (or Shift+wheel) while hovered. With WithWrap() they wrap instead and the
+// block grows taller.
+//
+// Usage:
+//
+//	ctx.CodeBlock("install", "go get github.com/go-theft-auto/gui")
+//	ctx.CodeBlock("config", configText, WithWrap(), WithWidth(400))
+func (ctx *Context) CodeBlock(id string, text string, opts ...Option) {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+	wid := ctx.GetID(id)
+	copyID := ctx.GetID(id + "_copy")
+	state := codeBlockStore.GetFor(ctx, wid, CodeBlockState{})
+
+	pad := ctx.style.InputPadding
+	lh := ctx.lineHeight()
+	cw := ctx.monoCellWidth()
+
+	w := ctx.currentLayoutWidth()
+	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
+		w = optWidth
+	}
+	innerW := maxf(0, w-pad*2)
+
+	// Copy button, sized for the longer label so it doesn't jump when
+	// flashing. It sits in a header row above the text.
+	btnW := ctx.MeasureText("Copied!").X + SpaceXS*4
+	btnH := lh + SpaceXS*2
+	headerH := btnH + SpaceXS
+
+	wrap := GetOpt(o, OptWrap)
+	wrapCols := max(1, int(innerW/cw))
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = expandTabs(strings.TrimSuffix(line, "\r"), codeBlockTabColumns)
+		for wrap && utf8.RuneCountInString(line) > wrapCols {
+			runes := []rune(line)
+			lines = append(lines, string(runes[:wrapCols]))
+			line = string(runes[wrapCols:])
+		}
+		lines = append(lines, line)
+	}
+
+	var contentW float32
+	for _, line := range lines {
+		contentW = maxf(contentW, float32(utf8.RuneCountInString(line))*cw)
+	}
+	maxScroll := maxf(0, contentW-innerW)
+
+	h := headerH + float32(len(lines))*lh + pad
+	if maxScroll > 0 {
+		h += codeBlockScrollbarHeight
+	}
+	rect := Rect{X: pos.X, Y: pos.Y, W: w, H: h}
+
+	// Horizontal scrolling (Shift turns the vertical wheel sideways)
+	if ctx.Input != nil && maxScroll > 0 && ctx.isHovered(wid, rect) {
+		delta := ctx.Input.MouseWheelX
+		if delta == 0 && ctx.Input.ModShift {
+			delta = ctx.Input.MouseWheelY
+		}
+		if delta != 0 {
+			scroll, _ := ctx.wheelScroll(delta)
+			state.ScrollX -= scroll
+		}
+	}
+	state.ScrollX = clampf(state.ScrollX, 0, maxScroll)
+
+	// Background and border
+	ctx.DrawList.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.InputBgColor)
+	ctx.DrawList.AddRectOutline(rect.X, rect.Y, rect.W, rect.H, ctx.style.InputBorderColor, ctx.style.BorderSize)
+
+	// Text below the header, clipped to the padded interior
+	ctx.pushClipRect(Rect{X: rect.X + pad, Y: rect.Y, W: innerW, H: rect.H})
+	y := rect.Y + headerH
+	for _, line := range lines {
+		if line != "" {
+			ctx.addMonoText(rect.X+pad-state.ScrollX, y, line, cw, ctx.style.TextColor)
+		}
+		y += lh
+	}
+	ctx.popClipRect()
+
+	// Scroll indicator along the bottom edge
+	if maxScroll > 0 {
+		thumbW := maxf(innerW*innerW/contentW, codeBlockScrollbarHeight*4)
+		thumbX := rect.X + pad + (innerW-thumbW)*state.ScrollX/maxScroll
+		ctx.DrawList.AddRect(thumbX, rect.Y+rect.H-codeBlockScrollbarHeight-1, thumbW, codeBlockScrollbarHeight, ctx.style.SliderGrabColor)
+	}
+
+	// Copy button
+	btnRect := Rect{X: rect.X + rect.W - btnW - SpaceXS, Y: rect.Y + SpaceXS, W: btnW, H: btnH}
+
+	ctx.RegisterFocusable(copyID, id+" copy", btnRect, FocusTypeLeaf)
+	hovered, held, clicked := ctx.buttonBehavior(copyID, btnRect, false, false)
+	if clicked || ctx.keyActivated(copyID) {
+		ClipboardSetText(text)
+		state.Copied = true
+		state.CopiedAt = ctx.Time
+	}
+	if state.Copied && ctx.Time-state.CopiedAt >= codeBlockCopiedDuration {
+		state.Copied = false
+	}
+
+	btnColor := ctx.style.ButtonColor
+	if held {
+		btnColor = ctx.style.ButtonActiveColor
+	} else if hovered || ctx.IsRegistryFocused(copyID) {
+		btnColor = ctx.style.ButtonHoveredColor
+	}
+	label := "Copy"
+	if state.Copied {
+		label = "Copied!"
+	}
+	labelSize := ctx.MeasureText(label)
+	ctx.DrawList.AddRect(btnRect.X, btnRect.Y, btnRect.W, btnRect.H, btnColor)
+	ctx.addText(btnRect.X+(btnRect.W-labelSize.X)/2, btnRect.Y+(btnRect.H-labelSize.Y)/2, label, ctx.style.TextColor)
+
+	ctx.advanceCursor(Vec2{X: w, Y: h})
+}
+
+// monoCellWidth returns the cell width CodeBlock lays text out on: the
+// width of "M" in the active font, and never narrower than Style.CharWidth.
+func (ctx *Context) monoCellWidth() float32 {
+	return maxf(ctx.MeasureText("M").X, ctx.style.CharWidth*ctx.style.FontScale)
+}
+
+// addMonoText draws text with one character per cw-wide cell, centring each
+// glyph in its cell. All glyphs go into a single draw command.
+func (ctx *Context) addMonoText(x, y float32, text string, cw float32, color uint32) {
+	dl := ctx.DrawList
+	f := ctx.activeFont()
+	if f == nil {
+		// The built-in font is monospace already, but advances by byte
+		dl.SetTexture(ctx.FontTextureID)
+		col := 0
+		for _, r := range text {
+			if r != ' ' {
+				dl.AddText(x+float32(col)*cw, y, string(r), color, ctx.style.FontScale, ctx.style.CharWidth, ctx.style.CharHeight)
+			}
+			col++
+		}
+		dl.SetTexture(0)
+		return
+	}
+
+	quads := ctx.glyphBuffer[:0]
+	col := 0
+	for _, r := range text {
+		if r != ' ' {
+			glyph := string(r)
+			gx := x + float32(col)*cw + (cw-ctx.MeasureText(glyph).X)/2
+			for _, q := range f.GetGlyphQuads(glyph, gx, y, ctx.style.FontScale) {
+				quads = append(quads, GlyphQuad{
+					X0: q.X0, Y0: q.Y0,
+					X1: q.X1, Y1: q.Y1,
+					U0: q.U0, V0: q.V0,
+					U1: q.U1, V1: q.V1,
+				})
+			}
+		}
+		col++
+	}
+	ctx.glyphBuffer = quads
+	dl.SetTexture(f.TextureID())
+	dl.AddGlyphQuads(quads, color)
+	dl.SetTexture(0)
+}
+
+// expandTabs replaces each tab in line with spaces up to the next multiple
+// of tabCols columns.
+func expandTabs(line string, tabCols int) string {
+	if !strings.ContainsRune(line, '\t') {
+		return line
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range line {
+		if r == '\t' {
+			n := tabCols - col%tabCols
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
@@ -0,0 +1,461 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// colorPickerStore is the type-safe store for color picker state.
+var colorPickerStore = NewFrameStore[ColorPickerState]()
+
+// Color picker regions, as stored in ColorPickerState.Dragging.
+const (
+	colorPartNone = iota
+	colorPartSV
+	colorPartHue
+	colorPartAlpha
+)
+
+// colorPickerWidth is the default width of a ColorPicker, bars included.
+const colorPickerWidth = float32(200)
+
+// colorPickerKeyStep is how far one arrow key press moves a component.
+const colorPickerKeyStep = 0.01
+
+// colorCheckerSize is the cell size of the checkerboard drawn behind
+// translucent colors.
+const colorCheckerSize = float32(6)
+
+// hueStops are the hue bar's gradient stops, red around to red.
+var hueStops = [7]uint32{
+	RGBA(255, 0, 0, 255),
+	RGBA(255, 255, 0, 255),
+	RGBA(0, 255, 0, 255),
+	RGBA(0, 255, 255, 255),
+	RGBA(0, 0, 255, 255),
+	RGBA(255, 0, 255, 255),
+	RGBA(255, 0, 0, 255),
+}
+
+// ColorPicker draws a saturation/value square next to a hue bar for
+// editing a packed color. Click or drag in either region to pick; while
+// focused, Left/Right change saturation, Up/Down change value and
+// PageUp/PageDown change hue. WithAlpha() adds an alpha bar. The height
+// follows the width (WithWidth) so the square stays square.
+// Returns true if the color changed.
+//
+// Usage:
+//
+//	if ctx.ColorPicker("Paint", &paint, gui.WithAlpha()) {
+//	    car.SetColor(paint)
+//	}
+func (ctx *Context) ColorPicker(label string, color *uint32, opts ...Option) bool {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+
+	labelWidth := float32(0)
+	if label != "" {
+		labelWidth = ctx.MeasureText(label).X + ctx.style.ItemSpacing
+		ctx.addText(pos.X, pos.Y, label, ctx.style.TextColor)
+	}
+
+	alpha := GetOpt(o, OptAlpha)
+	w := ctx.itemWidth(o, colorPickerWidth, labelWidth)
+	rect := Rect{X: pos.X + labelWidth, Y: pos.Y, W: w, H: ctx.colorPickerHeight(w, alpha)}
+
+	focusable := ctx.RegisterFocusable(id, label, rect, FocusTypeLeaf)
+	focused := focusable != nil && focusable.IsFocused()
+
+	state := colorPickerStore.GetFor(ctx, id, ColorPickerState{})
+	changed := ctx.colorPicker(ctx.DrawList, id, rect, color, state, alpha, focused)
+
+	ctx.notifyChange(id, o, BuiltinComponents.ColorPicker, label, *color, changed, state.Dragging != colorPartNone)
+	ctx.advanceCursor(Vec2{X: labelWidth + rect.W, Y: rect.H})
+	return changed
+}
+
+// ColorButton draws a color swatch that opens a ColorPicker popup when
+// clicked (or activated with Enter/Space). The popup is drawn on the
+// ForegroundDrawList and closes on Escape or a click outside it.
+// WithAlpha() adds an alpha bar to the popup. Returns true if the color
+// changed.
+//
+// Usage:
+//
+//	ctx.ColorButton("Accent", &accent)
+func (ctx *Context) ColorButton(label string, color *uint32, opts ...Option) bool {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+	state := colorPickerStore.GetFor(ctx, id, ColorPickerState{})
+
+	h := ctx.lineHeight() + ctx.style.InputPadding*2
+	swatch := Rect{X: pos.X, Y: pos.Y, W: ctx.itemWidth(o, h, 0), H: h}
+
+	focusable := ctx.RegisterFocusable(id, label, swatch, FocusTypeLeaf)
+	focused := focusable != nil && focusable.IsFocused()
+	hovered, _, clicked := ctx.buttonBehavior(id, swatch, false, false)
+	if focused && !state.Open && ctx.Input != nil && (ctx.Input.KeyPressed(KeyEnter) || ctx.Input.KeyPressed(KeySpace)) {
+		clicked = true
+	}
+	justOpened := false
+	if clicked {
+		state.Open = !state.Open
+		justOpened = state.Open
+		state.Dragging = colorPartNone
+		if !state.Open {
+			ctx.SetActivePopup(0)
+		}
+	}
+
+	// Draw swatch
+	addCheckerboard(ctx.DrawList, swatch)
+	ctx.DrawList.AddRect(swatch.X, swatch.Y, swatch.W, swatch.H, *color)
+	border := ctx.style.InputBorderColor
+	if hovered || focused || state.Open {
+		border = ctx.style.TextColor
+	}
+	ctx.DrawList.AddRectOutline(swatch.X, swatch.Y, swatch.W, swatch.H, border, ctx.style.BorderSize)
+
+	width := swatch.W
+	if label != "" {
+		ctx.addText(swatch.X+swatch.W+ctx.style.ItemSpacing, pos.Y+ctx.style.InputPadding, label, ctx.style.TextColor)
+		width += ctx.style.ItemSpacing + ctx.MeasureText(label).X
+	}
+
+	changed := false
+	if state.Open {
+		ctx.SetActivePopup(id)
+		savedInPopup := ctx.inPopup
+		ctx.inPopup = true
+
+		// Popup below the swatch, kept on screen horizontally
+		alpha := GetOpt(o, OptAlpha)
+		pad := ctx.style.ItemSpacing
+		popup := Rect{
+			X: swatch.X,
+			Y: swatch.Y + swatch.H + 2,
+			W: colorPickerWidth + pad*2,
+			H: ctx.colorPickerHeight(colorPickerWidth, alpha) + pad*2,
+		}
+		if ctx.DisplaySize.X > 0 {
+			popup.X = maxf(0, minf(popup.X, ctx.DisplaySize.X-popup.W))
+		}
+		fg := ctx.ForegroundDrawList
+		if fg == nil {
+			fg = ctx.DrawList
+		}
+		fg.AddRect(popup.X, popup.Y, popup.W, popup.H, RGBA(20, 20, 25, 255))
+		fg.AddRectOutline(popup.X, popup.Y, popup.W, popup.H, ctx.style.InputBorderColor, ctx.style.BorderSize)
+
+		picker := Rect{X: popup.X + pad, Y: popup.Y + pad, W: colorPickerWidth, H: popup.H - pad*2}
+		changed = ctx.colorPicker(fg, id, picker, color, state, alpha, !justOpened)
+		ctx.inPopup = savedInPopup
+
+		// Close on a click outside the swatch and the popup
+		if ctx.Input != nil && ctx.Input.MouseClicked(MouseButtonLeft) && !justOpened && state.Dragging == colorPartNone {
+			mouse := Vec2{ctx.Input.MouseX, ctx.Input.MouseY}
+			if !popup.Contains(mouse) && !swatch.Contains(mouse) {
+				state.Open = false
+			}
+		}
+		if !GetOpt(o, OptNoEscape) {
+			if ctx.escapeFor(escapePopup, id) {
+				state.Open = false
+			} else {
+				ctx.claimEscape(escapePopup, id)
+			}
+		}
+		if state.Open {
+			ctx.registerPopupRect(id, popup)
+		} else {
+			state.Dragging = colorPartNone
+			ctx.SetActivePopup(0)
+		}
+	}
+
+	ctx.notifyChange(id, o, BuiltinComponents.ColorPicker, label, *color, changed, state.Dragging != colorPartNone)
+	ctx.advanceCursor(Vec2{X: width, Y: h})
+	return changed
+}
+
+// ColorEdit edits a packed color channel by channel: a preview swatch
+// with the label, then R, G and B SliderInt rows (0-255), plus an A row
+// with WithAlpha(). WithHexInput() adds a hex field below the sliders
+// taking "#RRGGBB" (alpha kept) or, with WithAlpha(), "#RRGGBBAA". The
+// field applies the color as soon as it holds a complete value. Returns
+// true if any channel changed.
+//
+// Usage:
+//
+//	if ctx.ColorEdit("Body", &paint, gui.WithHexInput()) {
+//	    car.SetColor(paint)
+//	}
+func (ctx *Context) ColorEdit(label string, color *uint32, opts ...Option) bool {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+	state := colorPickerStore.GetFor(ctx, id, ColorPickerState{})
+	alpha := GetOpt(o, OptAlpha)
+
+	// Preview swatch and label
+	h := ctx.lineHeight() + ctx.style.InputPadding*2
+	addCheckerboard(ctx.DrawList, Rect{X: pos.X, Y: pos.Y, W: h * 2, H: h})
+	ctx.DrawList.AddRect(pos.X, pos.Y, h*2, h, *color)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, h*2, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	width := h * 2
+	if label != "" {
+		ctx.addText(pos.X+h*2+ctx.style.ItemSpacing, pos.Y+ctx.style.InputPadding, label, ctx.style.TextColor)
+		width += ctx.style.ItemSpacing + ctx.MeasureText(label).X
+	}
+	ctx.advanceCursor(Vec2{X: width, Y: h})
+
+	ctx.PushID(label)
+	defer ctx.PopID()
+
+	var sliderOpts []Option
+	if w := GetOpt(o, OptWidth); w > 0 {
+		sliderOpts = append(sliderOpts, WithWidth(w))
+	}
+	r, g, b, a := UnpackRGBA(*color)
+	channels := []*uint8{&r, &g, &b}
+	names := []string{"R", "G", "B"}
+	if alpha {
+		channels = append(channels, &a)
+		names = append(names, "A")
+	}
+	changed := false
+	for i, ch := range channels {
+		v := int(*ch)
+		if ctx.SliderInt(names[i], &v, 0, 255, sliderOpts...) {
+			*ch = uint8(v)
+			changed = true
+		}
+	}
+	if changed {
+		*color = RGBA(r, g, b, a)
+	}
+
+	if GetOpt(o, OptHexInput) {
+		// Reformat unless the color came from the field itself, so partial
+		// input isn't overwritten while typing
+		if !state.Synced || state.HexColor != *color {
+			state.HexText = formatHexColor(*color, alpha)
+			state.HexColor = *color
+			state.Synced = true
+		}
+		if ctx.InputText("Hex", &state.HexText, append(sliderOpts, WithID("hex"))...) {
+			if c, ok := parseHexColor(state.HexText, *color, alpha); ok && c != *color {
+				*color = c
+				state.HexColor = c
+				changed = true
+			}
+		}
+	}
+
+	ctx.notifyChange(id, o, BuiltinComponents.ColorPicker, label, *color, changed, false)
+	return changed
+}
+
+// formatHexColor formats c as "#RRGGBB", or "#RRGGBBAA" with alpha.
+func formatHexColor(c uint32, alpha bool) string {
+	r, g, b, a := UnpackRGBA(c)
+	if alpha {
+		return fmt.Sprintf("#%02X%02X%02X%02X", r, g, b, a)
+	}
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+}
+
+// parseHexColor parses "#RRGGBB" (keeping the alpha of current) or, when
+// alpha is set, "#RRGGBBAA". The '#' is optional.
+func parseHexColor(text string, current uint32, alpha bool) (uint32, bool) {
+	text = strings.TrimPrefix(strings.TrimSpace(text), "#")
+	if len(text) != 6 && !(alpha && len(text) == 8) {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(text, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	_, _, _, a := UnpackRGBA(current)
+	if len(text) == 8 {
+		a = uint8(n)
+		n >>= 8
+	}
+	return RGBA(uint8(n>>16), uint8(n>>8), uint8(n), a), true
+}
+
+// colorPickerBars returns the width of one picker bar and the gap before it.
+func (ctx *Context) colorPickerBars() (barW, gap float32) {
+	return ctx.lineHeight(), ctx.style.ItemSpacing
+}
+
+// colorPickerHeight returns the height of a picker w wide: the side of its
+// square, which gets the width the bars leave.
+func (ctx *Context) colorPickerHeight(w float32, alpha bool) float32 {
+	barW, gap := ctx.colorPickerBars()
+	bars := float32(1)
+	if alpha {
+		bars = 2
+	}
+	return maxf(barW, w-bars*(barW+gap))
+}
+
+// colorPicker handles input for and draws a picker filling rect onto dl.
+// keyboard enables the arrow and page keys. Returns true if *color changed.
+func (ctx *Context) colorPicker(dl *DrawList, id ID, rect Rect, color *uint32, state *ColorPickerState, alpha, keyboard bool) bool {
+	barW, gap := ctx.colorPickerBars()
+	side := rect.H
+	sv := Rect{X: rect.X, Y: rect.Y, W: side, H: side}
+	hue := Rect{X: sv.X + side + gap, Y: rect.Y, W: barW, H: side}
+	alphaBar := Rect{X: hue.X + barW + gap, Y: rect.Y, W: barW, H: side}
+
+	// Resync HSV when the color was changed elsewhere. Grays keep the
+	// current hue, and black keeps the saturation as well.
+	if !state.Synced || *color != state.Color {
+		r, g, b, _ := UnpackRGBA(*color)
+		h, s, v := RGBToHSV(float32(r)/255, float32(g)/255, float32(b)/255)
+		if !state.Synced || s > 0 && v > 0 {
+			state.H = h
+		}
+		if !state.Synced || v > 0 {
+			state.S = s
+		}
+		state.V = v
+		state.Color = *color
+		state.Synced = true
+	}
+	_, _, _, a := UnpackRGBA(*color)
+
+	edited := false
+	if input := ctx.Input; input != nil {
+		if state.Dragging == colorPartNone && input.MouseClicked(MouseButtonLeft) {
+			switch {
+			case ctx.isHovered(id, sv):
+				state.Dragging = colorPartSV
+			case ctx.isHovered(id, hue):
+				state.Dragging = colorPartHue
+			case alpha && ctx.isHovered(id, alphaBar):
+				state.Dragging = colorPartAlpha
+			}
+		}
+		if state.Dragging != colorPartNone && !input.MouseDown(MouseButtonLeft) {
+			state.Dragging = colorPartNone
+		}
+
+		// Drags keep tracking once the mouse leaves the region
+		tx := clampf((input.MouseX-sv.X)/side, 0, 1)
+		ty := clampf((input.MouseY-rect.Y)/side, 0, 1)
+		edited = state.Dragging != colorPartNone
+		switch state.Dragging {
+		case colorPartSV:
+			state.S, state.V = tx, 1-ty
+		case colorPartHue:
+			state.H = ty
+		case colorPartAlpha:
+			a = unitToByte(1 - ty)
+		}
+
+		if keyboard {
+			step := float32(colorPickerKeyStep)
+			keyed := true
+			switch {
+			case input.KeyRepeated(KeyLeft):
+				state.S = clampf(state.S-step, 0, 1)
+			case input.KeyRepeated(KeyRight):
+				state.S = clampf(state.S+step, 0, 1)
+			case input.KeyRepeated(KeyDown):
+				state.V = clampf(state.V-step, 0, 1)
+			case input.KeyRepeated(KeyUp):
+				state.V = clampf(state.V+step, 0, 1)
+			case input.KeyRepeated(KeyPageDown):
+				state.H = clampf(state.H-step, 0, 1)
+			case input.KeyRepeated(KeyPageUp):
+				state.H = clampf(state.H+step, 0, 1)
+			default:
+				keyed = false
+			}
+			edited = edited || keyed
+		}
+	}
+
+	// Only write back edits, so an untouched color never drifts
+	changed := false
+	if edited {
+		r, g, b := HSVToRGB(state.H, state.S, state.V)
+		packed := RGBA(unitToByte(r), unitToByte(g), unitToByte(b), a)
+		changed = packed != *color
+		*color = packed
+		state.Color = packed
+	}
+	packed := *color
+
+	// Saturation/value square: white to the pure hue, darkened toward black
+	hr, hg, hb := HSVToRGB(state.H, 1, 1)
+	pure := RGBA(unitToByte(hr), unitToByte(hg), unitToByte(hb), 255)
+	dl.AddRectMultiColor(sv.X, sv.Y, side, side, ColorWhite, pure, pure, ColorWhite)
+	dl.AddRectMultiColor(sv.X, sv.Y, side, side, ColorTransparent, ColorTransparent, ColorBlack, ColorBlack)
+	dl.AddRectOutline(sv.X, sv.Y, side, side, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	mx, my := sv.X+state.S*side, sv.Y+(1-state.V)*side
+	dl.AddRectOutline(mx-4, my-4, 9, 9, ColorBlack, 1)
+	dl.AddRectOutline(mx-3, my-3, 7, 7, ColorWhite, 1)
+
+	// Hue bar: six gradient segments
+	segH := side / 6
+	for i := 0; i < 6; i++ {
+		top, bottom := hueStops[i], hueStops[i+1]
+		dl.AddRectMultiColor(hue.X, hue.Y+float32(i)*segH, barW, segH, top, top, bottom, bottom)
+	}
+	dl.AddRectOutline(hue.X, hue.Y, barW, side, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	addBarMarker(dl, hue, hue.Y+state.H*side)
+
+	// Alpha bar: opaque at the top, over a checkerboard
+	if alpha {
+		addCheckerboard(dl, alphaBar)
+		dl.AddRectMultiColor(alphaBar.X, alphaBar.Y, barW, side,
+			packed|0xFF000000, packed|0xFF000000, packed&0x00FFFFFF, packed&0x00FFFFFF)
+		dl.AddRectOutline(alphaBar.X, alphaBar.Y, barW, side, ctx.style.InputBorderColor, ctx.style.BorderSize)
+		addBarMarker(dl, alphaBar, alphaBar.Y+(1-float32(a)/255)*side)
+	}
+
+	return changed
+}
+
+// unitToByte converts a 0.0-1.0 component to 0-255, rounding to nearest.
+func unitToByte(f float32) uint8 {
+	return uint8(clampf(f, 0, 1)*255 + 0.5)
+}
+
+// addBarMarker draws a picker bar's position marker at y.
+func addBarMarker(dl *DrawList, bar Rect, y float32) {
+	dl.AddRectOutline(bar.X-2, y-3, bar.W+4, 6, ColorBlack, 1)
+	dl.AddRectOutline(bar.X-1, y-2, bar.W+2, 4, ColorWhite, 1)
+}
+
+// addCheckerboard fills rect with a gray checkerboard, the usual backdrop
+// for showing transparency.
+func addCheckerboard(dl *DrawList, rect Rect) {
+	dl.AddRect(rect.X, rect.Y, rect.W, rect.H, RGBA(204, 204, 204, 255))
+	row := 0
+	for y := rect.Y; y < rect.Y+rect.H; y += colorCheckerSize {
+		h := minf(colorCheckerSize, rect.Y+rect.H-y)
+		for x := rect.X + float32(row%2)*colorCheckerSize; x < rect.X+rect.W; x += colorCheckerSize * 2 {
+			dl.AddRect(x, y, minf(colorCheckerSize, rect.X+rect.W-x), h, RGBA(128, 128, 128, 255))
+		}
+		row++
+	}
+}
@@ -1,8 +1,14 @@
 package gui
 
-import "strings"
-
-// ComboBox draws a dropdown selection widget.
+import (
+	"hash/maphash"
+	"strings"
+)
+
+// ComboBox draws a dropdown selection widget. While the dropdown is open,
+// Up/Down move the highlighted item (within the matches when
+// WithSearchable filters the list), Enter selects it and closes, and
+// Escape closes without changing the selection.
 // Returns true if the selection changed.
 //
 // Usage:
@@ -12,6 +18,38 @@ import "strings"
 //	    applyQuality(selectedIndex)
 //	}
 func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, opts ...Option) bool {
+	itemText := func(i int) string { return items[i] }
+	return ctx.comboBox(label, selectedIndex, len(items), itemText, nil, nil, opts)
+}
+
+// ComboBoxCustom draws a dropdown whose entries are rendered by the caller.
+// renderItem is called for each visible dropdown row and may use ordinary
+// widgets (Text, TextColored, ...) laid out horizontally within the row.
+// renderPreview draws the selected entry in the closed field; if nil,
+// renderItem is used instead. Typing to filter (WithSearchable) is not
+// available since items have no text.
+// Returns true if the selection changed.
+//
+// Usage:
+//
+//	ctx.ComboBoxCustom("Color", &selected, len(colors),
+//	    func(i int, selected bool) {
+//	        ctx.TextColored("■", colors[i].Value)
+//	        ctx.Text(colors[i].Name)
+//	    },
+//	    nil,
+//	)
+func (ctx *Context) ComboBoxCustom(label string, selected *int, count int, renderItem func(i int, selected bool), renderPreview func(i int), opts ...Option) bool {
+	if renderPreview == nil {
+		renderPreview = func(i int) { renderItem(i, false) }
+	}
+	return ctx.comboBox(label, selected, count, nil, renderItem, renderPreview, opts)
+}
+
+// comboBox implements ComboBox and ComboBoxCustom.
+// Items are drawn from itemText unless renderItem is set.
+func (ctx *Context) comboBox(label string, selectedIndex *int, count int, itemText func(int) string,
+	renderItem func(i int, selected bool), renderPreview func(i int), opts []Option) bool {
 	pos := ctx.ItemPos()
 	o := applyOptions(opts)
 
@@ -29,15 +67,21 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 		labelWidth = ctx.MeasureText(label).X + ctx.style.ItemSpacing
 	}
 
-	// Calculate combo width based on longest item
-	comboWidth := float32(150)
-	if width := GetOpt(o, OptWidth); width > 0 {
-		comboWidth = width
-	} else {
-		for _, item := range items {
-			itemWidth := ctx.MeasureText(item).X + ctx.style.ButtonPadding*2 + 20 // +20 for arrow
-			if itemWidth > comboWidth {
+	// Calculate combo width based on longest item (unless given a width or
+	// filling a LabeledRow column)
+	comboWidth := ctx.itemWidth(o, 0, labelWidth)
+	autoWidth := GetOpt(o, OptAutoWidth)
+	if comboWidth == 0 {
+		comboWidth = 150
+		if itemText != nil {
+			itemWidth := ctx.comboItemsWidth(&state, count, itemText) + ctx.style.ButtonPadding*2 + 20 // +20 for arrow
+			if autoWidth.Enabled {
 				comboWidth = itemWidth
+				if autoWidth.Max > 0 {
+					comboWidth = minf(comboWidth, autoWidth.Max)
+				}
+			} else {
+				comboWidth = maxf(comboWidth, itemWidth)
 			}
 		}
 	}
@@ -70,19 +114,24 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 		bgColor = ctx.style.ButtonHoveredColor
 	}
 	ctx.DrawList.AddRect(headerX, headerY, comboWidth, h, bgColor)
-	ctx.DrawList.AddRectOutline(headerX, headerY, comboWidth, h, ctx.style.InputBorderColor, 1)
+	ctx.DrawList.AddRectOutline(headerX, headerY, comboWidth, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
 
-	// Draw selected item text
-	selectedText := ""
-	if *selectedIndex >= 0 && *selectedIndex < len(items) {
-		selectedText = items[*selectedIndex]
-	}
+	// Draw dropdown arrow
+	arrowX := headerX + comboWidth - ctx.style.ButtonPadding - arrowSize
+
+	// Draw selected item
 	textX := headerX + ctx.style.ButtonPadding
 	textY := headerY + (h-ctx.lineHeight())/2
-	ctx.addText(textX, textY, selectedText, ctx.style.TextColor)
+	hasSelection := *selectedIndex >= 0 && *selectedIndex < count
+	if renderPreview != nil {
+		if hasSelection {
+			previewRect := Rect{X: textX, Y: textY, W: arrowX - textX - ctx.style.ItemSpacing, H: ctx.lineHeight()}
+			ctx.drawCustomContent(ctx.DrawList, previewRect, func() { renderPreview(*selectedIndex) })
+		}
+	} else if hasSelection {
+		ctx.addText(textX, textY, itemText(*selectedIndex), ctx.style.TextColor)
+	}
 
-	// Draw dropdown arrow
-	arrowX := headerX + comboWidth - ctx.style.ButtonPadding - arrowSize
 	arrowY := headerY + h/2
 	if state.Open {
 		// Up arrow when open
@@ -121,21 +170,17 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 		}
 	}
 
-	// Keyboard support when focused
-	if isFocused && ctx.Input != nil {
-		// Enter/Space to toggle dropdown
+	// Keyboard support when focused: Enter/Space open the dropdown (while
+	// it's open, Enter picks the highlighted item below)
+	if isFocused && !state.Open && ctx.Input != nil {
 		if ctx.Input.KeyPressed(KeyEnter) || ctx.Input.KeyPressed(KeySpace) {
-			state.Open = !state.Open
+			state.Open = true
 			state.HoveredIndex = -1
-			if state.Open {
-				justOpened = true
-				state.KeyboardIndex = *selectedIndex // Start keyboard nav at current selection
-				ctx.SetActivePopup(id)               // Mark popup as active
-				if GetOpt(o, OptSearchable) {
-					state.SearchText = ""
-				}
-			} else {
-				ctx.SetActivePopup(0) // Close popup
+			justOpened = true
+			state.KeyboardIndex = *selectedIndex // Start keyboard nav at current selection
+			ctx.SetActivePopup(id)               // Mark popup as active
+			if GetOpt(o, OptSearchable) {
+				state.SearchText = ""
 			}
 		}
 	}
@@ -144,6 +189,9 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 	if state.Open {
 		// Mark popup as active every frame it's open (for HandleInput in next frame)
 		ctx.SetActivePopup(id)
+		// Popup content isn't clipped or occluded by the containers around the combo
+		savedInPopup := ctx.inPopup
+		ctx.inPopup = true
 		// Capture keyboard when dropdown is open to prevent focus navigation
 		ctx.WantCaptureKeyboard = true
 
@@ -153,23 +201,18 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 			fgDrawList = ctx.DrawList // Fallback if no foreground list
 		}
 
-		dropdownY := headerY + h
-
-		// Filter items if searchable
-		filteredItems := items
-		filteredIndices := make([]int, len(items))
-		for i := range items {
+		// Filter items if searchable (custom-rendered items have no text to match)
+		filteredIndices := make([]int, count)
+		for i := range count {
 			filteredIndices[i] = i
 		}
 
-		searchable := GetOpt(o, OptSearchable)
+		searchable := GetOpt(o, OptSearchable) && itemText != nil
 		if searchable && state.SearchText != "" {
-			filteredItems = nil
 			filteredIndices = nil
 			searchLower := strings.ToLower(state.SearchText)
-			for i, item := range items {
-				if strings.Contains(strings.ToLower(item), searchLower) {
-					filteredItems = append(filteredItems, item)
+			for i := range count {
+				if strings.Contains(strings.ToLower(itemText(i)), searchLower) {
 					filteredIndices = append(filteredIndices, i)
 				}
 			}
@@ -182,7 +225,7 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 			searchHeight = ctx.lineHeight() + ctx.style.InputPadding*2 + ctx.style.ItemSpacing
 		}
 
-		maxItems := len(filteredItems)
+		maxItems := len(filteredIndices)
 		maxDropdownHeight := GetOpt(o, OptMaxDropdownHeight)
 		if maxDropdownHeight == 0 {
 			maxDropdownHeight = 200
@@ -191,15 +234,48 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 		contentHeight := float32(maxItems)*itemHeight + searchHeight
 		dropdownHeight := minf(contentHeight, maxDropdownHeight)
 
+		// Open upward when the dropdown doesn't fit below the header and
+		// there is more room above. The direction is chosen when opening,
+		// so filtering doesn't flip it; the height is capped to the room
+		// on that side (always showing at least one item).
+		if ctx.DisplaySize.Y > 0 {
+			below := ctx.DisplaySize.Y - (headerY + h)
+			above := headerY
+			if justOpened {
+				state.OpenUpward = dropdownHeight > below && above > below
+			}
+			room := below
+			if state.OpenUpward {
+				room = above
+			}
+			dropdownHeight = minf(dropdownHeight, maxf(room, itemHeight+searchHeight))
+		}
+		dropdownRect := Rect{X: headerX, Y: headerY + h, W: comboWidth, H: dropdownHeight}
+		if state.OpenUpward {
+			dropdownRect.Y = headerY - dropdownHeight
+		}
+
+		// The search box sits next to the header: at the top of the
+		// dropdown, or at the bottom when it opens upward
+		dropdownY := dropdownRect.Y // Top of the item list
+		searchY := dropdownRect.Y
+		if searchable {
+			if state.OpenUpward {
+				searchY = dropdownRect.Y + dropdownHeight - (searchHeight - ctx.style.ItemSpacing) - 4
+			} else {
+				dropdownY += searchHeight
+			}
+		}
+
 		// Draw dropdown background (fully opaque for visibility)
-		fgDrawList.AddRect(headerX, dropdownY, comboWidth, dropdownHeight, RGBA(20, 20, 25, 255))
-		fgDrawList.AddRectOutline(headerX, dropdownY, comboWidth, dropdownHeight, ctx.style.InputBorderColor, 1)
+		fgDrawList.AddRect(dropdownRect.X, dropdownRect.Y, comboWidth, dropdownHeight, RGBA(20, 20, 25, 255))
+		fgDrawList.AddRectOutline(dropdownRect.X, dropdownRect.Y, comboWidth, dropdownHeight, ctx.style.InputBorderColor, ctx.style.BorderSize)
 
 		// Handle search input if searchable
 		if searchable {
 			searchRect := Rect{
 				X: headerX + 2,
-				Y: dropdownY + 2,
+				Y: searchY + 2,
 				W: comboWidth - 4,
 				H: ctx.lineHeight() + ctx.style.InputPadding*2,
 			}
@@ -209,6 +285,7 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 
 			// Handle search input
 			if ctx.Input != nil {
+				search := state.SearchText
 				for _, ch := range ctx.Input.InputChars {
 					if ch >= 32 && ch < 127 {
 						state.SearchText += string(ch)
@@ -217,6 +294,11 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 				if ctx.Input.KeyRepeated(KeyBackspace) && len(state.SearchText) > 0 {
 					state.SearchText = state.SearchText[:len(state.SearchText)-1]
 				}
+				// A new filter applies next frame: highlight its first match
+				if state.SearchText != search {
+					state.KeyboardIndex = 0
+					state.ScrollY = 0
+				}
 			}
 
 			// Draw search text
@@ -227,8 +309,6 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 			} else {
 				ctx.addTextTo(fgDrawList, searchTextX, searchTextY, "Search...", ctx.style.TextDisabledColor)
 			}
-
-			dropdownY += searchHeight
 		}
 
 		// Push clip rect for scrollable area
@@ -239,8 +319,7 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 		itemY := dropdownY - state.ScrollY
 		state.HoveredIndex = -1
 
-		for i, item := range filteredItems {
-			originalIndex := filteredIndices[i]
+		for i, originalIndex := range filteredIndices {
 
 			// Skip items outside visible area
 			if itemY+itemHeight < dropdownY {
@@ -280,7 +359,12 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 			if originalIndex == *selectedIndex || isKeyboardSelected {
 				textColor = ctx.style.SelectedTextColor
 			}
-			ctx.addTextTo(fgDrawList, itemRect.X+ctx.style.ItemSpacing, itemY, item, textColor)
+			if renderItem != nil {
+				contentRect := Rect{X: itemRect.X + ctx.style.ItemSpacing, Y: itemY, W: itemRect.W - ctx.style.ItemSpacing, H: itemHeight}
+				ctx.drawCustomContent(fgDrawList, contentRect, func() { renderItem(originalIndex, originalIndex == *selectedIndex) })
+			} else {
+				ctx.addTextTo(fgDrawList, itemRect.X+ctx.style.ItemSpacing, itemY, itemText(originalIndex), textColor)
+			}
 
 			// Handle click on item
 			if ctx.Input != nil && ctx.Input.MouseClicked(MouseButtonLeft) {
@@ -301,7 +385,6 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 
 		// Handle scroll
 		if ctx.Input != nil {
-			dropdownRect := Rect{X: headerX, Y: headerY + h, W: comboWidth, H: dropdownHeight}
 			if ctx.isHovered(id, dropdownRect) && ctx.Input.MouseWheelY != 0 {
 				maxScroll := maxf(0, contentHeight-searchHeight-scrollAreaHeight)
 				state.ScrollY = clampf(state.ScrollY-ctx.Input.MouseWheelY*20, 0, maxScroll)
@@ -310,19 +393,25 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 
 		// Close on click outside
 		if ctx.Input != nil && ctx.Input.MouseClicked(MouseButtonLeft) {
-			dropdownRect := Rect{X: headerX, Y: headerY, W: comboWidth, H: h + dropdownHeight}
-			if !ctx.isHovered(id, dropdownRect) {
+			if !ctx.isHovered(id, dropdownRect) && !ctx.isHovered(id, headerRect) {
 				state.Open = false
 				ctx.SetActivePopup(0)
 			}
 		}
 
 		// Close on Escape
-		if ctx.Input != nil && ctx.Input.KeyPressed(KeyEscape) {
-			state.Open = false
-			ctx.SetActivePopup(0)
+		if !GetOpt(o, OptNoEscape) {
+			if ctx.escapeFor(escapePopup, id) {
+				state.Open = false
+				ctx.SetActivePopup(0)
+			} else {
+				ctx.claimEscape(escapePopup, id)
+			}
 		}
 
+		ctx.registerPopupRect(id, dropdownRect)
+		ctx.inPopup = savedInPopup
+
 		// Keyboard navigation within dropdown (when focused or open)
 		if ctx.Input != nil {
 			// Use keyboard index if not set
@@ -330,18 +419,34 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 				state.KeyboardIndex = *selectedIndex
 			}
 
-			// Up/Down to navigate items
+			// Up/Down to navigate items, within the filtered subset
+			state.KeyboardIndex = min(state.KeyboardIndex, len(filteredIndices)-1)
+			moved := false
 			if ctx.Input.KeyRepeated(KeyUp) {
 				if state.KeyboardIndex > 0 {
 					state.KeyboardIndex--
+					moved = true
 				}
 			}
 			if ctx.Input.KeyRepeated(KeyDown) {
-				if state.KeyboardIndex < len(filteredItems)-1 {
+				if state.KeyboardIndex < len(filteredIndices)-1 {
 					state.KeyboardIndex++
+					moved = true
 				}
 			}
 
+			// Scroll the highlighted item into view
+			if moved {
+				top := float32(state.KeyboardIndex) * itemHeight
+				maxScroll := maxf(0, contentHeight-searchHeight-scrollAreaHeight)
+				if top < state.ScrollY {
+					state.ScrollY = top
+				} else if top+itemHeight > state.ScrollY+scrollAreaHeight {
+					state.ScrollY = top + itemHeight - scrollAreaHeight
+				}
+				state.ScrollY = clampf(state.ScrollY, 0, maxScroll)
+			}
+
 			// Enter to select and close (skip if we just opened this frame)
 			if !justOpened && ctx.Input.KeyPressed(KeyEnter) {
 				if state.KeyboardIndex >= 0 && state.KeyboardIndex < len(filteredIndices) {
@@ -365,9 +470,84 @@ func (ctx *Context) ComboBox(label string, selectedIndex *int, items []string, o
 	// Save state
 	SetState(ctx, id, state)
 
-	// Advance cursor
+	ctx.notifyChange(id, o, BuiltinComponents.ComboBox, label, *selectedIndex, changed, false)
+
+	// Advance cursor (past the error message, if any)
+	h += ctx.drawFieldError(o, headerRect)
 	totalWidth := labelWidth + comboWidth
 	ctx.advanceCursor(Vec2{totalWidth, h})
 
 	return changed
 }
+
+// drawCustomContent runs fn with the cursor at rect inside a horizontal
+// layout, drawing into dl. The caller's cursor, layouts and draw list are
+// restored afterwards, so fn can use ordinary widgets without disturbing
+// the surrounding layout. Content drawn into another list (e.g. a popup on
+// the foreground list) isn't clipped by the current containers.
+func (ctx *Context) drawCustomContent(dl *DrawList, rect Rect, fn func()) {
+	savedDrawList := ctx.DrawList
+	savedCursor := ctx.cursor
+	savedLayouts := ctx.layoutStack
+	savedClips := ctx.clipStack
+
+	// Reuse the stacks' spare capacity; restoring truncates back
+	ctx.layoutStack = savedLayouts[len(savedLayouts):]
+	if dl != savedDrawList {
+		ctx.clipStack = savedClips[len(savedClips):]
+	}
+	ctx.DrawList = dl
+	ctx.cursor = Vec2{rect.X, rect.Y}
+	savedInPopup := ctx.inPopup
+	if dl != savedDrawList {
+		ctx.inPopup = true
+	}
+
+	ctx.pushLayoutWith(&Layout{
+		Type:   LayoutHorizontal,
+		Width:  rect.W,
+		Height: rect.H,
+		Gap:    ctx.style.ItemSpacing,
+	})
+	fn()
+	ctx.popLayout()
+
+	ctx.DrawList = savedDrawList
+	ctx.cursor = savedCursor
+	ctx.layoutStack = savedLayouts
+	ctx.clipStack = savedClips
+	ctx.inPopup = savedInPopup
+}
+
+// comboHashSeed seeds the item hash comboItemsWidth caches widths under.
+var comboHashSeed = maphash.MakeSeed()
+
+// comboItemsWidth returns the widest item's text width. The result is cached
+// in state under a hash of the item texts and the line height, so large
+// lists are only re-measured when their contents or the font change (or
+// InvalidateTextCache is called).
+func (ctx *Context) comboItemsWidth(state *ComboBoxState, count int, itemText func(int) string) float32 {
+	var h maphash.Hash
+	h.SetSeed(comboHashSeed)
+	for i := range count {
+		h.WriteString(itemText(i))
+		h.WriteByte(0)
+	}
+	hash := h.Sum64()
+	lineH := ctx.lineHeight()
+	if state.ItemsMeasured && state.ItemsHash == hash && state.ItemsLineHeight == lineH &&
+		state.ItemsTextGen == ctx.textCacheGen {
+		return state.ItemsWidth
+	}
+
+	var widest float32
+	for i := range count {
+		widest = maxf(widest, ctx.MeasureText(itemText(i)).X)
+	}
+	state.ItemsMeasured = true
+	state.ItemsHash = hash
+	state.ItemsLineHeight = lineH
+	state.ItemsTextGen = ctx.textCacheGen
+	state.ItemsWidth = widest
+	return widest
+}
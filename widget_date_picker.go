@@ -0,0 +1,257 @@
+package gui
+
+import "time"
+
+// datePickerStore is the type-safe store for date picker state.
+var datePickerStore = NewFrameStore[DatePickerState]()
+
+// datePickerWeekdays are the calendar's column headers, Sunday first like
+// time.Weekday.
+var datePickerWeekdays = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// datePickerWidth is the minimum width of a DatePicker's field.
+const datePickerWidth = float32(120)
+
+// DatePicker draws a field showing *t formatted with WithDateFormat
+// ("2006-01-02" by default). Clicking it (or Enter/Space when focused)
+// opens a month calendar on the ForegroundDrawList: the arrows in its
+// header change month, and clicking a day sets the date of *t, keeping its
+// time of day and location, and closes the calendar. While open,
+// the arrow keys move the highlighted day, PageUp/PageDown change month and
+// Enter picks the day; Escape or a click outside closes it. Today's date
+// is outlined. Returns true if *t changed.
+//
+// Usage:
+//
+//	if ctx.DatePicker("Since", &since, gui.WithDateFormat("Jan 2, 2006")) {
+//	    filterLogs(since)
+//	}
+func (ctx *Context) DatePicker(label string, t *time.Time, opts ...Option) bool {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+	state := datePickerStore.GetFor(ctx, id, DatePickerState{})
+
+	labelWidth := float32(0)
+	if label != "" {
+		labelWidth = ctx.MeasureText(label).X + ctx.style.ItemSpacing
+	}
+	text := t.Format(GetOpt(o, OptDateFormat))
+	pad := ctx.style.InputPadding
+	h := ctx.lineHeight() + pad*2
+	w := ctx.itemWidth(o, maxf(datePickerWidth, ctx.MeasureText(text).X+pad*2), labelWidth)
+	field := Rect{X: pos.X + labelWidth, Y: pos.Y, W: w, H: h}
+
+	focusable := ctx.RegisterFocusable(id, label, field, FocusTypeLeaf)
+	focused := focusable != nil && focusable.IsFocused()
+	hovered, _, clicked := ctx.buttonBehavior(id, field, false, false)
+	if focused && !state.Open && ctx.Input != nil && (ctx.Input.KeyPressed(KeyEnter) || ctx.Input.KeyPressed(KeySpace)) {
+		clicked = true
+	}
+	justOpened := false
+	if clicked {
+		state.Open = !state.Open
+		justOpened = state.Open
+		if state.Open {
+			// Start at the current date, or today when unset
+			cursor := *t
+			if cursor.IsZero() {
+				cursor = time.Now()
+			}
+			state.Cursor = dateOnly(cursor)
+			state.Month = firstOfMonth(state.Cursor)
+		} else {
+			ctx.SetActivePopup(0)
+		}
+	}
+
+	// Draw label and field
+	if label != "" {
+		ctx.addText(pos.X, pos.Y+pad, label, ctx.style.TextColor)
+	}
+	bg := ctx.style.InputBgColor
+	if hovered || focused || state.Open {
+		bg = ctx.style.InputFocusedBgColor
+	}
+	ctx.DrawList.AddRect(field.X, field.Y, field.W, field.H, bg)
+	ctx.DrawList.AddRectOutline(field.X, field.Y, field.W, field.H, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	ctx.addText(field.X+pad, field.Y+pad, text, ctx.style.TextColor)
+
+	changed := false
+	if state.Open {
+		changed = ctx.datePickerPopup(id, field, t, state, justOpened, !GetOpt(o, OptNoEscape))
+	}
+
+	ctx.notifyChange(id, o, BuiltinComponents.DatePicker, label, *t, changed, false)
+	ctx.advanceCursor(Vec2{X: labelWidth + w, Y: h})
+	return changed
+}
+
+// datePickerPopup draws the open calendar below field and handles picking a
+// day, keyboard navigation and closing. Returns true if *t changed.
+func (ctx *Context) datePickerPopup(id ID, field Rect, t *time.Time, state *DatePickerState, justOpened, escape bool) bool {
+	ctx.SetActivePopup(id)
+	savedInPopup := ctx.inPopup
+	ctx.inPopup = true
+	ctx.PushID("date_picker")
+	defer ctx.PopID()
+
+	changed := false
+	pick := func(day time.Time) {
+		picked := time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		if !picked.Equal(*t) {
+			*t = picked
+			changed = true
+		}
+		state.Open = false
+	}
+	moveCursor := func(days int) {
+		state.Cursor = state.Cursor.AddDate(0, 0, days)
+		state.Month = firstOfMonth(state.Cursor)
+	}
+	changeMonth := func(months int) {
+		state.Month = state.Month.AddDate(0, months, 0)
+		// Keep the highlighted day in the shown month, clamped to its length
+		last := state.Month.AddDate(0, 1, -1).Day()
+		state.Cursor = state.Month.AddDate(0, 0, min(state.Cursor.Day(), last)-1)
+	}
+
+	// Header row with the month arrows, a weekday row, and six weeks
+	pad := ctx.style.ItemSpacing
+	lh := ctx.lineHeight()
+	cellW := maxf(ctx.MeasureText("00").X, ctx.MeasureText("Mo").X) + pad*2
+	cellH := lh + pad
+	popup := Rect{X: field.X, Y: field.Y + field.H + 2, W: cellW*7 + pad*2, H: cellH*8 + pad*2}
+	if ctx.DisplaySize.X > 0 {
+		popup.X = maxf(0, minf(popup.X, ctx.DisplaySize.X-popup.W))
+	}
+	if ctx.DisplaySize.Y > 0 && popup.Y+popup.H > ctx.DisplaySize.Y && field.Y-popup.H-2 >= 0 {
+		popup.Y = field.Y - popup.H - 2
+	}
+	fg := ctx.foregroundDrawList()
+	fg.AddRect(popup.X, popup.Y, popup.W, popup.H, ctx.style.DropdownBgColor)
+	fg.AddRectOutline(popup.X, popup.Y, popup.W, popup.H, ctx.style.InputBorderColor, ctx.style.BorderSize)
+
+	x0, y := popup.X+pad, popup.Y+pad
+	centerText := func(r Rect, text string, color uint32) {
+		ctx.addTextTo(fg, r.X+(r.W-ctx.MeasureText(text).X)/2, r.Y+(r.H-lh)/2, text, color)
+	}
+
+	// Month header
+	for _, arrow := range []struct {
+		text   string
+		rect   Rect
+		months int
+	}{
+		{"<", Rect{X: x0, Y: y, W: cellW, H: cellH}, -1},
+		{">", Rect{X: x0 + cellW*6, Y: y, W: cellW, H: cellH}, 1},
+	} {
+		hovered, _, clicked := ctx.buttonBehavior(ctx.GetID(arrow.text), arrow.rect, false, false)
+		if hovered {
+			fg.AddRect(arrow.rect.X, arrow.rect.Y, arrow.rect.W, arrow.rect.H, ctx.style.HoveredBgColor)
+		}
+		centerText(arrow.rect, arrow.text, ctx.style.TextColor)
+		if clicked {
+			changeMonth(arrow.months)
+		}
+	}
+	centerText(Rect{X: x0 + cellW, Y: y, W: cellW * 5, H: cellH}, state.Month.Format("January 2006"), ctx.style.TextColor)
+	y += cellH
+
+	// Weekday headers
+	headerText := ctx.style.HeaderTextColor
+	if headerText == 0 {
+		headerText = ctx.style.TextColor
+	}
+	fg.AddRect(x0, y, cellW*7, cellH, ctx.style.HeaderBgColor)
+	for i, name := range datePickerWeekdays {
+		centerText(Rect{X: x0 + cellW*float32(i), Y: y, W: cellW, H: cellH}, name, headerText)
+	}
+	y += cellH
+
+	// Days, starting in the column of the month's first weekday
+	today := dateOnly(time.Now().In(state.Month.Location()))
+	selected := dateOnly(*t)
+	offset := int(state.Month.Weekday())
+	days := state.Month.AddDate(0, 1, -1).Day()
+	for d := 1; d <= days; d++ {
+		day := state.Month.AddDate(0, 0, d-1)
+		cell := Rect{X: x0 + cellW*float32((offset+d-1)%7), Y: y + cellH*float32((offset+d-1)/7), W: cellW, H: cellH}
+		hovered, _, clicked := ctx.buttonBehavior(ctx.GetID(day.Format("2006-01-02")), cell, false, false)
+
+		textColor := ctx.style.TextColor
+		switch {
+		case day.Equal(selected):
+			fg.AddRect(cell.X, cell.Y, cell.W, cell.H, ctx.style.SelectedBgColor)
+			textColor = ctx.style.SelectedTextColor
+		case hovered || day.Equal(state.Cursor):
+			fg.AddRect(cell.X, cell.Y, cell.W, cell.H, ctx.style.HoveredBgColor)
+		}
+		if day.Equal(today) {
+			fg.AddRectOutline(cell.X, cell.Y, cell.W, cell.H, ctx.style.FocusColor, 1)
+		}
+		if day.Equal(state.Cursor) && ctx.DebugFocusHighlight {
+			fg.AddRectOutline(cell.X, cell.Y, cell.W, cell.H, DebugFocusBorderColor, 3)
+		}
+		centerText(cell, day.Format("2"), textColor)
+		if clicked {
+			pick(day)
+		}
+	}
+
+	if input := ctx.Input; input != nil && state.Open {
+		switch {
+		case input.KeyRepeated(KeyLeft):
+			moveCursor(-1)
+		case input.KeyRepeated(KeyRight):
+			moveCursor(1)
+		case input.KeyRepeated(KeyUp):
+			moveCursor(-7)
+		case input.KeyRepeated(KeyDown):
+			moveCursor(7)
+		case input.KeyRepeated(KeyPageUp):
+			changeMonth(-1)
+		case input.KeyRepeated(KeyPageDown):
+			changeMonth(1)
+		case input.KeyPressed(KeyEnter) && !justOpened:
+			pick(state.Cursor)
+		}
+
+		// Close on a click outside the field and the calendar
+		if input.MouseClicked(MouseButtonLeft) && !justOpened {
+			mouse := Vec2{input.MouseX, input.MouseY}
+			if !popup.Contains(mouse) && !field.Contains(mouse) {
+				state.Open = false
+			}
+		}
+	}
+	if state.Open && escape {
+		if ctx.escapeFor(escapePopup, id) {
+			state.Open = false
+		} else {
+			ctx.claimEscape(escapePopup, id)
+		}
+	}
+
+	ctx.inPopup = savedInPopup
+	if state.Open {
+		ctx.registerPopupRect(id, popup)
+	} else if ctx.ActivePopupID() == id {
+		ctx.SetActivePopup(0)
+	}
+	return changed
+}
+
+// dateOnly returns midnight of t's date in t's location.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// firstOfMonth returns midnight of the first day of t's month.
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,111 @@
+package gui
+
+// editableLabelStore is the type-safe store for EditableLabel state.
+var editableLabelStore = NewFrameStore[EditableLabelState]()
+
+// editableLabelMargin is extra width after the text so the caret fits.
+const editableLabelMargin = 8
+
+// EditableLabel draws text that turns into an InputText when clicked
+// (or when Enter is pressed while focused), for rename-in-place.
+// Enter or clicking elsewhere commits the edit; Escape reverts it.
+// Returns true when an edit is committed with a changed value.
+//
+// Both modes occupy the same height and place the text at the same
+// position, so switching doesn't shift the layout. The edit box tracks
+// the text width plus a small margin.
+//
+// Usage:
+//
+//	if ctx.EditableLabel("layer_name", &layer.Name) {
+//	    renameLayer(layer)
+//	}
+func (ctx *Context) EditableLabel(id string, value *string, opts ...Option) bool {
+	o := applyOptions(opts)
+	wid := ctx.GetID(id)
+	state := editableLabelStore.GetFor(ctx, wid, EditableLabelState{})
+
+	h := ctx.lineHeight() + ctx.style.InputPadding*2
+	committed := false
+
+	// Clicking outside the edit box commits, like losing focus
+	if state.Editing && ctx.Input != nil && ctx.Input.MouseClicked(MouseButtonLeft) && !ctx.isHovered(wid, state.Rect) {
+		state.Editing = false
+		if state.Buffer != *value {
+			*value = state.Buffer
+			committed = true
+		}
+		inputState := GetState(ctx, wid, InputTextState{})
+		inputState.Editing = false
+		SetState(ctx, wid, inputState)
+	}
+
+	if state.Editing {
+		escape := ctx.Input != nil && ctx.escapeTargets(escapeText, wid)
+		w := ctx.MeasureText(state.Buffer).X + ctx.style.InputPadding*2 + editableLabelMargin
+		if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
+			w = optWidth
+		}
+
+		// Peek where InputText will be placed (ItemPos applies the layout gap)
+		saved := ctx.cursor
+		pos := ctx.ItemPos()
+		ctx.cursor = saved
+		state.Rect = Rect{X: pos.X, Y: pos.Y, W: w, H: h}
+
+		ctx.inputText(wid, "", &state.Buffer, applyOptions([]Option{WithWidth(w), WithOpt(OptNoEscape, GetOpt(o, OptNoEscape))}))
+
+		// InputText leaves edit mode on Enter, Escape, or focus loss
+		if !GetState(ctx, wid, InputTextState{}).Editing {
+			state.Editing = false
+			if !escape && state.Buffer != *value {
+				*value = state.Buffer
+				committed = true
+			}
+		}
+		return committed
+	}
+
+	pos := ctx.ItemPos()
+	w := ctx.MeasureText(*value).X + ctx.style.InputPadding*2 + editableLabelMargin
+	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
+		w = optWidth
+	}
+	rect := Rect{X: pos.X, Y: pos.Y, W: w, H: h}
+	state.Rect = rect
+
+	disabled := GetOpt(o, OptDisabled)
+	if disabled {
+		ctx.RegisterFocusableDisabled(wid, id, rect, FocusTypeLeaf)
+	} else {
+		ctx.RegisterFocusable(wid, id, rect, FocusTypeLeaf)
+	}
+
+	hovered, _, clicked := ctx.buttonBehavior(wid, rect, false, disabled)
+	focused := ctx.IsRegistryFocused(wid)
+
+	if hovered && !disabled {
+		ctx.DrawList.AddRect(pos.X, pos.Y, w, h, ctx.style.HoveredBgColor)
+	}
+
+	textColor := ctx.style.TextColor
+	if disabled {
+		textColor = ctx.style.TextDisabledColor
+	}
+	ctx.addText(pos.X+ctx.style.InputPadding, pos.Y+ctx.style.InputPadding, *value, textColor)
+
+	// Activate: start editing with all text selected
+	enter := focused && ctx.Input != nil && ctx.Input.KeyPressed(KeyEnter)
+	if !disabled && (clicked || enter) {
+		state.Editing = true
+		state.Buffer = *value
+		n := len([]rune(*value))
+		inputState := InputTextState{Editing: true, SelectionStart: -1, SelectionEnd: -1}
+		inputState.SelectAll(n)
+		SetState(ctx, wid, inputState)
+		ctx.SetRegistryFocus(wid)
+	}
+
+	ctx.advanceCursor(Vec2{w, h})
+	return committed
+}
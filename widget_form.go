@@ -0,0 +1,185 @@
+package gui
+
+import "fmt"
+
+// formStore is the type-safe store for Form state.
+var formStore = NewFrameStore[FormState]()
+
+// FormBuilder lays out a form field by field and gathers validation errors
+// as it goes. Create one with Context.Form each frame, add fields, and finish
+// with Submit.
+type FormBuilder struct {
+	ctx    *Context
+	id     ID
+	state  *FormState
+	errors []formError
+}
+
+// formError is a failed validation, in field order.
+type formError struct {
+	label string
+	err   error
+}
+
+// Form starts a form: a column of validated fields ending in a submit
+// button. Each field method takes a validator (nil = always valid) that
+// returns an error describing why the value is rejected. A field's error is
+// shown beneath it, with the widget's WithError styling, once the user has
+// changed it. Submit is disabled until every field validates, and updates
+// live as values change.
+//
+// The fields form a focus group: while one of them is focused, Tab and
+// Shift+Tab cycle through the form in field order, and tabbing into a text
+// field starts editing it.
+//
+// Submit must be called last; it closes the form's ID scope and focus group.
+//
+// Usage:
+//
+//	form := ctx.Form("signup")
+//	form.Text("Name", &name, required)
+//	form.Int("Age", &age, func(v int) error {
+//	    if v < 18 {
+//	        return errors.New("must be 18 or over")
+//	    }
+//	    return nil
+//	})
+//	form.Summary()
+//	if form.Submit("Create account") {
+//	    createAccount(name, age)
+//	}
+func (ctx *Context) Form(id string) *FormBuilder {
+	ctx.PushID(id)
+	fid := ctx.CurrentID()
+	ctx.BeginFocusGroup(fid, id, Rect{})
+	return &FormBuilder{
+		ctx:   ctx,
+		id:    fid,
+		state: formStore.GetFor(ctx, fid, FormState{}),
+	}
+}
+
+// inline returns the options to draw a field with: WithError is added once
+// the user has touched the field and while its value fails validation.
+func (f *FormBuilder) inline(label string, err error, opts []Option) []Option {
+	if err == nil || !f.state.Touched[label] {
+		return opts
+	}
+	return append(append([]Option(nil), opts...), WithError(err.Error()))
+}
+
+// record notes a field's validation result after it is drawn (so edits made
+// this frame count) and marks it touched when the user changed it.
+func (f *FormBuilder) record(label string, changed bool, err error) bool {
+	if changed {
+		if f.state.Touched == nil {
+			f.state.Touched = make(map[string]bool)
+		}
+		f.state.Touched[label] = true
+	}
+	if err != nil {
+		f.errors = append(f.errors, formError{label: label, err: err})
+	}
+	return changed
+}
+
+// validate runs a possibly nil validator.
+func validate[T any](fn func(T) error, v T) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(v)
+}
+
+// Text adds an InputText field. Returns true if the value changed.
+func (f *FormBuilder) Text(label string, value *string, validator func(string) error, opts ...Option) bool {
+	ctx := f.ctx
+	opts = f.inline(label, validate(validator, *value), opts)
+	id := ctx.GetID(label)
+
+	// Tabbing into a text field starts editing it
+	if f.state.TabbedTo == id && ctx.IsRegistryFocused(id) {
+		f.state.TabbedTo = 0
+		opts = append(append([]Option(nil), opts...), ForceFocus())
+	}
+
+	changed := ctx.inputText(id, label, value, applyOptions(opts))
+	return f.record(label, changed, validate(validator, *value))
+}
+
+// Float adds a NumberInputFloat field. Returns true if the value changed.
+func (f *FormBuilder) Float(label string, value *float32, validator func(float32) error, opts ...Option) bool {
+	opts = f.inline(label, validate(validator, *value), opts)
+	changed := f.ctx.NumberInputFloat(label, value, opts...)
+	return f.record(label, changed, validate(validator, *value))
+}
+
+// Int adds a NumberInputInt field. Returns true if the value changed.
+func (f *FormBuilder) Int(label string, value *int, validator func(int) error, opts ...Option) bool {
+	opts = f.inline(label, validate(validator, *value), opts)
+	changed := f.ctx.NumberInputInt(label, value, opts...)
+	return f.record(label, changed, validate(validator, *value))
+}
+
+// Select adds a ComboBox field choosing an index into items.
+// Returns true if the selection changed.
+func (f *FormBuilder) Select(label string, selected *int, items []string, validator func(int) error, opts ...Option) bool {
+	opts = f.inline(label, validate(validator, *selected), opts)
+	changed := f.ctx.ComboBox(label, selected, items, opts...)
+	return f.record(label, changed, validate(validator, *selected))
+}
+
+// Checkbox adds a Checkbox field (e.g. "I accept the terms").
+// Checkboxes have no inline error; their errors appear in Summary.
+// Returns true if the value changed.
+func (f *FormBuilder) Checkbox(label string, value *bool, validator func(bool) error, opts ...Option) bool {
+	changed := f.ctx.Checkbox(label, value, opts...)
+	return f.record(label, changed, validate(validator, *value))
+}
+
+// Valid reports whether every field added so far validates.
+func (f *FormBuilder) Valid() bool {
+	return len(f.errors) == 0
+}
+
+// Errors returns the validation errors of the fields added so far, as
+// "Label: message", including fields the user hasn't touched yet.
+func (f *FormBuilder) Errors() []string {
+	msgs := make([]string, len(f.errors))
+	for i, e := range f.errors {
+		msgs[i] = fmt.Sprintf("%s: %v", e.label, e.err)
+	}
+	return msgs
+}
+
+// Summary draws the errors of the fields the user has touched, one per
+// line in the style's error color. Draws nothing when there are none.
+func (f *FormBuilder) Summary() {
+	color := f.ctx.errorColor()
+	for _, e := range f.errors {
+		if f.state.Touched[e.label] {
+			f.ctx.TextColored(fmt.Sprintf("%s: %v", e.label, e.err), color)
+		}
+	}
+}
+
+// Submit draws the form's submit button and ends the form. The button is
+// disabled while any field fails validation; it returns true when clicked
+// with every field valid.
+func (f *FormBuilder) Submit(label string, opts ...Option) bool {
+	ctx := f.ctx
+	valid := f.Valid()
+	clicked := ctx.Button(label, append(append([]Option(nil), opts...), WithDisabled(!valid))...)
+
+	scope := ctx.EndFocusGroup()
+	ctx.PopID()
+
+	// Tab cycles through the form while focus is inside it
+	if ctx.Input != nil && scope.FocusedChild >= 0 && ctx.Input.KeyPressed(KeyTab) && !ctx.tabConsumed && ctx.focusRegistry != nil {
+		if ctx.focusRegistry.NavigateTabWithin(f.id, !ctx.Input.ModShift) {
+			f.state.TabbedTo = ctx.focusRegistry.CurrentFocusID()
+		}
+	}
+
+	return clicked && valid
+}
@@ -119,7 +119,7 @@ func (ctx *Context) Graph(id string, data []GraphData, height float32, opts ...O
 	graphRect := Rect{X: pos.X, Y: pos.Y, W: w, H: height}
 	state.HoveredIndex = -1
 
-	if ctx.Input != nil && graphRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY}) {
+	if ctx.isHovered(graphID, graphRect) {
 		// Calculate which data index is hovered
 		relX := ctx.Input.MouseX - pos.X
 		idx := int(relX/w*float32(maxLen-1) + 0.5)
@@ -163,7 +163,7 @@ func (ctx *Context) Graph(id string, data []GraphData, height float32, opts ...O
 	ctx.addText(pos.X+2, pos.Y+height-ctx.lineHeight()-2, fmt.Sprintf("%.1f", yMin), labelColor)
 
 	// Draw border
-	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.BorderColor, 1)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.BorderColor, ctx.style.BorderSize)
 
 	// Save state
 	SetState(ctx, graphID, state)
@@ -198,7 +198,7 @@ func (ctx *Context) drawGraphTooltip(x, y float32, lines []string) {
 
 	// Draw background
 	ctx.DrawList.AddRect(x, y, tooltipW, tooltipH, ctx.style.PanelColor)
-	ctx.DrawList.AddRectOutline(x, y, tooltipW, tooltipH, ctx.style.PanelBorderColor, 1)
+	ctx.DrawList.AddRectOutline(x, y, tooltipW, tooltipH, ctx.style.PanelBorderColor, ctx.style.BorderSize)
 
 	// Draw text
 	textY := y + padding
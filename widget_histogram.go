@@ -103,7 +103,7 @@ func (ctx *Context) Histogram(id string, bars []HistogramBar, height float32, op
 
 			// Check hover
 			barRect := Rect{X: pos.X, Y: barY, W: w, H: barHeight}
-			if ctx.Input != nil && barRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY}) {
+			if ctx.isHovered(histID, barRect) {
 				state.HoveredBar = i
 				// Brighten on hover
 				r, g, b, a := UnpackRGBA(barColor)
@@ -139,7 +139,7 @@ func (ctx *Context) Histogram(id string, bars []HistogramBar, height float32, op
 
 			// Check hover
 			barRect := Rect{X: barX, Y: barY, W: barWidth, H: barH}
-			if ctx.Input != nil && barRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY}) {
+			if ctx.isHovered(histID, barRect) {
 				state.HoveredBar = i
 				// Brighten on hover
 				r, g, b, a := UnpackRGBA(barColor)
@@ -175,7 +175,7 @@ func (ctx *Context) Histogram(id string, bars []HistogramBar, height float32, op
 	}
 
 	// Draw border
-	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.BorderColor, 1)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.BorderColor, ctx.style.BorderSize)
 
 	// Save state
 	SetState(ctx, histID, state)
@@ -200,7 +200,7 @@ func (ctx *Context) drawHistogramTooltip(x, y float32, text string) {
 
 	// Draw background
 	ctx.DrawList.AddRect(x, y, tooltipW, tooltipH, ctx.style.PanelColor)
-	ctx.DrawList.AddRectOutline(x, y, tooltipW, tooltipH, ctx.style.PanelBorderColor, 1)
+	ctx.DrawList.AddRectOutline(x, y, tooltipW, tooltipH, ctx.style.PanelBorderColor, ctx.style.BorderSize)
 
 	// Draw text
 	ctx.addText(x+padding, y+padding, text, ctx.style.TextColor)
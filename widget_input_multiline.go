@@ -0,0 +1,429 @@
+package gui
+
+// editWrapStore caches each multiline field's wrapped lines.
+var editWrapStore = NewFrameStore[editWrapCache]()
+
+// editWrapCache is a multiline field's last wrap, reused while its text,
+// box width and font scale are unchanged.
+type editWrapCache struct {
+	text         string
+	width, scale float32
+	gen          uint64 // Context.textCacheGen when wrapped
+	lines        []editLine
+	textW        float32 // Width the lines were wrapped to
+}
+
+// editLine is one visual line of a multiline text field: runes [start, end)
+// of the text. A soft-wrapped line keeps the space it broke at; a hard line
+// ends before its '\n'.
+type editLine struct {
+	start, end int
+}
+
+// wrapEditLines splits runes into visual lines no wider than maxWidth,
+// breaking at '\n' and, within a line, after the last space that fits
+// (or between characters when a word alone is too wide). Unlike WrapText
+// it keeps every rune, so cursor positions map back onto the text. There
+// is always at least one line.
+func (ctx *Context) wrapEditLines(runes []rune, maxWidth float32) []editLine {
+	var lines []editLine
+	start := 0
+	for {
+		hard := start
+		for hard < len(runes) && runes[hard] != '\n' {
+			hard++
+		}
+		for s := start; ; {
+			e := ctx.fitRunes(runes, s, hard, maxWidth)
+			if e >= hard {
+				lines = append(lines, editLine{s, hard})
+				break
+			}
+			lines = append(lines, editLine{s, e})
+			s = e
+		}
+		if hard == len(runes) {
+			return lines
+		}
+		start = hard + 1
+	}
+}
+
+// fitRunes returns where a line starting at s should soft-wrap before end:
+// after the widest prefix that fits maxWidth (at least one rune), moved
+// back to just after the last space when there is one. Returns end if
+// the whole rest fits.
+func (ctx *Context) fitRunes(runes []rune, s, end int, maxWidth float32) int {
+	if s >= end || ctx.MeasureText(string(runes[s:end])).X <= maxWidth {
+		return end
+	}
+	// Binary search for the widest fitting prefix
+	lo, hi := s+1, end
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if ctx.MeasureText(string(runes[s:mid])).X <= maxWidth {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	// A space at the break point hangs at the end of the line
+	if runes[lo] == ' ' {
+		return lo + 1
+	}
+	for k := lo - 1; k > s; k-- {
+		if runes[k] == ' ' {
+			return k + 1
+		}
+	}
+	return lo
+}
+
+// editLineAt returns the visual line holding cursor position pos. A
+// position at a soft wrap belongs to the start of the following line.
+func editLineAt(lines []editLine, pos int) int {
+	for i := len(lines) - 1; i > 0; i-- {
+		if pos >= lines[i].start {
+			return i
+		}
+	}
+	return 0
+}
+
+// editLineEnd returns the last cursor position on visual line i: before
+// the hanging space of a soft-wrapped line, so the caret stays on it.
+func editLineEnd(lines []editLine, i int) int {
+	if i+1 < len(lines) && lines[i+1].start == lines[i].end && lines[i].end > lines[i].start {
+		return lines[i].end - 1
+	}
+	return lines[i].end
+}
+
+// editPosAtX returns the cursor position on visual line i closest to x
+// (relative to the line start).
+func (ctx *Context) editPosAtX(runes []rune, lines []editLine, i int, x float32) int {
+	line := lines[i]
+	last := editLineEnd(lines, i)
+	pos := line.start
+	for p := line.start + 1; p <= last; p++ {
+		left := ctx.MeasureText(string(runes[line.start : p-1])).X
+		right := ctx.MeasureText(string(runes[line.start:p])).X
+		if x < (left+right)/2 {
+			break
+		}
+		pos = p
+	}
+	return pos
+}
+
+// InputTextMultilineHeight is InputTextMultiline with the box height as an
+// argument, for callers that always size the box themselves.
+//
+// Usage:
+//
+//	ctx.InputTextMultilineHeight("Notes", &notes, 120)
+func (ctx *Context) InputTextMultilineHeight(label string, value *string, height float32, opts ...Option) bool {
+	opts = append(append([]Option(nil), opts...), WithHeight(height))
+	return ctx.InputTextMultiline(label, value, opts...)
+}
+
+// InputTextMultiline draws a multi-line text editor. Text wraps at word
+// boundaries within the box and scrolls vertically, with a draggable
+// scrollbar when it overflows. Dragging the mouse selects text across
+// lines. Enter inserts a newline; Ctrl+Enter or Escape finishes
+// editing. Up/Down move between visual lines, Home/End go to the start or
+// end of the visual line (Ctrl for the whole text), and the InputText
+// editing keys (selection, clipboard, undo) work as usual. The box is four
+// lines tall unless WithHeight is given. Returns true if the value changed.
+//
+// Usage:
+//
+//	ctx.InputTextMultiline("Notes", &notes, gui.WithHeight(120))
+func (ctx *Context) InputTextMultiline(label string, value *string, opts ...Option) bool {
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+
+	pos := ctx.ItemPos()
+	state := GetState(ctx, id, InputTextState{
+		CursorPos:      len([]rune(*value)),
+		SelectionStart: -1,
+		SelectionEnd:   -1,
+	})
+
+	justStartedEditing := false
+	if GetOpt(o, OptForceFocus) && !state.Editing {
+		state.Editing = true
+		justStartedEditing = true
+	}
+
+	// Label, then the box
+	drawX := pos.X
+	startX := pos.X
+	if label != "" {
+		ctx.addText(drawX, pos.Y, label, ctx.style.TextColor)
+		drawX += ctx.MeasureText(label).X + ctx.style.ItemSpacing
+	}
+
+	pad := ctx.style.InputPadding
+	lineH := ctx.lineHeight()
+	w := ctx.itemWidth(o, 200, drawX-startX)
+	h := GetOpt(o, OptHeight)
+	if h <= 0 {
+		h = lineH*4 + pad*2
+	}
+	rect := Rect{X: drawX, Y: pos.Y, W: w, H: h}
+
+	focusable := ctx.RegisterFocusable(id, label, rect, FocusTypeLeaf)
+	isRegistryFocused := focusable != nil && focusable.IsFocused()
+
+	// Enter to start editing when registry-focused but not in edit mode
+	if isRegistryFocused && !state.Editing && ctx.Input != nil && ctx.Input.KeyPressed(KeyEnter) {
+		state.Editing = true
+		justStartedEditing = true
+		state.CursorBlinkTime = 0
+	}
+
+	textX := drawX + pad
+	textY := pos.Y + pad
+	viewH := h - pad*2
+
+	runes := []rune(*value)
+	state.CursorPos = min(max(state.CursorPos, 0), len(runes))
+
+	// Wrap to the box, narrowing for the scrollbar when the text overflows.
+	// The wrap is redone only when the text, width or font scale change, or
+	// InvalidateTextCache is called.
+	var lines []editLine
+	var textW float32
+	wrap := editWrapStore.GetFor(ctx, id, editWrapCache{})
+	layout := func() {
+		scale := ctx.style.FontScale
+		if wrap.lines != nil && wrap.text == *value && wrap.width == w && wrap.scale == scale &&
+			wrap.gen == ctx.textCacheGen {
+			lines, textW = wrap.lines, wrap.textW
+			return
+		}
+		textW = w - pad*2
+		lines = ctx.wrapEditLines(runes, textW)
+		if float32(len(lines))*lineH > viewH {
+			textW -= ctx.style.ScrollbarSize + 2
+			lines = ctx.wrapEditLines(runes, textW)
+		}
+		*wrap = editWrapCache{text: *value, width: w, scale: scale, gen: ctx.textCacheGen, lines: lines, textW: textW}
+	}
+	layout()
+	prevPos := state.CursorPos
+
+	// posAt returns the cursor position under the mouse
+	posAt := func() int {
+		row := int((ctx.Input.MouseY - textY + state.ScrollY) / lineH)
+		row = min(max(row, 0), len(lines)-1)
+		return ctx.editPosAtX(runes, lines, row, ctx.Input.MouseX-textX)
+	}
+
+	// Click to enter edit mode and place the cursor (clicks on the
+	// scrollbar don't); dragging selects from there
+	textRect := rect
+	if textW < w-pad*2 {
+		textRect.W -= ctx.style.ScrollbarSize + 2
+	}
+	if ctx.isClicked(id, textRect) {
+		state.Editing = true
+		state.CursorBlinkTime = 0
+		state.CursorPos = posAt()
+		state.ClearSelection()
+		state.dragClicks = 1
+		state.dragStart = state.CursorPos
+	} else if state.dragClicks > 0 {
+		if ctx.Input == nil || !ctx.Input.MouseDown(MouseButtonLeft) {
+			state.dragClicks = 0
+		} else {
+			state.CursorPos = posAt()
+			state.SelectionStart, state.SelectionEnd = state.dragStart, state.CursorPos
+			if !state.HasSelection() {
+				state.ClearSelection()
+			}
+		}
+	}
+
+	// Exit edit mode if registry focus moved to a different widget
+	if state.Editing && !isRegistryFocused {
+		state.Editing = false
+	}
+
+	changed := false
+	vertical := false
+	if state.Editing && ctx.Input != nil {
+		ctx.WantCaptureKeyboard = true
+
+		if !justStartedEditing {
+			input := ctx.Input
+			moveTo := func(p int) {
+				if input.ModShift {
+					if state.SelectionStart < 0 {
+						state.SelectionStart = state.CursorPos
+					}
+					state.SelectionEnd = p
+				} else {
+					state.ClearSelection()
+				}
+				state.CursorPos = p
+				state.CursorBlinkTime = 0
+			}
+
+			// Up/Down keep the caret's X across lines of different length
+			row := editLineAt(lines, state.CursorPos)
+			up, down := input.KeyRepeated(KeyUp), input.KeyRepeated(KeyDown)
+			if up || down {
+				if !state.hasPreferredX {
+					state.preferredX = ctx.MeasureText(string(runes[lines[row].start:state.CursorPos])).X
+					state.hasPreferredX = true
+				}
+				switch {
+				case up && row > 0:
+					moveTo(ctx.editPosAtX(runes, lines, row-1, state.preferredX))
+				case up:
+					moveTo(0)
+				case down && row+1 < len(lines):
+					moveTo(ctx.editPosAtX(runes, lines, row+1, state.preferredX))
+				default:
+					moveTo(len(runes))
+				}
+				vertical = true
+			}
+
+			// Home/End work on the visual line; with Ctrl, on the whole text
+			if input.KeyPressed(KeyHome) {
+				if input.ModCtrl {
+					moveTo(0)
+				} else {
+					moveTo(lines[row].start)
+				}
+			}
+			if input.KeyPressed(KeyEnd) {
+				if input.ModCtrl {
+					moveTo(len(runes))
+				} else {
+					moveTo(editLineEnd(lines, row))
+				}
+			}
+
+			// Enter inserts a newline; Ctrl+Enter finishes editing
+			if input.KeyPressed(KeyEnter) {
+				if input.ModCtrl {
+					state.Editing = false
+				} else if maxLen := GetOpt(o, OptMaxLength); maxLen == 0 || len(runes) < maxLen || state.HasSelection() {
+					state.PushUndo(*value)
+					if state.HasSelection() {
+						start, end := state.GetSelectedRange()
+						runes = append(runes[:start], runes[end:]...)
+						state.CursorPos = start
+						state.ClearSelection()
+					}
+					runes = append(runes[:state.CursorPos], append([]rune{'\n'}, runes[state.CursorPos:]...)...)
+					*value = string(runes)
+					state.CursorPos++
+					changed = true
+				}
+			}
+
+			escape := !GetOpt(o, OptNoEscape) && ctx.escapeFor(escapeText, id)
+			if state.Editing && ctx.processInputTextKeyboard(value, &state, &runes, escape, false, true, o) {
+				changed = true
+			}
+		}
+	}
+	if state.Editing && !GetOpt(o, OptNoEscape) {
+		ctx.claimEscape(escapeText, id)
+	}
+	if !vertical && (state.CursorPos != prevPos || changed) {
+		state.hasPreferredX = false
+	}
+	if changed {
+		layout()
+	}
+
+	// Track the cursor's visual position and keep it in view when it moves
+	row := editLineAt(lines, state.CursorPos)
+	state.CursorRow = row
+	state.CursorCol = state.CursorPos - lines[row].start
+	contentH := float32(len(lines)) * lineH
+	maxScroll := maxf(0, contentH-viewH)
+	if state.CursorPos != prevPos || changed {
+		caretY := float32(row) * lineH
+		if caretY < state.ScrollY {
+			state.ScrollY = caretY
+		}
+		if caretY+lineH > state.ScrollY+viewH {
+			state.ScrollY = caretY + lineH - viewH
+		}
+	}
+	if ctx.Input != nil && ctx.Input.MouseWheelY != 0 && ctx.isHovered(id, rect) {
+		scroll, _ := ctx.wheelScroll(ctx.Input.MouseWheelY)
+		state.ScrollY -= scroll
+	}
+	state.ScrollY = clampf(state.ScrollY, 0, maxScroll)
+
+	// Draw background
+	bgColor := ctx.style.InputBgColor
+	if state.Editing {
+		bgColor = ctx.style.InputFocusedBgColor
+	}
+	ctx.DrawList.AddRect(drawX, pos.Y, w, h, bgColor)
+	ctx.DrawList.AddRectOutline(drawX, pos.Y, w, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
+
+	// Scrollbar, handled before the text is drawn at the new offset
+	track := Rect{X: drawX + w - ctx.style.ScrollbarSize - 2, Y: pos.Y, W: ctx.style.ScrollbarSize, H: h}
+	ctx.scrollbarV(id, track, viewH, contentH, &state.ScrollY, &state.scrollDragging, &state.scrollDragStartY, &state.scrollDragStartScroll)
+
+	// Draw the visible lines, with one selection rect per visual line
+	ctx.pushClipRect(Rect{X: textX, Y: pos.Y, W: textW, H: h})
+	selStart, selEnd := state.GetSelectedRange()
+	hasSel := state.Editing && state.HasSelection()
+	first := int(state.ScrollY / lineH)
+	last := min(len(lines), int((state.ScrollY+viewH)/lineH)+1)
+	for i := first; i < last; i++ {
+		line := lines[i]
+		y := textY + float32(i)*lineH - state.ScrollY
+		if hasSel {
+			a, b := max(selStart, line.start), min(selEnd, line.end)
+			// A selected newline shows as a space-wide block at the line end
+			newline := line.end < len(runes) && runes[line.end] == '\n' && selStart <= line.end && selEnd > line.end
+			if a < b || newline {
+				x1 := ctx.MeasureText(string(runes[line.start:a])).X
+				x2 := ctx.MeasureText(string(runes[line.start:max(a, b)])).X
+				if newline {
+					x2 += ctx.MeasureText(" ").X
+				}
+				ctx.DrawList.AddRect(textX+x1, y, x2-x1, lineH, ctx.style.SelectionColor)
+			}
+		}
+		ctx.addText(textX, y, string(runes[line.start:line.end]), ctx.style.TextColor)
+	}
+
+	// Draw cursor when in edit mode
+	if state.Editing {
+		state.CursorBlinkTime += ctx.DeltaTime
+		if int(state.CursorBlinkTime*2)%2 == 0 {
+			caretX := textX + ctx.MeasureText(string(runes[lines[row].start:state.CursorPos])).X
+			caretY := textY + float32(row)*lineH - state.ScrollY
+			ctx.DrawList.AddLine(caretX, caretY, caretX, caretY+lineH, ctx.style.CaretColor, 1)
+		}
+	}
+	ctx.popClipRect()
+
+	SetState(ctx, id, state)
+
+	ctx.notifyChange(id, o, BuiltinComponents.InputText, label, *value, changed, state.Editing)
+
+	// Advance cursor (past the error message, if any)
+	h += ctx.drawFieldError(o, rect)
+	ctx.cursor.X = startX
+	ctx.advanceCursor(Vec2{w + (drawX - startX), h})
+
+	return changed
+}
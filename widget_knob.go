@@ -0,0 +1,165 @@
+package gui
+
+import "math"
+
+// knobStore is the type-safe store for knob state.
+var knobStore = NewFrameStore[KnobState]()
+
+const (
+	knobSegments    = 32             // Line segments in the full dial arc
+	knobSweep       = 1.5 * math.Pi  // The dial covers 270 degrees
+	knobStartAngle  = 0.75 * math.Pi // Minimum at the bottom left (clockwise on screen)
+	knobDragPixels  = float32(200)   // Default drag distance covering the whole range
+	knobArcWidth    = float32(3)     // Thickness of the dial arc
+	knobPointerSize = float32(2)     // Thickness of the value pointer
+)
+
+// Knob draws a circular dial for compact parameter tweaking, with the
+// formatted value and the label centered below it. Drag up to increase the
+// value and down to decrease it; WithDragSpeed sets the pixels per unit
+// (by default 200 pixels cover the range) and WithStep snaps the value.
+// The mouse wheel and, when focused, the arrow keys move it by WithStep
+// (1% of the range by default). Double-clicking resets it to the
+// WithDefault value. WithWidth sets the dial's diameter.
+// Returns true if the value changed.
+//
+// Usage:
+//
+//	if ctx.Knob("Cutoff", &cutoff, 20, 20000, gui.WithFormat("%.0f Hz"), gui.WithDefault(1000)) {
+//	    synth.SetCutoff(cutoff)
+//	}
+func (ctx *Context) Knob(label string, value *float32, min, max float32, opts ...Option) bool {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+	state := knobStore.GetFor(ctx, id, KnobState{})
+
+	diameter := ctx.lineHeight() * 2.5
+	if w := GetOpt(o, OptWidth); w > 0 {
+		diameter = w
+	}
+	format := GetOpt(o, OptFormat)
+	lh := ctx.lineHeight()
+	w := maxf(diameter, ctx.MeasureText(label).X)
+	dial := Rect{X: pos.X + (w-diameter)/2, Y: pos.Y, W: diameter, H: diameter}
+
+	focusable := ctx.RegisterFocusable(id, label, dial, FocusTypeLeaf)
+	isFocused := focusable != nil && focusable.IsFocused()
+	hovered, _, _ := ctx.buttonBehavior(id, dial, false, false)
+
+	changed := false
+	setValue := func(v float32) {
+		if step := GetOpt(o, OptStep); step > 0 {
+			v = min + float32(math.Round(float64((v-min)/step)))*step
+		}
+		v = clampf(v, min, max)
+		if v != *value {
+			*value = v
+			changed = true
+		}
+	}
+	step := GetOpt(o, OptStep)
+	if step == 0 {
+		step = (max - min) / 100
+	}
+
+	if input := ctx.Input; input != nil {
+		// Press starts a drag; a quick second press resets instead
+		if hovered && input.MouseClicked(MouseButtonLeft) {
+			doubleClick := input.MouseDoubleClicked(MouseButtonLeft)
+			if doubleClick && HasOpt(o, OptDefault) {
+				setValue(GetOpt(o, OptDefault))
+				state.Dragging = false
+			} else {
+				state.Dragging = true
+				state.DragStartValue = *value
+			}
+		}
+
+		if state.Dragging {
+			if input.MouseDown(MouseButtonLeft) {
+				// Only past the threshold, so clicks don't nudge the value
+				if ctx.IsMouseDragging(MouseButtonLeft, 0) {
+					speed := GetOpt(o, OptDragSpeed)
+					if speed == 0 {
+						speed = knobDragPixels / (max - min)
+					}
+					setValue(state.DragStartValue - ctx.MouseDragDelta(MouseButtonLeft).Y/speed)
+				}
+			} else {
+				state.Dragging = false
+			}
+		}
+
+		if hovered && input.MouseWheelY != 0 {
+			setValue(*value + input.MouseWheelY*step)
+		}
+		if isFocused {
+			if input.KeyRepeated(KeyUp) || input.KeyRepeated(KeyRight) {
+				setValue(*value + step)
+			}
+			if input.KeyRepeated(KeyDown) || input.KeyRepeated(KeyLeft) {
+				setValue(*value - step)
+			}
+		}
+	}
+
+	// Dial: the full arc as a track, the arc up to the value filled, and a
+	// pointer from the center
+	center := Vec2{X: dial.X + diameter/2, Y: dial.Y + diameter/2}
+	radius := diameter/2 - knobArcWidth
+	ratio := float32(0)
+	if max > min {
+		ratio = clampf((*value-min)/(max-min), 0, 1)
+	}
+	pointerColor := ctx.style.SliderGrabColor
+	switch {
+	case state.Dragging:
+		pointerColor = ctx.style.SliderGrabActive
+	case hovered || isFocused:
+		pointerColor = ctx.style.SliderGrabHovered
+	}
+	addKnobArc(ctx.DrawList, center, radius, 0, 1, ctx.style.SliderTrackColor)
+	addKnobArc(ctx.DrawList, center, radius, 0, ratio, ctx.style.SliderFillColor)
+	pointer := knobPoint(center, radius-knobArcWidth, ratio)
+	ctx.DrawList.AddLine(center.X, center.Y, pointer.X, pointer.Y, pointerColor, knobPointerSize)
+	if isFocused && ctx.DebugFocusHighlight {
+		ctx.DrawList.AddRectOutline(dial.X, dial.Y, dial.W, dial.H, DebugFocusBorderColor, 3)
+	}
+
+	// Value and label, centered under the dial
+	text := formatNumber(format, *value)
+	ctx.addText(pos.X+(w-ctx.MeasureText(text).X)/2, pos.Y+diameter, text, ctx.style.TextColor)
+	h := diameter + lh
+	if label != "" {
+		ctx.addText(pos.X+(w-ctx.MeasureText(label).X)/2, pos.Y+h, label, ctx.style.TextDisabledColor)
+		h += lh
+	}
+
+	ctx.notifyChange(id, o, BuiltinComponents.Knob, label, *value, changed, state.Dragging)
+	ctx.advanceCursor(Vec2{X: w, Y: h})
+	return changed
+}
+
+// knobPoint returns the point at distance r from center at fraction t of
+// the dial's sweep.
+func knobPoint(center Vec2, r, t float32) Vec2 {
+	a := knobStartAngle + float64(t)*knobSweep
+	return Vec2{X: center.X + r*float32(math.Cos(a)), Y: center.Y + r*float32(math.Sin(a))}
+}
+
+// addKnobArc draws the dial arc from fraction t0 to t1 of the sweep as
+// line segments.
+func addKnobArc(dl *DrawList, center Vec2, r, t0, t1 float32, color uint32) {
+	n := int(math.Ceil(float64((t1 - t0) * knobSegments)))
+	prev := knobPoint(center, r, t0)
+	for i := 1; i <= n; i++ {
+		p := knobPoint(center, r, t0+(t1-t0)*float32(i)/float32(n))
+		dl.AddLine(prev.X, prev.Y, p.X, p.Y, color, knobArcWidth)
+		prev = p
+	}
+}
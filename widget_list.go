@@ -1,6 +1,9 @@
 package gui
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 // listStore is the type-safe store for list state.
 // Uses the new FrameStore pattern instead of the old GetState/SetState.
@@ -17,6 +20,17 @@ type ListBuilder struct {
 	itemIndex       int
 	selectedItem    int
 	onSelectChanged func(int)
+	reorder         func(from, to int) // From WithReorderable
+	rect            Rect               // List bounds
+	itemCount       int                // Items added so far, shown or not
+	rows            []listRow          // Items drawn this frame, for drop hit-testing
+}
+
+// listRow is an item drawn in a List, with its bounds (including any
+// nested content).
+type listRow struct {
+	index int
+	rect  Rect
 }
 
 // SectionBuilder provides a fluent API for building list sections.
@@ -56,7 +70,7 @@ func (ctx *Context) List(id string, height float32, opts ...Option) *ListBuilder
 
 	scrollID := ctx.GetID(id + "_list")
 	// Use the new type-safe store instead of GetState
-	state := listStore.Get(scrollID, ListState{
+	state := listStore.GetFor(ctx, scrollID, ListState{
 		CollapsedSections: make(map[string]bool),
 		SelectedIndex:     -1,
 	})
@@ -69,6 +83,7 @@ func (ctx *Context) List(id string, height float32, opts ...Option) *ListBuilder
 		state:        state,
 		scrollID:     scrollID,
 		selectedItem: -1,
+		reorder:      GetOpt(o, OptReorder),
 	}
 
 	// Start the list container
@@ -86,9 +101,11 @@ func (lb *ListBuilder) begin() {
 		w = width
 	}
 
+	lb.rect = Rect{X: pos.X, Y: pos.Y, W: w, H: lb.height}
+
 	// Draw list background
 	ctx.DrawList.AddRect(pos.X, pos.Y, w, lb.height, ctx.style.InputBgColor)
-	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, lb.height, ctx.style.InputBorderColor, 1)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, lb.height, ctx.style.InputBorderColor, ctx.style.BorderSize)
 
 	// Calculate content area
 	contentY := pos.Y
@@ -106,7 +123,7 @@ func (lb *ListBuilder) begin() {
 			H: ctx.lineHeight() + ctx.style.InputPadding*2,
 		}
 		ctx.DrawList.AddRect(filterRect.X, filterRect.Y, filterRect.W, filterRect.H, ctx.style.InputBgColor)
-		ctx.DrawList.AddRectOutline(filterRect.X, filterRect.Y, filterRect.W, filterRect.H, ctx.style.InputBorderColor, 1)
+		ctx.DrawList.AddRectOutline(filterRect.X, filterRect.Y, filterRect.W, filterRect.H, ctx.style.InputBorderColor, ctx.style.BorderSize)
 
 		// Handle filter input
 		filterID := ctx.GetID(lb.id + "_filter")
@@ -137,7 +154,7 @@ func (lb *ListBuilder) begin() {
 				if ctx.Input.KeyRepeated(KeyBackspace) && len(lb.state.SearchText) > 0 {
 					lb.state.SearchText = lb.state.SearchText[:len(lb.state.SearchText)-1]
 				}
-				if ctx.Input.KeyPressed(KeyEscape) || ctx.Input.KeyPressed(KeyEnter) {
+				if ctx.escapeFor(escapeText, filterID) || ctx.Input.KeyPressed(KeyEnter) {
 					lb.state.FilterEditing = false
 				}
 			}
@@ -146,6 +163,9 @@ func (lb *ListBuilder) begin() {
 			if lb.state.FilterEditing && !isRegistryFocused {
 				lb.state.FilterEditing = false
 			}
+			if lb.state.FilterEditing {
+				ctx.claimEscape(escapeText, filterID)
+			}
 		}
 
 		// Draw filter text
@@ -154,9 +174,9 @@ func (lb *ListBuilder) begin() {
 		if lb.state.SearchText != "" {
 			ctx.addText(textX, textY, lb.state.SearchText, ctx.style.TextColor)
 			// Draw cursor when in edit mode
-			if lb.state.FilterEditing && (ctx.FrameCount/30)%2 == 0 {
+			if lb.state.FilterEditing && int(ctx.Time*2)%2 == 0 {
 				cursorX := textX + ctx.MeasureText(lb.state.SearchText).X
-				ctx.DrawList.AddLine(cursorX, filterRect.Y+2, cursorX, filterRect.Y+filterRect.H-2, ctx.style.TextColor, 1)
+				ctx.DrawList.AddLine(cursorX, filterRect.Y+2, cursorX, filterRect.Y+filterRect.H-2, ctx.style.CaretColor, 1)
 			}
 		} else {
 			ctx.addText(textX, textY, filterPlaceholder, ctx.style.TextDisabledColor)
@@ -167,7 +187,7 @@ func (lb *ListBuilder) begin() {
 
 	// Push clip rect for scrollable content
 	scrollableHeight := lb.height - filterHeight
-	ctx.DrawList.PushClipRect(pos.X, contentY, pos.X+w, contentY+scrollableHeight)
+	ctx.pushClipRect(Rect{X: pos.X, Y: contentY, W: w, H: scrollableHeight})
 
 	// Set up cursor for content
 	ctx.cursor.X = pos.X + 2
@@ -200,6 +220,8 @@ func (sb *SectionBuilder) Item(label string, selected bool) *SectionBuilder {
 		sb.drawHeader()
 		sb.started = true
 	}
+	index := sb.list.itemCount
+	sb.list.itemCount++
 
 	if !sb.isOpen {
 		return sb
@@ -258,6 +280,8 @@ func (sb *SectionBuilder) Item(label string, selected bool) *SectionBuilder {
 	if ctx.isClicked(itemID, rect) {
 		lb.selectedItem = lb.itemIndex
 	}
+	lb.dragSource(index, label, rect)
+	lb.rows = append(lb.rows, listRow{index: index, rect: rect})
 
 	// Advance cursor
 	ctx.cursor.Y += h + ctx.style.ItemSpacing
@@ -272,6 +296,8 @@ func (sb *SectionBuilder) ItemFunc(label string, selected bool, content func())
 		sb.drawHeader()
 		sb.started = true
 	}
+	index := sb.list.itemCount
+	sb.list.itemCount++
 
 	if !sb.isOpen {
 		return sb
@@ -314,6 +340,7 @@ func (sb *SectionBuilder) ItemFunc(label string, selected bool, content func())
 		textColor = ctx.style.SelectedTextColor
 	}
 	ctx.addText(x+ctx.style.ItemSpacing, y, label, textColor)
+	lb.dragSource(index, label, rect)
 
 	ctx.cursor.Y += h + ctx.style.ItemSpacing
 
@@ -323,6 +350,7 @@ func (sb *SectionBuilder) ItemFunc(label string, selected bool, content func())
 	ctx.cursor.X -= ctx.style.ItemSpacing*2 + ctx.style.ItemSpacing*2
 
 	ctx.cursor.Y += ctx.style.ItemSpacing
+	lb.rows = append(lb.rows, listRow{index: index, rect: Rect{X: x, Y: y, W: w, H: ctx.cursor.Y - y}})
 
 	return sb
 }
@@ -354,7 +382,7 @@ func (sb *SectionBuilder) drawHeader() {
 	if ctx.isHovered(headerID, rect) {
 		bgColor = ctx.style.ButtonHoveredColor
 	}
-	ctx.DrawList.AddRect(x, y, w, h, bgColor)
+	ctx.fillRectV(x, y, w, h, bgColor, ctx.style.HeaderGradient)
 
 	// Draw collapse indicator
 	indicator := "v"
@@ -381,7 +409,11 @@ func (lb *ListBuilder) End() int {
 	ctx := lb.ctx
 
 	// Pop clip rect
-	ctx.DrawList.PopClipRect()
+	ctx.popClipRect()
+
+	if lb.reorder != nil {
+		lb.handleDrop()
+	}
 
 	// Calculate content bounds for scrolling
 	pos := ctx.GetCursorPos()
@@ -398,7 +430,8 @@ func (lb *ListBuilder) End() int {
 	if ctx.Input != nil && ctx.isHovered(lb.scrollID, listRect) {
 		if ctx.Input.MouseWheelY != 0 {
 			maxScroll := maxf(0, contentHeight-lb.height)
-			lb.state.ScrollY = clampf(lb.state.ScrollY-ctx.Input.MouseWheelY*30, 0, maxScroll)
+			scroll, _ := ctx.wheelScrollLines(ctx.Input.MouseWheelY, GetOpt(lb.opts, OptScrollLines), ctx.lineHeight())
+			lb.state.ScrollY = clampf(lb.state.ScrollY-scroll, 0, maxScroll)
 		}
 	}
 
@@ -451,3 +484,76 @@ func (lb *ListBuilder) OnSelect(callback func(int)) *ListBuilder {
 	lb.onSelectChanged = callback
 	return lb
 }
+
+// ItemRect returns the bounds of the item at index (counting every Item
+// and ItemFunc call in order) as drawn this frame, including an ItemFunc's
+// nested content. Returns false when the item was not drawn: it is in a
+// collapsed section, filtered out, or not added yet.
+func (lb *ListBuilder) ItemRect(index int) (Rect, bool) {
+	for _, row := range lb.rows {
+		if row.index == index {
+			return row.rect, true
+		}
+	}
+	return Rect{}, false
+}
+
+// dragKind is the drag-and-drop payload kind of this list's items.
+func (lb *ListBuilder) dragKind() string {
+	return "list_item:" + lb.id
+}
+
+// dragSource makes an item's row a drag source when the list is
+// reorderable, drawing the row following the mouse while it is dragged.
+func (lb *ListBuilder) dragSource(index int, label string, rect Rect) {
+	if lb.reorder == nil {
+		return
+	}
+	ctx := lb.ctx
+	ctx.lastItemRect = rect
+	if !ctx.BeginDragDropSource() {
+		return
+	}
+	ctx.SetDragDropPayload(lb.dragKind(), []byte(strconv.Itoa(index)))
+	y := rect.Y + ctx.MouseDragDelta(MouseButtonLeft).Y
+	ctx.DrawList.AddRect(rect.X, y, rect.W, rect.H, ctx.style.SelectedBgColor)
+	ctx.addText(rect.X+ctx.style.ItemSpacing, y, label, ctx.style.SelectedTextColor)
+	ctx.EndDragDropSource()
+}
+
+// handleDrop draws the insertion line while one of this list's items is
+// dragged over it, and reports the move when the item is dropped.
+func (lb *ListBuilder) handleDrop() {
+	ctx := lb.ctx
+	kind, active := ctx.IsDragDropActive()
+	if !active || kind != lb.dragKind() || len(lb.rows) == 0 || !ctx.isHovered(0, lb.rect) {
+		return
+	}
+	from, err := strconv.Atoi(string(ctx.dragDrop.data))
+	if err != nil {
+		return
+	}
+
+	// Insert before the first row whose middle is below the mouse
+	gap := ctx.style.ItemSpacing
+	last := lb.rows[len(lb.rows)-1]
+	to := last.index + 1
+	lineY := last.rect.Y + last.rect.H + gap/2
+	for _, row := range lb.rows {
+		if ctx.Input.MouseY < row.rect.Y+row.rect.H/2 {
+			to = row.index
+			lineY = row.rect.Y - gap/2
+			break
+		}
+	}
+	if to > from {
+		to-- // The item leaves its old slot first
+	}
+
+	lineY = clampf(lineY, lb.rect.Y+1, lb.rect.Y+lb.rect.H-1)
+	ctx.DrawList.AddLine(lb.rect.X+2, lineY, lb.rect.X+lb.rect.W-2, lineY, ctx.style.FocusColor, 2)
+
+	if !ctx.Input.MouseDown(MouseButtonLeft) && to != from {
+		lb.reorder(from, to)
+	}
+}
@@ -0,0 +1,171 @@
+package gui
+
+import "strings"
+
+// logViewStore is the type-safe store for log view state.
+var logViewStore = NewFrameStore[LogViewState]()
+
+// LogLine is one line of a LogView.
+type LogLine struct {
+	Text  string
+	Color uint32 // Text color (0 = Style.TextColor)
+}
+
+// LogView draws a scrollable, colored log that follows new lines. Only the
+// rows in view are drawn, so tens of thousands of lines stay cheap. The
+// view stays pinned to the bottom as lines are appended until the user
+// scrolls up, and pins again when scrolled back to the end (or End is
+// pressed). Click selects a line, Shift+click extends the selection, and
+// Ctrl+C copies it (Ctrl+A selects all). WithFilter adds a filter box
+// taking a TextFilter expression such as "error,warn" or "-debug".
+//
+// Lines are treated as append-only between frames: appended lines are
+// filtered incrementally, and a shorter slice rebuilds the filter.
+//
+// Usage:
+//
+//	ctx.LogView("console", logLines, gui.WithHeight(300), gui.WithFilter("Filter (error,-debug)"))
+func (ctx *Context) LogView(id string, lines []LogLine, opts ...Option) {
+	o := applyOptions(opts)
+	viewID := ctx.GetID(id)
+	state := logViewStore.GetFor(ctx, viewID, LogViewState{Pinned: true, Anchor: -1, Cursor: -1})
+
+	w := ctx.itemWidth(o, ctx.currentLayoutWidth(), 0)
+
+	// Filter box
+	if placeholder := GetOpt(o, OptFilterPlaceholder); placeholder != "" {
+		filterOpts := applyOptions([]Option{WithWidth(w), WithOpt(optPlaceholder, placeholder)})
+		ctx.inputText(ctx.GetID(id+"_filter"), "", &state.Filter.Text, filterOpts)
+	}
+
+	pos := ctx.ItemPos()
+	height := GetOpt(o, OptHeight)
+	if height <= 0 {
+		height = ctx.ContentRegionAvail().Y
+	}
+	if height <= 0 {
+		height = 200
+	}
+	rect := Rect{X: pos.X, Y: pos.Y, W: w, H: height}
+
+	// Indices of the lines passing the filter, updated incrementally
+	filtered := state.Filter.IsActive()
+	if state.visibleFor != state.Filter.Text || len(lines) < state.visibleUpTo {
+		state.visible = state.visible[:0]
+		state.visibleFor = state.Filter.Text
+		state.visibleUpTo = 0
+	}
+	if filtered {
+		for i := state.visibleUpTo; i < len(lines); i++ {
+			if state.Filter.PassFilter(lines[i].Text) {
+				state.visible = append(state.visible, i)
+			}
+		}
+		state.visibleUpTo = len(lines)
+	}
+	count := len(lines)
+	lineAt := func(row int) int { return row }
+	if filtered {
+		count = len(state.visible)
+		lineAt = func(row int) int { return state.visible[row] }
+	}
+	if state.Anchor >= len(lines) || state.Cursor >= len(lines) {
+		state.Anchor, state.Cursor = -1, -1
+	}
+
+	focusable := ctx.RegisterFocusable(viewID, id, rect, FocusTypeLeaf)
+	focused := focusable != nil && focusable.IsFocused()
+	hovered, _, clicked := ctx.buttonBehavior(viewID, rect, false, false)
+
+	rowH := ctx.lineHeight()
+	maxScroll := maxf(0, float32(count)*rowH-height)
+
+	if hovered && ctx.Input.MouseWheelY != 0 {
+		scroll, _ := ctx.wheelScrollLines(ctx.Input.MouseWheelY, GetOpt(o, OptScrollLines), rowH)
+		state.ScrollY = clampf(state.ScrollY-scroll, 0, maxScroll)
+		state.Pinned = state.ScrollY >= maxScroll
+	}
+
+	if clicked {
+		row := int((ctx.Input.MouseY - pos.Y + state.ScrollY) / rowH)
+		if row >= 0 && row < count {
+			state.Cursor = lineAt(row)
+			if !ctx.Input.ModShift || state.Anchor < 0 {
+				state.Anchor = state.Cursor
+			}
+		} else {
+			state.Anchor, state.Cursor = -1, -1
+		}
+	}
+
+	if focused && ctx.Input != nil {
+		input := ctx.Input
+		switch {
+		case input.ModCtrl && input.KeyPressed(KeyA) && count > 0:
+			state.Anchor, state.Cursor = lineAt(0), lineAt(count-1)
+		case input.ModCtrl && input.KeyPressed(KeyC) && state.Anchor >= 0:
+			lo, hi := min(state.Anchor, state.Cursor), max(state.Anchor, state.Cursor)
+			var sb strings.Builder
+			for i := lo; i <= hi; i++ {
+				if filtered && !state.Filter.PassFilter(lines[i].Text) {
+					continue
+				}
+				if sb.Len() > 0 {
+					sb.WriteByte('\n')
+				}
+				sb.WriteString(lines[i].Text)
+			}
+			ClipboardSetText(sb.String())
+		case input.KeyPressed(KeyHome):
+			state.ScrollY = 0
+			state.Pinned = maxScroll == 0
+		case input.KeyPressed(KeyEnd):
+			state.Pinned = true
+		}
+	}
+
+	// Follow new lines while pinned
+	if state.Pinned {
+		state.ScrollY = maxScroll
+	}
+	state.ScrollY = clampf(state.ScrollY, 0, maxScroll)
+
+	// Draw background
+	ctx.DrawList.AddRect(pos.X, pos.Y, w, height, ctx.style.InputBgColor)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.InputBorderColor, ctx.style.BorderSize)
+
+	rowW := w
+	if maxScroll > 0 {
+		rowW -= ctx.style.ScrollbarSize + 2
+	}
+
+	// Draw only the visible rows
+	lo, hi := min(state.Anchor, state.Cursor), max(state.Anchor, state.Cursor)
+	clipper := NewListClipper(count, rowH, height, state.ScrollY)
+	ctx.pushClipRect(rect)
+	for row := clipper.StartIdx; row < clipper.EndIdx; row++ {
+		i := lineAt(row)
+		y := clipper.ItemY(row, pos.Y, state.ScrollY)
+		color := lines[i].Color
+		if color == 0 {
+			color = ctx.style.TextColor
+		}
+		if lo >= 0 && i >= lo && i <= hi {
+			ctx.DrawList.AddRect(pos.X, y, rowW, rowH, ctx.style.SelectedBgColor)
+		}
+		ctx.addText(pos.X+ctx.style.InputPadding, y, lines[i].Text, color)
+	}
+	ctx.popClipRect()
+
+	// Draw scrollbar
+	if maxScroll > 0 {
+		contentHeight := clipper.ContentHeight()
+		scrollbarX := pos.X + w - ctx.style.ScrollbarSize - 2
+		thumbHeight := maxf(20, height*height/contentHeight)
+		thumbPos := state.ScrollY / maxScroll * (height - thumbHeight)
+		ctx.DrawList.AddRect(scrollbarX, pos.Y, ctx.style.ScrollbarSize, height, ctx.style.ScrollbarBgColor)
+		ctx.DrawList.AddRect(scrollbarX, pos.Y+thumbPos, ctx.style.ScrollbarSize, thumbHeight, ctx.style.ScrollbarGrabColor)
+	}
+
+	ctx.advanceCursor(Vec2{w, height})
+}
@@ -0,0 +1,438 @@
+package gui
+
+// popupStore is the type-safe store for context menu and menu state.
+var popupStore = NewFrameStore[PopupState]()
+
+// menuBarStore is the type-safe store for menu bar state.
+var menuBarStore = NewFrameStore[MenuBarState]()
+
+// menuShortcutGap is the space between a menu entry's label and its
+// right-aligned shortcut.
+const menuShortcutGap = float32(24)
+
+// popupFrame is a popup being filled between its Begin and EndPopup.
+type popupFrame struct {
+	id           ID
+	state        *PopupState
+	rect         Rect    // Bounds, sized from last frame
+	y            float32 // Top of the next entry
+	width        float32 // Widest entry this frame
+	count        int     // Entries added so far
+	measuring    bool    // Size unknown yet: entries are measured, not drawn
+	justOpened   bool
+	activated    bool // An entry was chosen: close after this frame
+	savedInPopup bool
+
+	// Menus opened from a menu bar or a parent menu
+	anchor     Rect   // Header or entry that opened the popup; clicks on it aren't outside
+	owner      *ID    // Cleared when the popup closes (the open menu of its bar or parent)
+	childOpen  bool   // A submenu stayed open this frame and takes the keyboard
+	childRects []Rect // Open submenus' bounds; clicks in them aren't outside
+}
+
+// BeginPopupContextItem opens a context menu at the mouse when the last
+// item (the widget drawn just before) is right-clicked. It returns true
+// while the menu is open; add entries with MenuItem and call EndPopup
+// only then. The menu draws on the ForegroundDrawList as the active popup,
+// and closes when an entry is chosen, on a click outside it, or on Escape.
+// While open, Up/Down select an entry and Enter activates it.
+//
+// The menu sizes itself from last frame's entries, so on the frame it
+// first opens the entries are measured but not drawn.
+//
+// Usage:
+//
+//	ctx.Button("Vehicle")
+//	if ctx.BeginPopupContextItem("vehicle_menu") {
+//	    if ctx.MenuItem("Repair", "R", false) {
+//	        repair()
+//	    }
+//	    ctx.EndPopup()
+//	}
+func (ctx *Context) BeginPopupContextItem(id string) bool {
+	popupID := ctx.GetID(id)
+	state := popupStore.GetFor(ctx, popupID, PopupState{KeyboardIndex: -1})
+
+	justOpened := false
+	if ctx.Input != nil && ctx.Input.MouseClicked(MouseButtonRight) && ctx.isHovered(popupID, ctx.lastItemRect) {
+		state.Open = true
+		state.Pos = Vec2{X: ctx.Input.MouseX, Y: ctx.Input.MouseY}
+		state.KeyboardIndex = -1
+		justOpened = true
+	}
+	if !state.Open {
+		if ctx.ActivePopupID() == popupID {
+			ctx.SetActivePopup(0)
+		}
+		return false
+	}
+
+	ctx.beginPopup(popupID, state, justOpened, Rect{}, nil)
+	return true
+}
+
+// beginPopup pushes an open popup at state.Pos and draws its background,
+// sized from last frame. anchor and owner are set for menus (see
+// popupFrame).
+func (ctx *Context) beginPopup(id ID, state *PopupState, justOpened bool, anchor Rect, owner *ID) {
+	ctx.SetActivePopup(id)
+
+	// Keep the menu on screen
+	rect := Rect{X: state.Pos.X, Y: state.Pos.Y, W: state.Size.X, H: state.Size.Y}
+	if ctx.DisplaySize.X > 0 && ctx.DisplaySize.Y > 0 {
+		rect.X = maxf(0, minf(rect.X, ctx.DisplaySize.X-rect.W))
+		rect.Y = maxf(0, minf(rect.Y, ctx.DisplaySize.Y-rect.H))
+	}
+
+	f := &popupFrame{
+		id:           id,
+		state:        state,
+		rect:         rect,
+		y:            rect.Y + SpaceXS,
+		measuring:    state.Size == Vec2{},
+		justOpened:   justOpened,
+		savedInPopup: ctx.inPopup,
+		anchor:       anchor,
+		owner:        owner,
+	}
+	if !f.measuring {
+		fg := ctx.foregroundDrawList()
+		fg.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.PanelColor)
+		fg.AddRectOutline(rect.X, rect.Y, rect.W, rect.H, ctx.style.BorderColor, ctx.style.BorderSize)
+	}
+	ctx.inPopup = true
+	ctx.popupStack = append(ctx.popupStack, f)
+}
+
+// EndPopup finishes a popup begun with BeginPopupContextItem: it handles
+// keyboard navigation and closing, and records the menu's size for the
+// next frame.
+func (ctx *Context) EndPopup() {
+	n := len(ctx.popupStack)
+	if n == 0 {
+		return
+	}
+	f := ctx.popupStack[n-1]
+	ctx.popupStack = ctx.popupStack[:n-1]
+	ctx.inPopup = f.savedInPopup
+	state := f.state
+	state.Size = Vec2{X: f.width, Y: f.y + SpaceXS - f.rect.Y}
+
+	// A submenu drawn this frame takes the keyboard; Left closes a submenu
+	if input := ctx.Input; input != nil && !f.childOpen {
+		if f.count > 0 {
+			if input.KeyRepeated(KeyDown) {
+				state.KeyboardIndex = min(state.KeyboardIndex+1, f.count-1)
+			}
+			if input.KeyRepeated(KeyUp) {
+				state.KeyboardIndex = max(state.KeyboardIndex-1, 0)
+			}
+		}
+		if n > 1 && !f.justOpened && input.KeyPressed(KeyLeft) {
+			state.Open = false
+		}
+	}
+
+	// Close on a click outside (either button); a right-click on the
+	// item reopens the menu at the new position
+	if input := ctx.Input; input != nil &&
+		(input.MouseClicked(MouseButtonLeft) || input.MouseClicked(MouseButtonRight)) && !f.justOpened {
+		mouse := Vec2{input.MouseX, input.MouseY}
+		inside := f.rect.Contains(mouse) || f.anchor.Contains(mouse)
+		for _, r := range f.childRects {
+			inside = inside || r.Contains(mouse)
+		}
+		if !inside {
+			state.Open = false
+		}
+	}
+	if f.activated {
+		state.Open = false
+	}
+	if state.Open {
+		if ctx.escapeFor(escapePopup, f.id) {
+			state.Open = false
+		} else {
+			ctx.claimEscape(escapePopup, f.id)
+		}
+	}
+
+	// Let the parent menu know: a chosen entry closes the whole chain, an
+	// open submenu keeps it open
+	if n > 1 {
+		parent := ctx.popupStack[n-2]
+		parent.activated = parent.activated || f.activated
+		parent.childOpen = true
+		if state.Open {
+			parent.childRects = append(append(parent.childRects, f.rect), f.childRects...)
+		}
+	}
+
+	if state.Open {
+		ctx.registerPopupRect(f.id, f.rect)
+		if ctx.ActivePopupID() == 0 {
+			ctx.SetActivePopup(f.id) // A submenu closed: the focus returns here
+		}
+	} else {
+		if f.owner != nil && *f.owner == f.id {
+			*f.owner = 0
+		}
+		if ctx.ActivePopupID() == f.id {
+			ctx.SetActivePopup(0)
+		}
+	}
+}
+
+// MenuItem draws an entry of the open popup or menu: the label, a check
+// mark when selected, and the shortcut text (e.g. "Ctrl+S", display only)
+// right-aligned. Returns true when the entry is clicked or activated with
+// Enter, which also closes the popup and its parent menus. Outside a popup
+// it does nothing.
+func (ctx *Context) MenuItem(label, shortcut string, selected bool) bool {
+	n := len(ctx.popupStack)
+	if n == 0 {
+		return false
+	}
+	f := ctx.popupStack[n-1]
+
+	id := ctx.GetID(label)
+	k := f.count
+	f.count++
+
+	pad := ctx.style.ItemSpacing
+	checkW := ctx.MeasureText("✓").X + pad
+	entryW := pad + checkW + ctx.MeasureText(label).X + pad
+	if shortcut != "" {
+		entryW += menuShortcutGap + ctx.MeasureText(shortcut).X
+	}
+	f.width = maxf(f.width, entryW)
+
+	rowH := ctx.lineHeight() + ctx.style.ItemSpacing
+	rect := Rect{X: f.rect.X + SpaceXS, Y: f.y, W: f.rect.W - SpaceXS*2, H: rowH}
+	f.y += rowH
+	if f.measuring {
+		return false
+	}
+
+	fg := ctx.foregroundDrawList()
+	hovered, _, clicked := ctx.buttonBehavior(id, rect, false, false)
+	if hovered {
+		f.state.Submenu = 0 // Hovering another entry closes an open submenu
+	}
+	keySelected := f.state.KeyboardIndex == k
+	if hovered || keySelected {
+		fg.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.SelectedBgColor)
+	}
+	if keySelected && ctx.DebugFocusHighlight {
+		fg.AddRectOutline(rect.X, rect.Y, rect.W, rect.H, DebugFocusBorderColor, 3)
+	}
+	textColor := ctx.style.TextColor
+	if keySelected {
+		textColor = ctx.style.SelectedTextColor
+	}
+	textY := rect.Y + ctx.style.ItemSpacing/2
+	if selected {
+		ctx.addTextTo(fg, rect.X+pad, textY, "✓", textColor)
+	}
+	ctx.addTextTo(fg, rect.X+pad+checkW, textY, label, textColor)
+	if shortcut != "" {
+		ctx.addTextTo(fg, rect.X+rect.W-pad-ctx.MeasureText(shortcut).X, textY, shortcut, ctx.style.TextDisabledColor)
+	}
+
+	if keySelected && !f.justOpened && f.state.Submenu == 0 && ctx.Input != nil && ctx.Input.KeyPressed(KeyEnter) {
+		clicked = true
+	}
+	if clicked {
+		f.activated = true
+	}
+	return clicked
+}
+
+// menuBarFrame is a menu bar being filled between BeginMenuBar and
+// EndMenuBar.
+type menuBarFrame struct {
+	state *MenuBarState
+	rect  Rect
+	x     float32 // Left edge of the next menu's header
+	seen  bool    // The open menu's header was drawn this frame
+}
+
+// BeginMenuBar starts a menu bar across the current layout, at the cursor.
+// Add menus with BeginMenu and finish with EndMenuBar. Clicking a menu's
+// header opens it; while one is open, hovering another header switches to
+// it. Always returns true.
+//
+// Usage:
+//
+//	if ctx.BeginMenuBar() {
+//	    if ctx.BeginMenu("File") {
+//	        if ctx.MenuItem("Save", "Ctrl+S", false) {
+//	            save()
+//	        }
+//	        ctx.EndMenu()
+//	    }
+//	    ctx.EndMenuBar()
+//	}
+func (ctx *Context) BeginMenuBar() bool {
+	pos := ctx.ItemPos()
+	id := ctx.GetID("##menubar")
+	h := ctx.lineHeight() + ctx.style.ItemSpacing*2
+	rect := Rect{X: pos.X, Y: pos.Y, W: ctx.currentLayoutWidth(), H: h}
+
+	bg := ctx.style.PanelHeaderBgColor
+	if bg == 0 {
+		bg = ctx.style.ButtonColor
+	}
+	ctx.DrawList.AddRect(rect.X, rect.Y, rect.W, rect.H, bg)
+
+	ctx.menuBar = &menuBarFrame{
+		state: menuBarStore.GetFor(ctx, id, MenuBarState{}),
+		rect:  rect,
+		x:     rect.X,
+	}
+	return true
+}
+
+// EndMenuBar finishes a menu bar begun with BeginMenuBar.
+func (ctx *Context) EndMenuBar() {
+	bar := ctx.menuBar
+	if bar == nil {
+		return
+	}
+	ctx.menuBar = nil
+	if !bar.seen {
+		bar.state.OpenMenu = 0 // The open menu is no longer drawn
+	}
+	ctx.advanceCursor(Vec2{X: bar.rect.W, Y: bar.rect.H})
+}
+
+// BeginMenu adds a menu. In a menu bar it draws the menu's header, and
+// returns true while the menu is open; inside an open menu or context
+// menu it draws an entry with an arrow that opens a submenu to the side
+// when hovered (or with Right when keyboard-selected; Left closes it).
+// While it returns true, add entries with MenuItem and nested menus with
+// BeginMenu, then call EndMenu. Choosing an entry closes every menu up to
+// the bar. Menus draw on the ForegroundDrawList as the active popup.
+// Outside a menu bar or menu it does nothing.
+func (ctx *Context) BeginMenu(label string) bool {
+	if n := len(ctx.popupStack); n > 0 {
+		return ctx.beginSubmenu(ctx.popupStack[n-1], label)
+	}
+	bar := ctx.menuBar
+	if bar == nil {
+		return false
+	}
+
+	id := ctx.GetID(label)
+	pad := ctx.style.ItemSpacing
+	header := Rect{X: bar.x, Y: bar.rect.Y, W: ctx.MeasureText(label).X + pad*2, H: bar.rect.H}
+	bar.x += header.W
+
+	hovered, _, clicked := ctx.buttonBehavior(id, header, true, false)
+	switch {
+	case clicked && bar.state.OpenMenu == id:
+		bar.state.OpenMenu = 0
+	case clicked, hovered && bar.state.OpenMenu != 0:
+		bar.state.OpenMenu = id
+	}
+	open := bar.state.OpenMenu == id
+
+	switch {
+	case open:
+		ctx.DrawList.AddRect(header.X, header.Y, header.W, header.H, ctx.style.SelectedBgColor)
+	case hovered:
+		ctx.DrawList.AddRect(header.X, header.Y, header.W, header.H, ctx.style.HoveredBgColor)
+	}
+	textColor := ctx.style.TextColor
+	if open {
+		textColor = ctx.style.SelectedTextColor
+	}
+	ctx.addText(header.X+pad, header.Y+pad, label, textColor)
+	if !open {
+		return false
+	}
+
+	bar.seen = true
+	state := popupStore.GetFor(ctx, id, PopupState{KeyboardIndex: -1})
+	justOpened := !state.Open
+	state.Open = true
+	state.Pos = Vec2{X: header.X, Y: header.Y + header.H}
+	ctx.beginPopup(id, state, justOpened, header, &bar.state.OpenMenu)
+	return true
+}
+
+// EndMenu finishes a menu begun with BeginMenu. Call it only when
+// BeginMenu returned true.
+func (ctx *Context) EndMenu() {
+	ctx.EndPopup()
+}
+
+// beginSubmenu draws a submenu entry in the open popup f, and opens the
+// submenu beside it while it is f's open submenu.
+func (ctx *Context) beginSubmenu(f *popupFrame, label string) bool {
+	id := ctx.GetID(label)
+	k := f.count
+	f.count++
+
+	pad := ctx.style.ItemSpacing
+	checkW := ctx.MeasureText("✓").X + pad
+	arrowW := ctx.MeasureText("►").X
+	f.width = maxf(f.width, pad+checkW+ctx.MeasureText(label).X+menuShortcutGap+arrowW+pad)
+
+	rowH := ctx.lineHeight() + ctx.style.ItemSpacing
+	rect := Rect{X: f.rect.X + SpaceXS, Y: f.y, W: f.rect.W - SpaceXS*2, H: rowH}
+	f.y += rowH
+	if f.measuring {
+		return false
+	}
+
+	hovered, _, clicked := ctx.buttonBehavior(id, rect, true, false)
+	keySelected := f.state.KeyboardIndex == k
+	byKey := keySelected && f.state.Submenu != id && ctx.Input != nil && ctx.Input.KeyPressed(KeyRight)
+	if hovered || clicked || byKey {
+		f.state.Submenu = id
+	}
+	open := f.state.Submenu == id
+
+	fg := ctx.foregroundDrawList()
+	if hovered || keySelected || open {
+		fg.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.SelectedBgColor)
+	}
+	if keySelected && ctx.DebugFocusHighlight {
+		fg.AddRectOutline(rect.X, rect.Y, rect.W, rect.H, DebugFocusBorderColor, 3)
+	}
+	textColor := ctx.style.TextColor
+	if keySelected {
+		textColor = ctx.style.SelectedTextColor
+	}
+	textY := rect.Y + ctx.style.ItemSpacing/2
+	ctx.addTextTo(fg, rect.X+pad+checkW, textY, label, textColor)
+	ctx.addTextTo(fg, rect.X+rect.W-pad-arrowW, textY, "►", textColor)
+	if !open {
+		return false
+	}
+
+	state := popupStore.GetFor(ctx, id, PopupState{KeyboardIndex: -1})
+	justOpened := !state.Open
+	state.Open = true
+	if byKey {
+		state.KeyboardIndex = 0
+	}
+
+	// Beside the entry, or on the parent's left when it doesn't fit
+	state.Pos = Vec2{X: f.rect.X + f.rect.W, Y: rect.Y - SpaceXS}
+	if ctx.DisplaySize.X > 0 && state.Pos.X+state.Size.X > ctx.DisplaySize.X {
+		state.Pos.X = f.rect.X - state.Size.X
+	}
+	ctx.beginPopup(id, state, justOpened, rect, &f.state.Submenu)
+	return true
+}
+
+// foregroundDrawList returns the list popups draw on: the foreground list,
+// or the main list when there is none.
+func (ctx *Context) foregroundDrawList() *DrawList {
+	if ctx.ForegroundDrawList != nil {
+		return ctx.ForegroundDrawList
+	}
+	return ctx.DrawList
+}
@@ -50,6 +50,10 @@ type ModalMenu struct {
 
 	// Draggable support
 	drag DraggablePanel
+
+	// Set once drawn: Escape then comes through the context's Escape chain
+	// (see Context.HandleEscape) instead of HandleInput
+	ctxEscape bool
 }
 
 // NewModalMenu creates a new modal menu.
@@ -134,6 +138,18 @@ func (m *ModalMenu) Draw(ctx *Context) {
 		return
 	}
 
+	// Escape closes the menu unless a text field or popup takes it first
+	m.ctxEscape = true
+	escapeID := ctx.GetID("##modal_" + m.title)
+	if ctx.escapeFor(escapeModal, escapeID) {
+		m.Close()
+		if m.delegate != nil {
+			m.delegate.OnCancel()
+		}
+		return
+	}
+	ctx.claimEscape(escapeModal, escapeID)
+
 	// Handle drag input
 	m.drag.HandleDrag(ctx)
 
@@ -265,8 +281,8 @@ func (m *ModalMenu) HandleInput(input *InputState) bool {
 		}
 	}
 
-	// Escape to cancel
-	if input.KeyPressed(KeyEscape) {
+	// Escape to cancel (once drawn, Draw handles it instead)
+	if !m.ctxEscape && input.KeyPressed(KeyEscape) {
 		m.Close()
 		if m.delegate != nil {
 			m.delegate.OnCancel()
@@ -2,13 +2,16 @@ package gui
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
 
 // NumberInputFloat draws a numeric input field for float32 values.
 // Click to enter text edit mode, or drag left/right to adjust the value.
-// Returns true if the value was changed.
+// When focused the arrow keys (Up/Right increase, Down/Left decrease) and,
+// when hovered, the mouse wheel step the value by WithStep (default 1),
+// clamped to WithRange. Returns true if the value was changed.
 //
 // Usage:
 //
@@ -17,6 +20,11 @@ import (
 //	    ctx.NumberInputFloat("", &scaleY, WithPrefix("Y:"), WithWidth(60))
 //	    ctx.NumberInputFloat("", &scaleZ, WithPrefix("Z:"), WithWidth(60))
 //	})
+//
+// WithTimeFormat treats the value as a duration in seconds: it is shown as
+// "M:SS" or "H:MM:SS", typed text is parsed with parseDuration, and dragging
+// moves it in whole seconds. Text that doesn't parse leaves the value as it
+// was. WithRange clamps in seconds.
 func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Option) bool {
 	pos := ctx.ItemPos()
 	o := applyOptions(opts)
@@ -33,10 +41,6 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 	state := GetState(ctx, id, NumberInputState{})
 
 	// Calculate dimensions
-	w := float32(80)
-	if width := GetOpt(o, OptWidth); width > 0 {
-		w = width
-	}
 	h := ctx.lineHeight() + ctx.style.InputPadding*2
 
 	// Label width if present
@@ -44,6 +48,7 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 	if label != "" {
 		labelWidth = ctx.MeasureText(label).X + ctx.style.ItemSpacing
 	}
+	w := ctx.itemWidth(o, 80, labelWidth)
 
 	// Draw label
 	if label != "" {
@@ -66,6 +71,7 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 
 	// Track if we just started editing this frame (to prevent Enter from immediately closing)
 	justStartedEditing := false
+	wasEditing := state.Editing // Enter that confirms an edit mustn't start another
 
 	// Drag speed (pixels per unit change)
 	dragSpeed := GetOpt(o, OptDragSpeed)
@@ -73,6 +79,13 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 		dragSpeed = 1.0 // Default: 1 pixel = 1 unit
 	}
 
+	// Durations drag in whole seconds unless a step says otherwise
+	timeFormat := GetOpt(o, OptTimeFormat)
+	dragStep := GetOpt(o, OptStep)
+	if dragStep == 0 && timeFormat != TimeFormatNone {
+		dragStep = 1
+	}
+
 	// Handle input
 	if ctx.Input != nil {
 		// Click to start editing or dragging
@@ -87,37 +100,26 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 			}
 		}
 
-		// Double-click to enter edit mode
-		// For simplicity, we'll use a small movement threshold to distinguish click from drag
+		// Releasing without moving past the drag threshold is a click: enter edit mode
 		if state.Dragging && ctx.Input.MouseReleased(MouseButtonLeft) {
-			dragDist := absf(ctx.Input.MouseX - state.DragStartX)
-			if dragDist < 3 {
+			if !ctx.IsMouseDragPastThreshold(MouseButtonLeft) {
 				// Small movement = click, enter edit mode
 				state.Editing = true
 				justStartedEditing = true
-				format := GetOpt(o, OptFormat)
-				if format == "" {
-					format = "%.2f"
-				}
-				// Handle integer format specifiers (%d) with float32 value
-				if strings.Contains(format, "%d") {
-					state.EditText = fmt.Sprintf(format, int(*value))
-				} else {
-					state.EditText = fmt.Sprintf(format, *value)
-				}
+				state.EditText = formatInputValue(o, *value)
 			}
 			state.Dragging = false
 		}
 
-		// Handle dragging
-		if state.Dragging && ctx.Input.MouseDown(MouseButtonLeft) {
-			deltaX := ctx.Input.MouseX - state.DragStartX
+		// Handle dragging (only once past the threshold, so clicks don't nudge the value)
+		if state.Dragging && ctx.IsMouseDragging(MouseButtonLeft, 0) {
+			deltaX := ctx.MouseDragDelta(MouseButtonLeft).X
 			deltaValue := deltaX / dragSpeed
 			newValue := state.DragStartValue + deltaValue
 
 			// Apply step if configured
-			if step := GetOpt(o, OptStep); step > 0 {
-				newValue = float32(int(newValue/step+0.5)) * step
+			if dragStep > 0 {
+				newValue = float32(int(newValue/dragStep+0.5)) * dragStep
 			}
 
 			// Clamp to range if configured
@@ -137,23 +139,11 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 			// Mark that keyboard is captured (prevents hotkeys from triggering)
 			ctx.WantCaptureKeyboard = true
 
-			// Text input
-			for _, ch := range ctx.Input.InputChars {
-				// Allow digits, decimal point, minus sign
-				if (ch >= '0' && ch <= '9') || ch == '.' || ch == '-' {
-					state.EditText += string(ch)
-				}
-			}
-
-			// Backspace
-			if ctx.Input.KeyRepeated(KeyBackspace) && len(state.EditText) > 0 {
-				state.EditText = state.EditText[:len(state.EditText)-1]
-			}
+			ctx.numberEditInput(&state.EditText, timeFormat != TimeFormatNone)
 
 			// Enter to confirm (skip if we just started editing this frame)
 			if !justStartedEditing && ctx.Input.KeyPressed(KeyEnter) {
-				if v, err := strconv.ParseFloat(strings.TrimSpace(state.EditText), 32); err == nil {
-					newValue := float32(v)
+				if newValue, ok := parseInputValue(o, state.EditText); ok {
 					rangeVal := GetOpt(o, OptRange)
 					if rangeVal.HasRange {
 						newValue = clampf(newValue, rangeVal.Min, rangeVal.Max)
@@ -167,7 +157,7 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 			}
 
 			// Escape to cancel
-			if ctx.Input.KeyPressed(KeyEscape) {
+			if !GetOpt(o, OptNoEscape) && ctx.escapeFor(escapeText, id) {
 				state.Editing = false
 			}
 		}
@@ -175,8 +165,7 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 		// Exit edit mode if registry focus moved to a different widget
 		if state.Editing && !isFocused {
 			// Confirm current value
-			if v, err := strconv.ParseFloat(strings.TrimSpace(state.EditText), 32); err == nil {
-				newValue := float32(v)
+			if newValue, ok := parseInputValue(o, state.EditText); ok {
 				rangeVal := GetOpt(o, OptRange)
 				if rangeVal.HasRange {
 					newValue = clampf(newValue, rangeVal.Min, rangeVal.Max)
@@ -191,8 +180,7 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 
 		// Click outside to confirm edit
 		if state.Editing && !hovered && ctx.Input.MouseClicked(MouseButtonLeft) {
-			if v, err := strconv.ParseFloat(strings.TrimSpace(state.EditText), 32); err == nil {
-				newValue := float32(v)
+			if newValue, ok := parseInputValue(o, state.EditText); ok {
 				rangeVal := GetOpt(o, OptRange)
 				if rangeVal.HasRange {
 					newValue = clampf(newValue, rangeVal.Min, rangeVal.Max)
@@ -205,52 +193,43 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 			state.Editing = false
 		}
 
-		// Keyboard support when focused but not editing (Left/Right arrows to adjust)
-		if isFocused && !state.Editing {
-			step := GetOpt(o, OptStep)
-			if step == 0 {
-				step = 1.0 // Default step for number input
+		// Step the value with the arrow keys when focused and the wheel when
+		// hovered; while typing, the keys edit the text instead
+		step := GetOpt(o, OptStep)
+		if step == 0 {
+			step = 1.0 // Default step for number input
+		}
+		nudge := func(delta float32) {
+			newValue := *value + delta
+			rangeVal := GetOpt(o, OptRange)
+			if rangeVal.HasRange {
+				newValue = clampf(newValue, rangeVal.Min, rangeVal.Max)
 			}
+			if newValue != *value {
+				*value = newValue
+				changed = true
+			}
+		}
 
+		if isFocused && !state.Editing {
 			// Enter to start editing
-			if ctx.Input.KeyPressed(KeyEnter) {
+			if !wasEditing && ctx.Input.KeyPressed(KeyEnter) {
 				state.Editing = true
 				justStartedEditing = true
-				format := GetOpt(o, OptFormat)
-				if format == "" {
-					format = "%.2f"
-				}
-				if strings.Contains(format, "%d") {
-					state.EditText = fmt.Sprintf(format, int(*value))
-				} else {
-					state.EditText = fmt.Sprintf(format, *value)
-				}
+				state.EditText = formatInputValue(o, *value)
 			}
 
-			// Left/Right arrows to adjust value
-			if ctx.Input.KeyRepeated(KeyLeft) {
-				newValue := *value - step
-				rangeVal := GetOpt(o, OptRange)
-				if rangeVal.HasRange {
-					newValue = clampf(newValue, rangeVal.Min, rangeVal.Max)
-				}
-				if newValue != *value {
-					*value = newValue
-					changed = true
-				}
+			// Left/Down decrease, Right/Up increase
+			if ctx.Input.KeyRepeated(KeyLeft) || ctx.Input.KeyRepeated(KeyDown) {
+				nudge(-step)
 			}
-			if ctx.Input.KeyRepeated(KeyRight) {
-				newValue := *value + step
-				rangeVal := GetOpt(o, OptRange)
-				if rangeVal.HasRange {
-					newValue = clampf(newValue, rangeVal.Min, rangeVal.Max)
-				}
-				if newValue != *value {
-					*value = newValue
-					changed = true
-				}
+			if ctx.Input.KeyRepeated(KeyRight) || ctx.Input.KeyRepeated(KeyUp) {
+				nudge(step)
 			}
 		}
+		if hovered && !state.Editing && !state.Dragging && ctx.Input.MouseWheelY != 0 {
+			nudge(ctx.Input.MouseWheelY * step)
+		}
 	}
 
 	// Draw background
@@ -261,7 +240,7 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 		bgColor = ctx.style.InputFocusedBgColor
 	}
 	ctx.DrawList.AddRect(boxX, boxY, w, h, bgColor)
-	ctx.DrawList.AddRectOutline(boxX, boxY, w, h, ctx.style.InputBorderColor, 1)
+	ctx.DrawList.AddRectOutline(boxX, boxY, w, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
 
 	// Draw content
 	textX := boxX + ctx.style.InputPadding
@@ -273,24 +252,13 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 		ctx.addText(textX, textY, displayText, ctx.style.TextColor)
 
 		// Draw cursor
-		if (ctx.FrameCount/30)%2 == 0 {
+		if int(ctx.Time*2)%2 == 0 { // Blink every 0.5s
 			cursorX := textX + ctx.MeasureText(prefix+state.EditText).X
-			ctx.DrawList.AddLine(cursorX, boxY+2, cursorX, boxY+h-2, ctx.style.TextColor, 1)
+			ctx.DrawList.AddLine(cursorX, boxY+2, cursorX, boxY+h-2, ctx.style.CaretColor, 1)
 		}
 	} else {
 		// Draw formatted value
-		format := GetOpt(o, OptFormat)
-		if format == "" {
-			format = "%.2f"
-		}
-		// Handle integer format specifiers (%d) with float32 value
-		var valueText string
-		if strings.Contains(format, "%d") {
-			valueText = fmt.Sprintf(format, int(*value))
-		} else {
-			valueText = fmt.Sprintf(format, *value)
-		}
-		displayText := prefix + valueText + suffix
+		displayText := prefix + formatInputValue(o, *value) + suffix
 		ctx.addText(textX, textY, displayText, ctx.style.TextColor)
 	}
 
@@ -315,10 +283,17 @@ func (ctx *Context) NumberInputFloat(label string, value *float32, opts ...Optio
 		)
 	}
 
+	if state.Editing && !GetOpt(o, OptNoEscape) {
+		ctx.claimEscape(escapeText, id)
+	}
+
 	// Save state
 	SetState(ctx, id, state)
 
-	// Advance cursor
+	ctx.notifyChange(id, o, BuiltinComponents.NumberInput, label, *value, changed, state.Dragging)
+
+	// Advance cursor (past the error message, if any)
+	h += ctx.drawFieldError(o, rect)
 	totalWidth := labelWidth + w
 	ctx.advanceCursor(Vec2{totalWidth, h})
 
@@ -343,12 +318,23 @@ func (ctx *Context) NumberInputInt(label string, value *int, opts ...Option) boo
 			hasStep = true
 		}
 	}
+	// Copy before appending, so the caller's backing array isn't written to
+	opts = append([]Option(nil), opts...)
 	if !hasFormat {
 		opts = append(opts, WithFormat("%d"))
 	}
 	if !hasStep {
 		opts = append(opts, WithStep(1))
 	}
+	opts = append(opts, WithOpt(optIntValue, true))
+
+	// Write the int back before the user's callback runs
+	if onChange := ApplyAndGet(opts, OptOnChange); onChange != nil {
+		opts = append(opts, WithOnChange(func() {
+			*value = int(floatVal)
+			onChange()
+		}))
+	}
 
 	changed := ctx.NumberInputFloat(label, &floatVal, opts...)
 	if changed {
@@ -357,6 +343,118 @@ func (ctx *Context) NumberInputInt(label string, value *int, opts ...Option) boo
 	return changed
 }
 
+// formatNumber formats v for display or editing. An empty format uses
+// "%.2f"; formats containing %d are given the value truncated to an int.
+func formatNumber(format string, v float32) string {
+	if format == "" {
+		format = "%.2f"
+	}
+	if strings.Contains(format, "%d") {
+		return fmt.Sprintf(format, int(v))
+	}
+	return fmt.Sprintf(format, v)
+}
+
+// parseNumber parses text typed into a number field.
+func parseNumber(text string) (float32, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(text), 32)
+	if err != nil {
+		return 0, false
+	}
+	return float32(v), true
+}
+
+// formatInputValue formats a NumberInput value for display or editing,
+// honoring WithTimeFormat and WithFormat.
+func formatInputValue(o options, v float32) string {
+	if tf := GetOpt(o, OptTimeFormat); tf != TimeFormatNone {
+		return formatDuration(tf, v)
+	}
+	return formatNumber(GetOpt(o, OptFormat), v)
+}
+
+// parseInputValue parses text typed into a NumberInput.
+func parseInputValue(o options, text string) (float32, bool) {
+	if GetOpt(o, OptTimeFormat) != TimeFormatNone {
+		return parseDuration(text)
+	}
+	return parseNumber(text)
+}
+
+// formatDuration formats seconds, rounded to whole seconds, as "M:SS" or
+// "H:MM:SS". Minutes aren't wrapped into hours in TimeFormatMMSS.
+func formatDuration(format TimeFormat, seconds float32) string {
+	total := int64(math.Round(float64(seconds)))
+	sign := ""
+	if total < 0 {
+		sign, total = "-", -total
+	}
+	if format == TimeFormatHHMMSS {
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, total/3600, total/60%60, total%60)
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, total/60, total%60)
+}
+
+// parseDuration parses a typed duration into seconds. Fields are read from
+// the right: seconds, then minutes, then hours, so the same rules hold in
+// either TimeFormat:
+//
+//	"90"      90 s (1:30)
+//	"1:30"    90 s
+//	"1:05:00" 3900 s
+//	"0:90"    90 s (fields may overflow)
+//	"-1:30"   -90 s
+//
+// Only the seconds field may have a fraction. Empty fields, more than three
+// fields and anything other than digits make the text invalid.
+func parseDuration(text string) (float32, bool) {
+	text = strings.TrimSpace(text)
+	neg := strings.HasPrefix(text, "-")
+	if neg {
+		text = text[1:]
+	}
+	parts := strings.Split(text, ":")
+	if len(parts) > 3 {
+		return 0, false
+	}
+
+	var total float64
+	unit := 1.0
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := parts[i]
+		if part == "" || strings.Trim(part, "0123456789.") != "" {
+			return 0, false
+		}
+		if i != len(parts)-1 && strings.Contains(part, ".") {
+			return 0, false
+		}
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, false
+		}
+		total += v * unit
+		unit *= 60
+	}
+	if neg {
+		total = -total
+	}
+	return float32(total), true
+}
+
+// numberEditInput applies this frame's typed characters and Backspace to
+// the text of a number being edited. Only digits, '.' and '-' are accepted,
+// plus ':' for durations.
+func (ctx *Context) numberEditInput(text *string, colons bool) {
+	for _, ch := range ctx.Input.InputChars {
+		if (ch >= '0' && ch <= '9') || ch == '.' || ch == '-' || (colons && ch == ':') {
+			*text += string(ch)
+		}
+	}
+	if ctx.Input.KeyRepeated(KeyBackspace) && len(*text) > 0 {
+		*text = (*text)[:len(*text)-1]
+	}
+}
+
 // absf returns the absolute value of a float32.
 func absf(x float32) float32 {
 	if x < 0 {
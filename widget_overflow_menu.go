@@ -0,0 +1,310 @@
+package gui
+
+// overflowStore is the type-safe store for OverflowMenu state.
+var overflowStore = NewFrameStore[OverflowMenuState]()
+
+// overflowMenuMinWidth is the narrowest the overflow dropdown gets.
+const overflowMenuMinWidth = float32(120)
+
+// OverflowBuilder lays out a row of actions that collapses into a "more
+// actions" menu when it runs out of room. Create one with
+// Context.OverflowMenu each frame, add items, and finish with End.
+type OverflowBuilder struct {
+	ctx     *Context
+	id      ID // Kebab button and dropdown popup
+	state   *OverflowMenuState
+	o       options
+	widths  []float32 // This frame's inline item widths
+	startX  float32
+	y       float32
+	right   float32 // Right edge of the available width
+	x       float32 // Right edge of the last inline item
+	rowH    float32
+	index   int // Items added so far
+	overIdx int // Overflowed items added so far
+
+	overflowing bool
+	justOpened  bool
+	kebab       Rect
+	menu        Rect    // Dropdown bounds (sized from last frame while open)
+	menuW       float32 // Widest overflowed entry this frame
+}
+
+// OverflowMenu starts a toolbar row of buttons. Items are laid out inline
+// while they fit in ContentRegionAvail; once one doesn't, it and every item
+// after it move into a dropdown opened by a kebab ("more") button at the
+// end of the row. Room for the kebab button is only kept when the row
+// doesn't fit entirely. The fit uses the item widths measured last frame, so
+// a row whose items change settles on the next frame.
+//
+// Inline items and the kebab button are focusable like buttons and activate
+// with Enter or Space. While the dropdown is open, Up/Down move through the
+// overflowed items, Enter runs one, and Escape or a click outside closes it.
+//
+// Options: WithID, WithWidth (instead of the available width), WithNoEscape.
+// End must be called after the last item; the row advances the cursor as a
+// single item.
+//
+// Usage:
+//
+//	bar := ctx.OverflowMenu("toolbar")
+//	if bar.Item("Cut") {
+//	    cut()
+//	}
+//	if bar.Item("Copy") {
+//	    copy()
+//	}
+//	bar.End()
+func (ctx *Context) OverflowMenu(id string, opts ...Option) *OverflowBuilder {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	oid := ctx.GetID(id)
+	if optID := GetOpt(o, OptID); optID != "" {
+		oid = ctx.GetID(optID)
+	}
+	state := overflowStore.GetFor(ctx, oid, OverflowMenuState{KeyboardIndex: -1})
+	avail := ctx.ContentRegionAvail().X
+	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
+		avail = optWidth
+	}
+
+	ctx.PushID(id)
+	rowH := ctx.lineHeight() + ctx.style.ButtonPadding*2
+	return &OverflowBuilder{
+		ctx:    ctx,
+		id:     oid,
+		state:  state,
+		o:      o,
+		startX: pos.X,
+		y:      pos.Y,
+		right:  pos.X + avail,
+		x:      pos.X,
+		rowH:   rowH,
+		kebab:  Rect{W: rowH, H: rowH},
+	}
+}
+
+// Item adds an action. It is drawn inline as a button if it fits, otherwise
+// as an entry in the overflow dropdown. Returns true when it is clicked or
+// activated from the keyboard.
+func (b *OverflowBuilder) Item(label string) bool {
+	ctx := b.ctx
+	id := ctx.GetID(label)
+	i := b.index
+	b.index++
+
+	w := ctx.MeasureText(label).X + ctx.style.ButtonPadding*2
+	b.widths = append(b.widths, w)
+	gap := ctx.style.ItemSpacing
+
+	if !b.overflowing {
+		x := b.x
+		if i > 0 {
+			x += gap
+		}
+		// Fits if the rest of the row (as measured last frame) fits too, or
+		// if there is still room for the kebab button after it
+		var rest float32
+		for _, rw := range b.state.Widths[min(i+1, len(b.state.Widths)):] {
+			rest += gap + rw
+		}
+		if x+w+rest <= b.right || x+w+gap+b.kebab.W <= b.right {
+			b.x = x + w
+			return b.inlineItem(id, label, Rect{X: x, Y: b.y, W: w, H: b.rowH})
+		}
+		b.beginOverflow()
+	}
+	return b.menuItem(id, label)
+}
+
+// inlineItem draws an item as a button in the row.
+func (b *OverflowBuilder) inlineItem(id ID, label string, rect Rect) bool {
+	ctx := b.ctx
+	ctx.RegisterFocusable(id, label, rect, FocusTypeLeaf)
+	hovered, pressed, clicked := ctx.buttonBehavior(id, rect, false, false)
+	focused := ctx.IsRegistryFocused(id)
+	if focused && b.keyActivated() {
+		clicked = true
+	}
+
+	bg := ctx.style.ButtonColor
+	if focused || pressed {
+		bg = ctx.style.ButtonActiveColor
+	} else if hovered {
+		bg = ctx.style.ButtonHoveredColor
+	}
+	ctx.DrawList.AddRect(rect.X, rect.Y, rect.W, rect.H, bg)
+	size := ctx.MeasureText(label)
+	ctx.addText(rect.X+(rect.W-size.X)/2, rect.Y+(rect.H-size.Y)/2, label, ctx.style.TextColor)
+	return clicked
+}
+
+// keyActivated reports whether Enter or Space activates the focused row
+// widget. While the dropdown is open, Enter belongs to its entries.
+func (b *OverflowBuilder) keyActivated() bool {
+	in := b.ctx.Input
+	return in != nil && !b.state.Open && (in.KeyPressed(KeyEnter) || in.KeyPressed(KeySpace))
+}
+
+// beginOverflow draws the kebab button after the inline items and, while
+// the menu is open, the dropdown background the overflowed items go on.
+func (b *OverflowBuilder) beginOverflow() {
+	ctx := b.ctx
+	b.overflowing = true
+
+	b.kebab.X = b.startX
+	if b.index > 1 {
+		b.kebab.X = b.x + ctx.style.ItemSpacing
+	}
+	b.kebab.Y = b.y
+	b.x = b.kebab.X + b.kebab.W
+
+	ctx.RegisterFocusable(b.id, "More", b.kebab, FocusTypeLeaf)
+	hovered, _, clicked := ctx.buttonBehavior(b.id, b.kebab, false, false)
+	focused := ctx.IsRegistryFocused(b.id)
+	if focused && b.keyActivated() {
+		clicked = true
+	}
+	if clicked {
+		b.state.Open = !b.state.Open
+		b.state.KeyboardIndex = -1
+		b.justOpened = b.state.Open
+		if !b.state.Open {
+			ctx.SetActivePopup(0)
+		}
+	}
+
+	bg := ctx.style.ButtonColor
+	if focused || b.state.Open {
+		bg = ctx.style.ButtonActiveColor
+	} else if hovered {
+		bg = ctx.style.ButtonHoveredColor
+	}
+	ctx.DrawList.AddRect(b.kebab.X, b.kebab.Y, b.kebab.W, b.kebab.H, bg)
+
+	// Three stacked dots, drawn so no glyph is needed
+	dot := maxf(2, b.kebab.W/10)
+	cx := b.kebab.X + b.kebab.W/2 - dot/2
+	for k := -1; k <= 1; k++ {
+		cy := b.kebab.Y + b.kebab.H/2 + float32(k)*dot*2 - dot/2
+		ctx.DrawList.AddRect(cx, cy, dot, dot, ctx.style.TextColor)
+	}
+
+	if !b.state.Open {
+		return
+	}
+
+	// Dropdown below the kebab, right-aligned with it; its size comes from
+	// last frame's overflowed items
+	ctx.SetActivePopup(b.id)
+	itemH := ctx.lineHeight() + ctx.style.ItemSpacing
+	menuW := maxf(overflowMenuMinWidth, b.state.MenuWidth)
+	count := max(b.state.Overflowed, 1)
+	b.menu = Rect{
+		X: maxf(0, b.kebab.X+b.kebab.W-menuW),
+		Y: b.kebab.Y + b.kebab.H,
+		W: menuW,
+		H: float32(count) * itemH,
+	}
+	fg := ctx.ForegroundDrawList
+	if fg == nil {
+		fg = ctx.DrawList
+	}
+	fg.AddRect(b.menu.X, b.menu.Y, b.menu.W, b.menu.H, RGBA(20, 20, 25, 255))
+	fg.AddRectOutline(b.menu.X, b.menu.Y, b.menu.W, b.menu.H, ctx.style.InputBorderColor, ctx.style.BorderSize)
+}
+
+// menuItem draws an overflowed item as a dropdown entry (when open).
+func (b *OverflowBuilder) menuItem(id ID, label string) bool {
+	ctx := b.ctx
+	k := b.overIdx
+	b.overIdx++
+	b.menuW = maxf(b.menuW, ctx.MeasureText(label).X+ctx.style.ItemSpacing*2)
+	if !b.state.Open {
+		return false
+	}
+
+	savedInPopup := ctx.inPopup
+	ctx.inPopup = true
+	defer func() { ctx.inPopup = savedInPopup }()
+
+	fg := ctx.ForegroundDrawList
+	if fg == nil {
+		fg = ctx.DrawList
+	}
+	itemH := ctx.lineHeight() + ctx.style.ItemSpacing
+	rect := Rect{X: b.menu.X + 2, Y: b.menu.Y + float32(k)*itemH, W: b.menu.W - 4, H: itemH}
+
+	hovered, _, clicked := ctx.buttonBehavior(id, rect, false, false)
+	selected := b.state.KeyboardIndex == k
+	if selected || hovered {
+		fg.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.SelectedBgColor)
+	}
+	if selected && ctx.DebugFocusHighlight {
+		fg.AddRectOutline(rect.X, rect.Y, rect.W, rect.H, DebugFocusBorderColor, 3)
+	}
+	textColor := ctx.style.TextColor
+	if selected {
+		textColor = ctx.style.SelectedTextColor
+	}
+	ctx.addTextTo(fg, rect.X+ctx.style.ItemSpacing, rect.Y+ctx.style.ItemSpacing/2, label, textColor)
+
+	if selected && !b.justOpened && ctx.Input != nil && ctx.Input.KeyPressed(KeyEnter) {
+		clicked = true
+	}
+	if clicked {
+		b.state.Open = false
+		ctx.SetActivePopup(0)
+	}
+	return clicked
+}
+
+// End finishes the row: it handles the dropdown's keyboard navigation and
+// closing, saves the measurements for next frame, and advances the cursor.
+func (b *OverflowBuilder) End() {
+	ctx := b.ctx
+	ctx.PopID()
+
+	b.state.Widths = append(b.state.Widths[:0], b.widths...)
+	b.state.Overflowed = b.overIdx
+	b.state.MenuWidth = b.menuW
+
+	if !b.overflowing {
+		b.state.Open = false
+	}
+	if b.state.Open {
+		if ctx.Input != nil {
+			if ctx.Input.KeyRepeated(KeyDown) {
+				b.state.KeyboardIndex = min(b.state.KeyboardIndex+1, b.overIdx-1)
+			}
+			if ctx.Input.KeyRepeated(KeyUp) {
+				b.state.KeyboardIndex = max(b.state.KeyboardIndex-1, 0)
+			}
+
+			// Close on click outside the kebab and the dropdown
+			if ctx.Input.MouseClicked(MouseButtonLeft) && !b.justOpened {
+				mouse := Vec2{ctx.Input.MouseX, ctx.Input.MouseY}
+				if !b.menu.Contains(mouse) && !b.kebab.Contains(mouse) {
+					b.state.Open = false
+				}
+			}
+		}
+		if !GetOpt(b.o, OptNoEscape) {
+			if ctx.escapeFor(escapePopup, b.id) {
+				b.state.Open = false
+			} else {
+				ctx.claimEscape(escapePopup, b.id)
+			}
+		}
+		if b.state.Open {
+			ctx.registerPopupRect(b.id, b.menu)
+		} else {
+			ctx.SetActivePopup(0)
+		}
+	} else if ctx.ActivePopupID() == b.id {
+		ctx.SetActivePopup(0)
+	}
+
+	ctx.advanceCursor(Vec2{X: b.x - b.startX, Y: b.rowH})
+}
@@ -50,6 +50,15 @@ type PanelEntry struct {
 	NeedsCursor bool        // If true, opening this panel releases cursor
 	BlockedBy   []string    // Panel names that block this panel's hotkey
 	Global      bool        // If true, hotkey works in all modes (not just model view)
+
+	zOrder uint64 // Raise counter from BringToFront (0 = never raised)
+}
+
+// BoundedPanel is implemented by panels that know their screen rectangle,
+// such as those embedding a DraggablePanel. PanelRegistry uses it to bring
+// a panel to the front when it is clicked.
+type BoundedPanel interface {
+	Bounds() Rect
 }
 
 // IsCloseKeyPressed returns true if the panel's close key is pressed.
@@ -93,6 +102,8 @@ type PanelRegistry struct {
 	onCursorChange    CursorChangeCallback // Called when cursor state should change
 	cursorReleased    bool                 // Current cursor state
 	focusManager      *FocusManager        // Focus management for Ctrl+Tab cycling
+	zCounter          uint64               // Last value handed out by BringToFront
+	drawOrder         []int                // Scratch buffer for Draw (entry indices, back to front)
 }
 
 // NewPanelRegistry creates a new panel registry.
@@ -410,14 +421,92 @@ func (r *PanelRegistry) HandleInput(input *InputState) bool {
 	return false
 }
 
-// Draw renders all open panels.
+// Draw renders all open panels back to front.
+// Panels are stacked by priority (lowest priority behind) until raised with
+// BringToFront; the focused panel is always drawn last. Clicking inside a
+// BoundedPanel, or dragging one, raises it before drawing.
 func (r *PanelRegistry) Draw(ctx *Context) {
-	// Draw in reverse priority order (lowest priority drawn first = behind)
-	for i := len(r.entries) - 1; i >= 0; i-- {
+	r.raiseFromInput(ctx.Input)
+	for _, i := range r.computeDrawOrder() {
 		r.entries[i].Panel.Draw(ctx)
 	}
 }
 
+// BringToFront raises a panel above all others and gives it focus.
+// The order persists across frames.
+func (r *PanelRegistry) BringToFront(panel Panel) {
+	for i := range r.entries {
+		if r.entries[i].Panel == panel {
+			r.zCounter++
+			r.entries[i].zOrder = r.zCounter
+			r.focusManager.setFocused(panel)
+			return
+		}
+	}
+}
+
+// computeDrawOrder returns entry indices back to front.
+func (r *PanelRegistry) computeDrawOrder() []int {
+	focused := r.focusManager.FocusedPanel()
+	key := func(i int) uint64 {
+		if r.entries[i].Panel == focused {
+			return ^uint64(0)
+		}
+		return r.entries[i].zOrder
+	}
+
+	// Start in reverse priority order, then stable insertion sort by raise order
+	r.drawOrder = r.drawOrder[:0]
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		r.drawOrder = append(r.drawOrder, i)
+	}
+	for i := 1; i < len(r.drawOrder); i++ {
+		idx := r.drawOrder[i]
+		j := i - 1
+		for j >= 0 && key(r.drawOrder[j]) > key(idx) {
+			r.drawOrder[j+1] = r.drawOrder[j]
+			j--
+		}
+		r.drawOrder[j+1] = idx
+	}
+	return r.drawOrder
+}
+
+// raiseFromInput brings the topmost open panel under a click to the front,
+// as well as any panel that is being dragged.
+func (r *PanelRegistry) raiseFromInput(input *InputState) {
+	if input == nil {
+		return
+	}
+	order := r.computeDrawOrder()
+	for k := len(order) - 1; k >= 0; k-- {
+		e := &r.entries[order[k]]
+		if !e.Panel.IsOpen() {
+			continue
+		}
+		if d, ok := e.Panel.(interface{ IsDragging() bool }); ok && d.IsDragging() {
+			if k != len(order)-1 {
+				r.BringToFront(e.Panel)
+			}
+			return
+		}
+	}
+	if !input.MouseClicked(MouseButtonLeft) {
+		return
+	}
+	mouse := Vec2{X: input.MouseX, Y: input.MouseY}
+	for k := len(order) - 1; k >= 0; k-- {
+		e := &r.entries[order[k]]
+		if !e.Panel.IsOpen() {
+			continue
+		}
+		if b, ok := e.Panel.(BoundedPanel); ok && b.Bounds().Contains(mouse) {
+			r.BringToFront(e.Panel)
+			return
+		}
+	}
+}
+
 // sortByPriority sorts entries by priority (highest first).
 func (r *PanelRegistry) sortByPriority() {
 	// Simple insertion sort (small list)
@@ -3,7 +3,8 @@ package gui
 import "fmt"
 
 // RadioGroup draws a group of radio buttons arranged vertically.
-// Returns true if the selection changed.
+// Returns true if the selection changed, which also fires WithOnChange and
+// the change observer with the new index.
 //
 // Usage:
 //
@@ -17,6 +18,7 @@ func (ctx *Context) RadioGroup(label string, selectedIndex *int, items []string,
 	changed := false
 	columns := GetOpt(o, OptColumns)
 	optID := GetOpt(o, OptID)
+	id := ctx.radioGroupID(label, optID)
 
 	ctx.VStack(Gap(ctx.style.ItemSpacing))(func() {
 		// Draw label if provided
@@ -61,6 +63,7 @@ func (ctx *Context) RadioGroup(label string, selectedIndex *int, items []string,
 		}
 	})
 
+	ctx.notifyChange(id, o, BuiltinComponents.RadioGroup, label, *selectedIndex, changed, false)
 	return changed
 }
 
@@ -78,6 +81,7 @@ func (ctx *Context) RadioGroupHorizontal(label string, selectedIndex *int, items
 
 	changed := false
 	optID := GetOpt(o, OptID)
+	id := ctx.radioGroupID(label, optID)
 
 	ctx.VStack(Gap(ctx.style.ItemSpacing))(func() {
 		// Draw label if provided
@@ -100,5 +104,14 @@ func (ctx *Context) RadioGroupHorizontal(label string, selectedIndex *int, items
 		})
 	})
 
+	ctx.notifyChange(id, o, BuiltinComponents.RadioGroup, label, *selectedIndex, changed, false)
 	return changed
 }
+
+// radioGroupID returns the ID a radio group reports its changes under.
+func (ctx *Context) radioGroupID(label, optID string) ID {
+	if optID != "" {
+		return ctx.GetID(optID)
+	}
+	return ctx.GetID(label)
+}
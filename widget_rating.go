@@ -0,0 +1,161 @@
+package gui
+
+import "math"
+
+// ratingStarGap is the horizontal space between stars.
+const ratingStarGap = SpaceXS
+
+// ratingStarInner is the inner radius of a star relative to its outer radius.
+const ratingStarInner = 0.4
+
+// Rating draws max stars filled up to *value, for feedback and review UIs.
+// Hovering previews the rating under the cursor and clicking sets it;
+// Left/Right adjust it when focused. Returns true if the value changed.
+//
+// With WithHalfStars() each star has two halves and *value counts half
+// stars, from 0 to 2*max: hovering the left half of a star previews a half.
+//
+// Usage:
+//
+//	if ctx.Rating("Quality", &stars, 5) {
+//	    saveFeedback(stars)
+//	}
+func (ctx *Context) Rating(label string, value *int, max int, opts ...Option) bool {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+
+	half := GetOpt(o, OptHalfStars)
+	unitsPerStar := 1
+	if half {
+		unitsPerStar = 2
+	}
+	maxUnits := max * unitsPerStar
+
+	// Calculate dimensions
+	labelWidth := float32(0)
+	if label != "" {
+		labelWidth = ctx.MeasureText(label).X + ctx.style.ItemSpacing
+	}
+	starSize := ctx.lineHeight()
+	cell := starSize + ratingStarGap
+	starsX := pos.X + labelWidth
+	rect := Rect{X: starsX, Y: pos.Y, W: float32(max)*cell - ratingStarGap, H: starSize}
+
+	if label != "" {
+		ctx.addText(pos.X, pos.Y, label, ctx.style.TextColor)
+	}
+
+	disabled := GetOpt(o, OptDisabled)
+	var focusable *FocusableHandle
+	if disabled {
+		ctx.RegisterFocusableDisabled(id, label, rect, FocusTypeLeaf)
+	} else {
+		focusable = ctx.RegisterFocusable(id, label, rect, FocusTypeLeaf)
+	}
+	isFocused := focusable != nil && focusable.IsFocused()
+
+	hovered, _, clicked := ctx.buttonBehavior(id, rect, false, disabled)
+	hovered = hovered && !disabled
+
+	// Map the cursor to a value: each star owns the gap after it, so there
+	// is no dead zone between stars
+	hoverValue := 0
+	if hovered {
+		relX := ctx.Input.MouseX - starsX
+		star := min(int(relX/cell), max-1)
+		hoverValue = (star + 1) * unitsPerStar
+		if half && relX-float32(star)*cell < starSize/2 {
+			hoverValue--
+		}
+	}
+
+	changed := false
+	setValue := func(v int) {
+		v = min(maxUnits, v)
+		if v < 0 {
+			v = 0
+		}
+		if v != *value {
+			*value = v
+			changed = true
+		}
+	}
+	if clicked {
+		setValue(hoverValue)
+	}
+	if isFocused && ctx.Input != nil {
+		if ctx.Input.KeyRepeated(KeyLeft) {
+			setValue(*value - 1)
+		}
+		if ctx.Input.KeyRepeated(KeyRight) {
+			setValue(*value + 1)
+		}
+	}
+
+	// Draw stars: the hover preview replaces the value while hovered
+	shown := *value
+	if hovered {
+		shown = hoverValue
+	}
+	emptyColor := ctx.style.SliderTrackColor
+	fillColor := ctx.style.SliderFillColor
+	if hovered || isFocused {
+		fillColor = ctx.style.SliderGrabHovered
+	}
+	if disabled {
+		fillColor = ctx.style.TextDisabledColor
+	}
+	for i := 0; i < max; i++ {
+		center := Vec2{X: starsX + float32(i)*cell + starSize/2, Y: pos.Y + starSize/2}
+		filled := shown - i*unitsPerStar
+		switch {
+		case filled >= unitsPerStar:
+			addStar(ctx.DrawList, center, starSize/2, fillColor)
+		case filled > 0:
+			// Half star: empty star with the left half filled over it
+			addStar(ctx.DrawList, center, starSize/2, emptyColor)
+			ctx.pushClipRect(Rect{X: center.X - starSize/2, Y: pos.Y, W: starSize / 2, H: starSize})
+			addStar(ctx.DrawList, center, starSize/2, fillColor)
+			ctx.popClipRect()
+		default:
+			addStar(ctx.DrawList, center, starSize/2, emptyColor)
+		}
+	}
+
+	ctx.notifyChange(id, o, BuiltinComponents.Rating, label, *value, changed, false)
+	ctx.advanceCursor(Vec2{X: labelWidth + rect.W, Y: starSize})
+	return changed
+}
+
+// starPoints holds a unit five-pointed star, point up: outer and inner
+// vertices alternating clockwise.
+var starPoints = func() [10]Vec2 {
+	var pts [10]Vec2
+	for i := range pts {
+		r := 1.0
+		if i%2 == 1 {
+			r = ratingStarInner
+		}
+		angle := -math.Pi/2 + float64(i)*math.Pi/5
+		pts[i] = Vec2{X: float32(r * math.Cos(angle)), Y: float32(r * math.Sin(angle))}
+	}
+	return pts
+}()
+
+// addStar draws a filled five-pointed star. The star isn't convex, so it is
+// drawn as five convex kites (center, inner, tip, inner) around the center.
+func addStar(dl *DrawList, center Vec2, radius float32, color uint32) {
+	var kite [4]Vec2
+	for i := 0; i < 5; i++ {
+		kite[0] = center
+		kite[1] = center.Add(starPoints[(i*2+9)%10].Mul(radius))
+		kite[2] = center.Add(starPoints[i*2].Mul(radius))
+		kite[3] = center.Add(starPoints[i*2+1].Mul(radius))
+		dl.AddConvexPolyFilled(kite[:], color)
+	}
+}
@@ -0,0 +1,83 @@
+package gui
+
+// rubberBandDash is the dash length of the selection outline.
+const rubberBandDash = 4
+
+// RubberBand implements marquee selection inside area: pressing the left
+// mouse button on empty space in the area and dragging draws a translucent
+// selection rectangle. Call it after drawing the area's contents so that
+// widgets inside the area (which claim the press first) keep working.
+//
+// selRect is the normalized selection clamped to area. active is true while
+// dragging past Style.DragThreshold; completed is true on the frame the
+// mouse is released, with selRect holding the final selection. A click
+// without dragging reports neither.
+//
+// Usage:
+//
+//	drawNodes(ctx)
+//	if sel, active, done := ctx.RubberBand("nodes", canvasRect); active || done {
+//	    highlightNodesIn(sel)
+//	    if done {
+//	        selectNodesIn(sel)
+//	    }
+//	}
+func (ctx *Context) RubberBand(id string, area Rect) (selRect Rect, active, completed bool) {
+	if ctx.Input == nil {
+		return Rect{}, false, false
+	}
+	wid := ctx.GetID(id)
+
+	if ctx.activeID == 0 && ctx.Input.MouseClicked(MouseButtonLeft) && ctx.isHovered(wid, area) {
+		ctx.activeID = wid
+	}
+	if ctx.activeID != wid {
+		return Rect{}, false, false
+	}
+
+	released := ctx.Input.MouseReleased(MouseButtonLeft)
+	if !released && !ctx.Input.MouseDown(MouseButtonLeft) {
+		// Release happened while the area wasn't drawn - drop the stale press
+		ctx.activeID = 0
+		return Rect{}, false, false
+	}
+	if released {
+		ctx.activeID = 0
+	}
+	if !ctx.IsMouseDragPastThreshold(MouseButtonLeft) {
+		return Rect{}, false, false
+	}
+
+	mouse := Vec2{X: ctx.Input.MouseX, Y: ctx.Input.MouseY}
+	start := mouse.Sub(ctx.Input.MouseDragDelta(MouseButtonLeft))
+	selRect = Rect{
+		X: minf(start.X, mouse.X),
+		Y: minf(start.Y, mouse.Y),
+		W: absf(mouse.X - start.X),
+		H: absf(mouse.Y - start.Y),
+	}.Intersect(area)
+
+	if released {
+		return selRect, false, true
+	}
+
+	color := ctx.style.SelectedBgColor
+	ctx.DrawList.AddRect(selRect.X, selRect.Y, selRect.W, selRect.H, color&0x00FFFFFF|0x40000000)
+	addDashedRectOutline(ctx.DrawList, selRect, color|0xFF000000, rubberBandDash)
+	return selRect, true, false
+}
+
+// addDashedRectOutline draws a 1px dashed rectangle outline.
+func addDashedRectOutline(dl *DrawList, r Rect, color uint32, dash float32) {
+	x2, y2 := r.X+r.W, r.Y+r.H
+	for x := r.X; x < x2; x += dash * 2 {
+		end := minf(x+dash, x2)
+		dl.AddRect(x, r.Y, end-x, 1, color)
+		dl.AddRect(x, y2-1, end-x, 1, color)
+	}
+	for y := r.Y; y < y2; y += dash * 2 {
+		end := minf(y+dash, y2)
+		dl.AddRect(r.X, y, 1, end-y, color)
+		dl.AddRect(x2-1, y, 1, end-y, color)
+	}
+}
@@ -24,12 +24,12 @@ var scrollableStore = NewFrameStore[ScrollableState]()
 func EnsureScrollVisible(ctx *Context, scrollID string, targetY, viewportHeight, padding float32) {
 	// Look up the stored ID from the name->ID map (set when Scrollable is rendered)
 	fullName := scrollID + "_scrollable"
-	storedID, ok := scrollableNameToID[fullName]
+	storedID, ok := ctx.scrollableIDs[fullName]
 	if !ok {
 		// Scrollable hasn't been rendered yet, nothing to scroll
 		return
 	}
-	state := scrollableStore.Get(storedID, ScrollableState{})
+	state := scrollableStore.GetFor(ctx, storedID, ScrollableState{})
 
 	maxScroll := maxf(0, state.ContentHeight-viewportHeight)
 
@@ -67,10 +67,13 @@ func (ctx *Context) Scrollable(id string, height float32, opts ...Option) func(f
 		scrollID := ctx.GetID(id + "_scrollable")
 		// Initialize with UserScrollTime > cooldown so auto-scroll works immediately
 		// The cooldown only applies AFTER the user has manually scrolled
-		state := scrollableStore.Get(scrollID, ScrollableState{UserScrollTime: 1.0})
+		state := scrollableStore.GetFor(ctx, scrollID, ScrollableState{UserScrollTime: 1.0})
 
 		// Register name -> ID mapping for GetScrollableState lookup
-		scrollableNameToID[id+"_scrollable"] = scrollID
+		if ctx.scrollableIDs == nil {
+			ctx.scrollableIDs = make(map[string]ID)
+		}
+		ctx.scrollableIDs[id+"_scrollable"] = scrollID
 
 		// Save position BEFORE pushing scrollable (needed for contentOrigin calculation)
 		x, y := ctx.cursor.X, ctx.cursor.Y
@@ -105,7 +108,7 @@ func (ctx *Context) Scrollable(id string, height float32, opts ...Option) func(f
 		ctx.BeginFocusScope(scrollID, id, FocusTypeContainer, viewportRect)
 
 		// Push clip rect for visible area
-		ctx.DrawList.PushClipRect(contentX, y, contentX+contentWidth, y+height)
+		ctx.pushClipRect(Rect{X: contentX, Y: y, W: contentWidth, H: height})
 
 		// Offset cursor by scroll
 		ctx.cursor.X = contentX
@@ -155,7 +158,7 @@ func (ctx *Context) Scrollable(id string, height float32, opts ...Option) func(f
 		state.ContentWidth = bounds.W
 
 		// Pop clip rect
-		ctx.DrawList.PopClipRect()
+		ctx.popClipRect()
 
 		// Pop scrollable from stack and get any focus set by children via ctx.ScrollTo()
 		scrollFocus.y, scrollFocus.padding, scrollFocus.ok = ctx.popScrollable()
@@ -308,7 +311,8 @@ func (ctx *Context) Scrollable(id string, height float32, opts ...Option) func(f
 			// Mouse wheel vertical scrolling
 			if ctx.Input.MouseWheelY != 0 {
 				maxScroll := maxf(0, state.ContentHeight-height)
-				newScroll := clampf(state.ScrollY-ctx.Input.MouseWheelY*30, 0, maxScroll)
+				scroll, _ := ctx.wheelScrollLines(ctx.Input.MouseWheelY, GetOpt(o, OptScrollLines), ctx.lineHeight())
+				newScroll := clampf(state.ScrollY-scroll, 0, maxScroll)
 				if GetOpt(o, OptClampToContent) {
 					newScroll = clampf(newScroll, 0, maxScroll)
 				}
@@ -321,7 +325,8 @@ func (ctx *Context) Scrollable(id string, height float32, opts ...Option) func(f
 			// Mouse wheel horizontal scrolling (with Shift or if enabled)
 			if horizontalScroll && ctx.Input.MouseWheelX != 0 {
 				maxScroll := maxf(0, state.ContentWidth-contentWidth)
-				newScroll := clampf(state.ScrollX-ctx.Input.MouseWheelX*30, 0, maxScroll)
+				scroll, _ := ctx.wheelScroll(ctx.Input.MouseWheelX)
+				newScroll := clampf(state.ScrollX-scroll, 0, maxScroll)
 				state.ScrollX = newScroll
 				state.UserScrolledThisFrame = true
 				state.UserScrollTime = 0
@@ -358,74 +363,12 @@ func (ctx *Context) Scrollable(id string, height float32, opts ...Option) func(f
 
 		// Draw scrollbar if content exceeds height
 		if showScrollbar && state.ContentHeight > height {
-			// Calculate scrollbar thumb size and position
-			scrollRatio := height / state.ContentHeight
-			thumbHeight := maxf(20, height*scrollRatio)
-			maxScroll := state.ContentHeight - height
-			thumbPos := float32(0)
-			if maxScroll > 0 {
-				thumbPos = (state.ScrollY / maxScroll) * (height - thumbHeight)
-			}
-
-			thumbY := y + thumbPos
-
-			// Scrollbar background
-			ctx.DrawList.AddRect(scrollbarX, y, scrollbarWidth, height, ctx.style.ScrollbarBgColor)
-
-			// Check if scrollbar thumb is hovered or being dragged
-			thumbRect := Rect{X: scrollbarX, Y: thumbY, W: scrollbarWidth, H: thumbHeight}
-			thumbHovered := ctx.isHovered(scrollID, thumbRect)
-
-			// Handle scrollbar dragging
-			if ctx.Input != nil {
-				// Start drag on thumb click
-				if thumbHovered && ctx.Input.MouseClicked(MouseButtonLeft) {
-					state.Dragging = true
-					state.DragStartY = ctx.Input.MouseY
-					state.DragStartScr = state.ScrollY
-				}
-
-				// Handle ongoing drag
-				if state.Dragging {
-					if ctx.Input.MouseDown(MouseButtonLeft) {
-						deltaY := ctx.Input.MouseY - state.DragStartY
-						// Convert pixel delta to scroll delta
-						scrollableTrack := height - thumbHeight
-						if scrollableTrack > 0 {
-							scrollDelta := deltaY * (maxScroll / scrollableTrack)
-							state.ScrollY = clampf(state.DragStartScr+scrollDelta, 0, maxScroll)
-						}
-						// Track scrollbar drag as user interaction
-						state.UserScrolledThisFrame = true
-						state.UserScrollTime = 0
-					} else {
-						state.Dragging = false
-					}
-				}
-
-				// Click on track (above or below thumb) to page scroll
-				scrollbarRect := Rect{X: scrollbarX, Y: y, W: scrollbarWidth, H: height}
-				if !thumbHovered && ctx.isHovered(scrollID, scrollbarRect) && ctx.Input.MouseClicked(MouseButtonLeft) {
-					if ctx.Input.MouseY < thumbY {
-						// Click above thumb - scroll up
-						state.ScrollY = clampf(state.ScrollY-height, 0, maxScroll)
-						state.UserScrolledThisFrame = true
-						state.UserScrollTime = 0
-					} else if ctx.Input.MouseY > thumbY+thumbHeight {
-						// Click below thumb - scroll down
-						state.ScrollY = clampf(state.ScrollY+height, 0, maxScroll)
-						state.UserScrolledThisFrame = true
-						state.UserScrollTime = 0
-					}
-				}
-			}
-
-			// Scrollbar thumb
-			thumbColor := ctx.style.ScrollbarGrabColor
-			if state.Dragging || thumbHovered {
-				thumbColor = ctx.style.ScrollbarGrabHovered
+			track := Rect{X: scrollbarX, Y: y, W: scrollbarWidth, H: height}
+			if ctx.scrollbarV(scrollID, track, height, state.ContentHeight, &state.ScrollY, &state.Dragging, &state.DragStartY, &state.DragStartScr) {
+				// Track scrollbar use as user interaction
+				state.UserScrolledThisFrame = true
+				state.UserScrollTime = 0
 			}
-			ctx.DrawList.AddRect(scrollbarX, thumbY, scrollbarWidth, thumbHeight, thumbColor)
 		}
 
 		// Draw horizontal scrollbar if enabled and content exceeds width
@@ -465,17 +408,80 @@ func (ctx *Context) Scrollable(id string, height float32, opts ...Option) func(f
 	}
 }
 
-// scrollableNameToID maps scrollable names to their IDs for lookup.
-// This enables GetScrollableState to find state by name instead of ID.
-var scrollableNameToID = make(map[string]ID)
+// scrollbarV draws a vertical scrollbar in track for content of height
+// contentH seen through a viewport of height viewH, and handles it: the
+// thumb can be dragged, and a click on the track above or below it pages
+// by viewH. scrollY is the scroll offset; dragging, dragStartY and
+// dragStartScroll persist the thumb drag between frames. Returns true if
+// the scrollbar was used this frame.
+func (ctx *Context) scrollbarV(id ID, track Rect, viewH, contentH float32, scrollY *float32, dragging *bool, dragStartY, dragStartScroll *float32) bool {
+	maxScroll := contentH - viewH
+	if maxScroll <= 0 {
+		*dragging = false
+		return false
+	}
+
+	// Calculate scrollbar thumb size and position
+	thumbH := maxf(20, track.H*viewH/contentH)
+	thumbY := track.Y + (*scrollY/maxScroll)*(track.H-thumbH)
+
+	// Scrollbar background
+	ctx.DrawList.AddRect(track.X, track.Y, track.W, track.H, ctx.style.ScrollbarBgColor)
+
+	// Check if scrollbar thumb is hovered or being dragged
+	thumbRect := Rect{X: track.X, Y: thumbY, W: track.W, H: thumbH}
+	thumbHovered := ctx.isHovered(id, thumbRect)
+
+	used := false
+	if input := ctx.Input; input != nil {
+		// Start drag on thumb click
+		if thumbHovered && input.MouseClicked(MouseButtonLeft) {
+			*dragging = true
+			*dragStartY = input.MouseY
+			*dragStartScroll = *scrollY
+		}
+
+		// Handle ongoing drag, converting the pixel delta to a scroll delta
+		if *dragging {
+			if input.MouseDown(MouseButtonLeft) {
+				if movable := track.H - thumbH; movable > 0 {
+					delta := (input.MouseY - *dragStartY) * (maxScroll / movable)
+					*scrollY = clampf(*dragStartScroll+delta, 0, maxScroll)
+				}
+				used = true
+			} else {
+				*dragging = false
+			}
+		}
+
+		// Click on track (above or below thumb) to page scroll
+		if !thumbHovered && ctx.isHovered(id, track) && input.MouseClicked(MouseButtonLeft) {
+			if input.MouseY < thumbY {
+				*scrollY = clampf(*scrollY-viewH, 0, maxScroll)
+				used = true
+			} else if input.MouseY > thumbY+thumbH {
+				*scrollY = clampf(*scrollY+viewH, 0, maxScroll)
+				used = true
+			}
+		}
+	}
+
+	// Scrollbar thumb
+	thumbColor := ctx.style.ScrollbarGrabColor
+	if *dragging || thumbHovered {
+		thumbColor = ctx.style.ScrollbarGrabHovered
+	}
+	ctx.DrawList.AddRect(track.X, thumbY, track.W, thumbH, thumbColor)
+	return used
+}
 
 // GetScrollableState returns a pointer to the scrollable's state for advanced manipulation.
 // Returns nil if the scrollable hasn't been rendered yet.
 // Note: This returns state from the FrameStore which persists across frames until cleanup.
 func GetScrollableState(ctx *Context, id string) *ScrollableState {
 	fullName := id + "_scrollable"
-	if storedID, ok := scrollableNameToID[fullName]; ok {
-		return scrollableStore.GetIfExists(storedID)
+	if storedID, ok := ctx.scrollableIDs[fullName]; ok {
+		return scrollableStore.GetIfExistsFor(ctx, storedID)
 	}
 	return nil
 }
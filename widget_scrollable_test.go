@@ -92,6 +92,46 @@ func TestScrollableMouseWheelScrolls(t *testing.T) {
 	}
 }
 
+func TestScrollableWheelSpeed(t *testing.T) {
+	style := gui.GTAStyle()
+	style.ScrollSpeed = 10
+	ui := gui.New(&mockRenderer{}, gui.WithStyle(style))
+	input := gui.NewInputState()
+	displaySize := gui.Vec2{X: 800, Y: 600}
+
+	var last float32
+	scrollBy := func(wheel float32) float32 {
+		input.Reset()
+		input.SetMousePos(50, 50)
+		input.MouseWheelY = wheel
+		ctx := ui.Begin(input, displaySize, 0.016)
+		ctx.Scrollable("wheel_speed_scroll", 100)(func() {
+			for i := 0; i < 50; i++ {
+				ctx.Text("Line")
+			}
+		})
+		_ = ui.End()
+		y := getScrollableState(ctx, "wheel_speed_scroll").ScrollY
+		delta := y - last
+		last = y
+		return delta
+	}
+
+	scrollBy(0)
+	// Notched wheel: whole steps scaled by ScrollSpeed
+	if got := scrollBy(-2); got != 20 {
+		t.Errorf("notched wheel scrolled %v, want 20", got)
+	}
+	// Trackpad: fractional deltas scroll proportionally
+	if got := scrollBy(-0.5); got != 5 {
+		t.Errorf("fractional wheel scrolled %v, want 5", got)
+	}
+	// Large deltas are already pixels
+	if got := scrollBy(-40); got != 40 {
+		t.Errorf("pixel wheel scrolled %v, want 40", got)
+	}
+}
+
 func TestScrollableUserScrollResetsTimer(t *testing.T) {
 	ui, input := setupScrollableTest()
 	displaySize := gui.Vec2{X: 800, Y: 600}
@@ -476,3 +516,35 @@ func BenchmarkScrollableWithScrollTo(b *testing.B) {
 		_ = ui.End()
 	}
 }
+
+func TestScrollableWheelLines(t *testing.T) {
+	ui, input := setupScrollableTest()
+	displaySize := gui.Vec2{X: 800, Y: 600}
+
+	var last, lineH float32
+	scrollBy := func(wheel float32) float32 {
+		input.Reset()
+		input.SetMousePos(50, 50)
+		input.MouseWheelY = wheel
+		ctx := ui.Begin(input, displaySize, 0.016)
+		lineH = ctx.MeasureText("Line").Y
+		ctx.Scrollable("wheel_lines_scroll", 100, gui.WithScrollLines(2))(func() {
+			for i := 0; i < 50; i++ {
+				ctx.Text("Line")
+			}
+		})
+		_ = ui.End()
+		y := getScrollableState(ctx, "wheel_lines_scroll").ScrollY
+		delta := y - last
+		last = y
+		return delta
+	}
+
+	scrollBy(0)
+	if got, want := scrollBy(-1), 2*lineH; got != want {
+		t.Errorf("one notch scrolled %v, want two lines (%v)", got, want)
+	}
+	if got, want := scrollBy(-0.25), lineH/2; got != want {
+		t.Errorf("fractional notch scrolled %v, want %v", got, want)
+	}
+}
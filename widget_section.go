@@ -66,7 +66,7 @@ func (ctx *Context) BeginSection(label string, opts ...Option) bool {
 
 	// Get stored state - default to closed unless DefaultOpen() is specified
 	defaultOpen := GetOpt(o, OptDefaultOpen)
-	state := sectionStore.Get(id, SectionState{Open: defaultOpen})
+	state := sectionStore.GetFor(ctx, id, SectionState{Open: defaultOpen})
 
 	// Check for controlled mode (external state via pointer)
 	openOpt := GetOpt(o, OptOpen)
@@ -111,7 +111,7 @@ func (ctx *Context) BeginSection(label string, opts ...Option) bool {
 	} else if hovered {
 		bgColor = ctx.style.ButtonHoveredColor
 	}
-	ctx.DrawList.AddRect(pos.X, pos.Y, w, h, bgColor)
+	ctx.fillRectV(pos.X, pos.Y, w, h, bgColor, ctx.style.HeaderGradient)
 
 	// Draw debug focus rect for sections when registry-focused (FocusTypeSection skips auto-draw)
 	if registryFocused && ctx.DebugFocusHighlight {
@@ -204,20 +204,20 @@ func (ctx *Context) EndSection() {
 //	sectionID := ctx.GetID("my_section")
 //	gui.ToggleSectionState(ctx, sectionID)
 func ToggleSectionState(ctx *Context, id ID) {
-	state := sectionStore.Get(id, SectionState{Open: false})
+	state := sectionStore.GetFor(ctx, id, SectionState{Open: false})
 	state.Open = !state.Open
 }
 
 // SetSectionOpen sets the open/closed state of a section.
 // Use this for external control (e.g., keyboard shortcuts).
 func SetSectionOpen(ctx *Context, id ID, open bool) {
-	state := sectionStore.Get(id, SectionState{Open: false})
+	state := sectionStore.GetFor(ctx, id, SectionState{Open: false})
 	state.Open = open
 }
 
 // IsSectionOpen returns whether a section is currently open.
 func IsSectionOpen(ctx *Context, id ID) bool {
-	state := sectionStore.GetIfExists(id)
+	state := sectionStore.GetIfExistsFor(ctx, id)
 	if state == nil {
 		return false
 	}
@@ -226,6 +226,6 @@ func IsSectionOpen(ctx *Context, id ID) bool {
 
 // GetSectionState returns a pointer to the section's state for advanced manipulation.
 // Returns nil if the section hasn't been rendered yet this frame.
-func GetSectionState(id ID) *SectionState {
-	return sectionStore.GetIfExists(id)
+func GetSectionState(ctx *Context, id ID) *SectionState {
+	return sectionStore.GetIfExistsFor(ctx, id)
 }
@@ -202,7 +202,7 @@ func (ctx *Context) Sequencer(id string, config SequencerConfig, height float32,
 
 		// Handle track hover/click
 		trackRect := Rect{X: pos.X, Y: trackY, W: trackLabelWidth, H: trackHeight}
-		if ctx.Input != nil && trackRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY}) {
+		if ctx.isHovered(seqID, trackRect) {
 			state.HoveredTrack = track.Name
 			if ctx.Input.MouseClicked(MouseButtonLeft) {
 				state.SelectedTrack = track.Name
@@ -258,7 +258,7 @@ func (ctx *Context) Sequencer(id string, config SequencerConfig, height float32,
 
 	if ctx.Input != nil {
 		// Scrubbing (click/drag on timeline)
-		if timelineRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY}) {
+		if ctx.isHovered(seqID, timelineRect) {
 			if ctx.Input.MouseClicked(MouseButtonLeft) {
 				state.Scrubbing = true
 			}
@@ -308,7 +308,7 @@ func (ctx *Context) Sequencer(id string, config SequencerConfig, height float32,
 		}
 
 		// Space to toggle play/pause
-		if ctx.Input.KeyPressed(KeySpace) && timelineRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY}) {
+		if ctx.Input.KeyPressed(KeySpace) && ctx.isHovered(seqID, timelineRect) {
 			if config.Playing {
 				if config.OnPause != nil {
 					config.OnPause()
@@ -322,7 +322,7 @@ func (ctx *Context) Sequencer(id string, config SequencerConfig, height float32,
 	}
 
 	// Draw border
-	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.BorderColor, 1)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.BorderColor, ctx.style.BorderSize)
 
 	// Draw separator between label area and timeline
 	ctx.DrawList.AddLine(timelineX, pos.Y, timelineX, pos.Y+height, ctx.style.BorderColor, 1)
@@ -346,7 +346,7 @@ func (ctx *Context) drawSequencerControls(x, y, labelW, h float32, config *Seque
 	btnY := y + 2
 
 	btnRect := Rect{X: btnX, Y: btnY, W: btnSize, H: btnSize}
-	hovered := ctx.Input != nil && btnRect.Contains(Vec2{ctx.Input.MouseX, ctx.Input.MouseY})
+	hovered := ctx.isHovered(0, btnRect)
 
 	btnColor := ctx.style.ButtonColor
 	if hovered {
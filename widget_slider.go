@@ -1,17 +1,24 @@
 package gui
 
-import (
-	"fmt"
-	"strings"
-)
-
 // sliderStore is the type-safe store for slider state.
 // Uses the new FrameStore pattern instead of the old GetState/SetState.
 var sliderStore = NewFrameStore[SliderState]()
 
+// sliderVerticalHeight is the default track height of a vertical slider.
+const sliderVerticalHeight = float32(150)
+
 // SliderFloat draws a horizontal slider for float32 values.
 // Returns true if the value was changed.
 //
+// With WithVertical() the track runs top (max) to bottom (min), like a
+// mixer fader: the label goes above it and the value below. WithHeight
+// sets the track height and WithWidth its thickness; Up/Down adjust the
+// value as well as Left/Right.
+//
+// With WithTypeable(), double-clicking the slider replaces it with a text
+// field for typing an exact value. Enter commits (rounded to the WithFormat
+// precision and clamped to min/max), Escape reverts.
+//
 // Usage:
 //
 //	if ctx.SliderFloat("Volume", &volume, 0, 1) {
@@ -27,7 +34,7 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 	}
 
 	// Get slider state using the new type-safe store
-	state := sliderStore.Get(id, SliderState{})
+	state := sliderStore.GetFor(ctx, id, SliderState{})
 
 	// Calculate dimensions
 	labelWidth := float32(0)
@@ -35,21 +42,18 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 		labelWidth = ctx.MeasureText(label).X + ctx.style.ItemSpacing
 	}
 
-	sliderWidth := float32(150)
-	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
-		sliderWidth = optWidth
-	}
+	// In a LabeledRow the track fills the column but leaves room for the
+	// value text, sized for the widest end of the range so it doesn't jump
+	format := GetOpt(o, OptFormat)
+	valueRoom := maxf(ctx.MeasureText(formatNumber(format, minVal)).X,
+		ctx.MeasureText(formatNumber(format, maxVal)).X) + ctx.style.ItemSpacing
+	sliderWidth := ctx.itemWidth(o, 150, labelWidth+valueRoom)
 
 	trackHeight := ctx.lineHeight() * 0.5
 	h := ctx.lineHeight()
 	grabWidth := float32(12)
 	grabHeight := h
 
-	// Draw label
-	if label != "" {
-		ctx.addText(pos.X, pos.Y+(h-ctx.lineHeight())/2, label, ctx.style.TextColor)
-	}
-
 	// Track position
 	trackX := pos.X + labelWidth
 	trackY := pos.Y + (h-trackHeight)/2
@@ -57,6 +61,35 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 	// Interaction rect (covers the whole slider area)
 	rect := Rect{X: trackX, Y: pos.Y, W: sliderWidth, H: h}
 
+	// Vertical: label, track and value stacked in a column centered on the
+	// track; the grab spans the track's width and moves along its height
+	vertical := GetOpt(o, OptVertical)
+	columnWidth := float32(0)
+	if vertical {
+		rect.W = ctx.lineHeight()
+		if w := GetOpt(o, OptWidth); w > 0 {
+			rect.W = w
+		}
+		rect.H = sliderVerticalHeight
+		if vh := GetOpt(o, OptHeight); vh > 0 {
+			rect.H = vh
+		}
+		columnWidth = maxf(rect.W, maxf(labelWidth, valueRoom)-ctx.style.ItemSpacing)
+		rect.X = pos.X + (columnWidth-rect.W)/2
+		if label != "" {
+			rect.Y += ctx.lineHeight() + ctx.style.ItemSpacing
+		}
+	}
+
+	// Draw label
+	if label != "" {
+		labelX := pos.X
+		if vertical {
+			labelX += (columnWidth - ctx.MeasureText(label).X) / 2
+		}
+		ctx.addText(labelX, pos.Y+(h-ctx.lineHeight())/2, label, ctx.style.TextColor)
+	}
+
 	// Register as focusable (enables click-to-focus and keyboard navigation)
 	focusable := ctx.RegisterFocusable(id, label, rect, FocusTypeLeaf)
 	isFocused := focusable != nil && focusable.IsFocused()
@@ -64,13 +97,55 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 	hovered := ctx.isHovered(id, rect)
 	changed := false
 
-	// Handle mouse input
-	if ctx.Input != nil {
-		// Start dragging on mouse down
+	// Typed entry (WithTypeable): Enter, focus loss or a click elsewhere
+	// commits, Escape reverts
+	if ctx.Input != nil && state.Editing {
+		ctx.WantCaptureKeyboard = true
+		// The pre-filled value starts selected: the first edit replaces it
+		if state.EditSelected && (len(ctx.Input.InputChars) > 0 || ctx.Input.KeyRepeated(KeyBackspace)) {
+			state.EditText = ""
+			state.EditSelected = false
+		}
+		ctx.numberEditInput(&state.EditText, false)
+
+		commit := ctx.Input.KeyPressed(KeyEnter) || (focusable != nil && !isFocused) ||
+			(!hovered && ctx.Input.MouseClicked(MouseButtonLeft))
+		if !GetOpt(o, OptNoEscape) && ctx.escapeFor(escapeText, id) {
+			state.Editing = false
+		} else if commit {
+			state.Editing = false
+			if newValue, ok := parseNumber(state.EditText); ok {
+				// Round to the displayed precision so the value matches what was typed
+				if rounded, ok := parseNumber(formatNumber(format, newValue)); ok {
+					newValue = rounded
+				}
+				newValue = clampf(newValue, minVal, maxVal)
+				if newValue != *value {
+					*value = newValue
+					changed = true
+				}
+			}
+		}
+	} else if ctx.Input != nil {
+		// Start dragging on mouse down; a quick second press starts typing instead
 		if hovered && ctx.Input.MouseClicked(MouseButtonLeft) {
-			state.Dragging = true
-			state.DragStartX = ctx.Input.MouseX
-			state.DragStartValue = *value
+			doubleClick := ctx.Input.MouseDoubleClicked(MouseButtonLeft)
+			if doubleClick && GetOpt(o, OptTypeable) {
+				// Undo the jump made by the first press, so the entry starts
+				// from (and Escape keeps) the value before the gesture
+				if *value != state.DragStartValue {
+					*value = state.DragStartValue
+					changed = true
+				}
+				state.Editing = true
+				state.EditText = formatNumber(format, *value)
+				state.EditSelected = true
+				state.Dragging = false
+			} else {
+				state.Dragging = true
+				state.DragStartX = ctx.Input.MouseX
+				state.DragStartValue = *value
+			}
 		}
 
 		// Handle dragging
@@ -79,6 +154,10 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 				// Calculate new value from mouse position
 				relX := ctx.Input.MouseX - trackX - grabWidth/2
 				ratio := clampf(relX/(sliderWidth-grabWidth), 0, 1)
+				if vertical {
+					relY := rect.Y + rect.H - ctx.Input.MouseY - grabWidth/2
+					ratio = clampf(relY/(rect.H-grabWidth), 0, 1)
+				}
 				newValue := minVal + ratio*(maxVal-minVal)
 
 				// Apply step if configured
@@ -111,20 +190,21 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 			}
 		}
 
-		// Keyboard support when focused (Left/Right arrows to adjust)
+		// Keyboard support when focused (Left/Right arrows to adjust, and
+		// Down/Up when vertical)
 		if isFocused {
 			step := GetOpt(o, OptStep)
 			if step == 0 {
 				step = (maxVal - minVal) / 100 // Default 1% step
 			}
-			if ctx.Input.KeyRepeated(KeyLeft) {
+			if ctx.Input.KeyRepeated(KeyLeft) || vertical && ctx.Input.KeyRepeated(KeyDown) {
 				newValue := clampf(*value-step, minVal, maxVal)
 				if newValue != *value {
 					*value = newValue
 					changed = true
 				}
 			}
-			if ctx.Input.KeyRepeated(KeyRight) {
+			if ctx.Input.KeyRepeated(KeyRight) || vertical && ctx.Input.KeyRepeated(KeyUp) {
 				newValue := clampf(*value+step, minVal, maxVal)
 				if newValue != *value {
 					*value = newValue
@@ -141,49 +221,91 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 	}
 	grabX := trackX + ratio*(sliderWidth-grabWidth)
 
-	// Draw track background
-	ctx.DrawList.AddRect(trackX, trackY, sliderWidth, trackHeight, ctx.style.SliderTrackColor)
+	if state.Editing {
+		// Typed entry replaces the track with an edit box, like NumberInput
+		ctx.DrawList.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.InputFocusedBgColor)
+		ctx.DrawList.AddRectOutline(rect.X, rect.Y, rect.W, rect.H, ctx.style.InputBorderColor, ctx.style.BorderSize)
+		textX := rect.X + ctx.style.InputPadding
+		textW := ctx.MeasureText(state.EditText).X
+		if state.EditSelected {
+			ctx.DrawList.AddRect(textX, rect.Y+2, textW, rect.H-4, ctx.style.SelectionColor)
+		}
+		ctx.addText(textX, rect.Y+(rect.H-ctx.lineHeight())/2, state.EditText, ctx.style.TextColor)
+		if int(ctx.Time*2)%2 == 0 { // Blink every 0.5s
+			cursorX := textX + textW
+			ctx.DrawList.AddLine(cursorX, rect.Y+2, cursorX, rect.Y+rect.H-2, ctx.style.CaretColor, 1)
+		}
+	} else if vertical {
+		// Draw track background, filled from the bottom
+		trackW := trackHeight
+		trackX := rect.X + (rect.W-trackW)/2
+		ctx.DrawList.AddRect(trackX, rect.Y, trackW, rect.H, ctx.style.SliderTrackColor)
+		if fillHeight := ratio * rect.H; fillHeight > 0 {
+			ctx.DrawList.AddRect(trackX, rect.Y+rect.H-fillHeight, trackW, fillHeight, ctx.style.SliderFillColor)
+		}
 
-	// Draw filled portion
-	fillWidth := ratio * sliderWidth
-	if fillWidth > 0 {
-		ctx.DrawList.AddRect(trackX, trackY, fillWidth, trackHeight, ctx.style.SliderFillColor)
-	}
+		// Draw grab handle
+		grabY := rect.Y + (1-ratio)*(rect.H-grabWidth)
+		ctx.DrawList.AddRect(rect.X, grabY, rect.W, grabWidth, ctx.sliderGrabColor(state, hovered || isFocused))
+		ctx.DrawList.AddRectOutline(rect.X, grabY, rect.W, grabWidth, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	} else {
+		// Draw track background
+		ctx.DrawList.AddRect(trackX, trackY, sliderWidth, trackHeight, ctx.style.SliderTrackColor)
 
-	// Draw grab handle
-	grabColor := ctx.style.SliderGrabColor
-	if state.Dragging {
-		grabColor = ctx.style.SliderGrabActive
-	} else if hovered || isFocused {
-		grabColor = ctx.style.SliderGrabHovered
+		// Draw filled portion
+		fillWidth := ratio * sliderWidth
+		if fillWidth > 0 {
+			ctx.DrawList.AddRect(trackX, trackY, fillWidth, trackHeight, ctx.style.SliderFillColor)
+		}
+
+		// Draw grab handle
+		ctx.DrawList.AddRect(grabX, pos.Y, grabWidth, grabHeight, ctx.sliderGrabColor(state, hovered || isFocused))
+		ctx.DrawList.AddRectOutline(grabX, pos.Y, grabWidth, grabHeight, ctx.style.InputBorderColor, ctx.style.BorderSize)
 	}
-	ctx.DrawList.AddRect(grabX, pos.Y, grabWidth, grabHeight, grabColor)
-	ctx.DrawList.AddRectOutline(grabX, pos.Y, grabWidth, grabHeight, ctx.style.InputBorderColor, 1)
 
 	// Draw value text
-	format := GetOpt(o, OptFormat)
-	if format == "" {
-		format = "%.2f"
-	}
-	// Handle integer format specifiers (%d) with float32 value
-	var valueText string
-	if strings.Contains(format, "%d") {
-		valueText = fmt.Sprintf(format, int(*value))
+	valueText := formatNumber(format, *value)
+	valueWidth := ctx.MeasureText(valueText).X
+	if vertical {
+		ctx.addText(pos.X+(columnWidth-valueWidth)/2, rect.Y+rect.H+ctx.style.ItemSpacing, valueText, ctx.style.TextColor)
 	} else {
-		valueText = fmt.Sprintf(format, *value)
+		ctx.addText(trackX+sliderWidth+ctx.style.ItemSpacing, pos.Y, valueText, ctx.style.TextColor)
+	}
+
+	if state.Editing && !GetOpt(o, OptNoEscape) {
+		ctx.claimEscape(escapeText, id)
 	}
-	valueWidth := ctx.MeasureText(valueText).X
-	ctx.addText(trackX+sliderWidth+ctx.style.ItemSpacing, pos.Y, valueText, ctx.style.TextColor)
 
 	// State is automatically saved via pointer (no need to call SetState)
 
-	// Advance cursor
+	ctx.notifyChange(id, o, BuiltinComponents.Slider, label, *value, changed, state.Dragging)
+
+	// Advance cursor (past the error message, if any)
+	if vertical {
+		// The error frame takes in the value below the track
+		column := Rect{X: pos.X, Y: rect.Y, W: columnWidth, H: rect.H + ctx.style.ItemSpacing + ctx.lineHeight()}
+		h = column.Y + column.H - pos.Y + ctx.drawFieldError(o, column)
+		ctx.advanceCursor(Vec2{columnWidth, h})
+		return changed
+	}
+	h += ctx.drawFieldError(o, rect)
 	totalWidth := labelWidth + sliderWidth + ctx.style.ItemSpacing + valueWidth
 	ctx.advanceCursor(Vec2{totalWidth, h})
 
 	return changed
 }
 
+// sliderGrabColor returns the grab handle color for the slider's state.
+func (ctx *Context) sliderGrabColor(state *SliderState, highlighted bool) uint32 {
+	if state.Dragging {
+		return ctx.style.SliderGrabActive
+	}
+	if highlighted {
+		return ctx.style.SliderGrabHovered
+	}
+	return ctx.style.SliderGrabColor
+}
+
 // SliderInt draws a horizontal slider for int values.
 // Returns true if the value was changed.
 //
@@ -195,7 +317,8 @@ func (ctx *Context) SliderFloat(label string, value *float32, minVal, maxVal flo
 func (ctx *Context) SliderInt(label string, value *int, minVal, maxVal int, opts ...Option) bool {
 	// Convert to float for internal handling
 	floatVal := float32(*value)
-	opts = append(opts, WithStep(1)) // Force integer steps
+	// Copy before appending, so the caller's backing array isn't written to
+	opts = append(append([]Option(nil), opts...), WithStep(1), WithOpt(optIntValue, true)) // Force integer steps
 
 	// Use format for integers if not specified
 	found := false
@@ -211,6 +334,14 @@ func (ctx *Context) SliderInt(label string, value *int, minVal, maxVal int, opts
 		opts = append(opts, WithFormat("%d"))
 	}
 
+	// Write the int back before the user's callback runs
+	if onChange := ApplyAndGet(opts, OptOnChange); onChange != nil {
+		opts = append(opts, WithOnChange(func() {
+			*value = int(floatVal)
+			onChange()
+		}))
+	}
+
 	changed := ctx.SliderFloat(label, &floatVal, float32(minVal), float32(maxVal), opts...)
 	if changed {
 		*value = int(floatVal)
@@ -222,5 +353,171 @@ func (ctx *Context) SliderInt(label string, value *int, minVal, maxVal int, opts
 // Returns nil if the slider hasn't been rendered yet this frame.
 func GetSliderState(ctx *Context, label string) *SliderState {
 	id := ctx.GetID(label)
-	return sliderStore.GetIfExists(id)
+	return sliderStore.GetIfExistsFor(ctx, id)
+}
+
+// VSliderFloat draws a vertical slider with a track height pixels tall,
+// for mixer-style channel strips. It is SliderFloat with WithVertical and
+// WithHeight(height): the label goes above the track and the value below.
+//
+// Usage:
+//
+//	ctx.HStack()(func() {
+//	    for i := range channels {
+//	        ctx.VSliderFloat(channels[i].Name, 120, &channels[i].Gain, 0, 1)
+//	    }
+//	})
+func (ctx *Context) VSliderFloat(label string, height float32, value *float32, minVal, maxVal float32, opts ...Option) bool {
+	opts = append(append([]Option(nil), opts...), WithVertical(), WithHeight(height))
+	return ctx.SliderFloat(label, value, minVal, maxVal, opts...)
+}
+
+// VSliderInt is the int variant of VSliderFloat.
+func (ctx *Context) VSliderInt(label string, height float32, value *int, minVal, maxVal int, opts ...Option) bool {
+	opts = append(append([]Option(nil), opts...), WithVertical(), WithHeight(height))
+	return ctx.SliderInt(label, value, minVal, maxVal, opts...)
+}
+
+// SliderFloatRange draws a slider with two handles for picking a range,
+// such as a price or health filter. The track between *lo and *hi is
+// filled. Pressing the track grabs the nearer handle and moves it to the
+// mouse; a handle can't be dragged past the other one, so *lo <= *hi
+// always holds. When focused, Left/Right move the handle that was dragged
+// last (the low one at first). Supports WithStep and WithFormat like
+// SliderFloat. Returns true if either bound changed.
+//
+// Usage:
+//
+//	if ctx.SliderFloatRange("Price", &minPrice, &maxPrice, 0, 1000, gui.WithFormat("$%.0f")) {
+//	    filterVehicles(minPrice, maxPrice)
+//	}
+func (ctx *Context) SliderFloatRange(label string, lo, hi *float32, minVal, maxVal float32, opts ...Option) bool {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	id := ctx.GetID(label)
+	if optID := GetOpt(o, OptID); optID != "" {
+		id = ctx.GetID(optID)
+	}
+	state := sliderStore.GetFor(ctx, id, SliderState{})
+
+	labelWidth := float32(0)
+	if label != "" {
+		labelWidth = ctx.MeasureText(label).X + ctx.style.ItemSpacing
+	}
+	format := GetOpt(o, OptFormat)
+	rangeText := func(a, b float32) string {
+		return formatNumber(format, a) + " - " + formatNumber(format, b)
+	}
+	valueRoom := ctx.MeasureText(rangeText(maxVal, maxVal)).X + ctx.style.ItemSpacing
+	sliderWidth := ctx.itemWidth(o, 150, labelWidth+valueRoom)
+
+	h := ctx.lineHeight()
+	trackHeight := h * 0.5
+	grabWidth := float32(12)
+	trackX := pos.X + labelWidth
+	trackY := pos.Y + (h-trackHeight)/2
+	rect := Rect{X: trackX, Y: pos.Y, W: sliderWidth, H: h}
+
+	if label != "" {
+		ctx.addText(pos.X, pos.Y, label, ctx.style.TextColor)
+	}
+
+	focusable := ctx.RegisterFocusable(id, label, rect, FocusTypeLeaf)
+	isFocused := focusable != nil && focusable.IsFocused()
+	hovered := ctx.isHovered(id, rect)
+
+	// Handles stay in range and can't pass each other
+	changed := false
+	set := func(v *float32, newValue, low, high float32) {
+		if step := GetOpt(o, OptStep); step > 0 {
+			newValue = minVal + float32(int((newValue-minVal)/step+0.5))*step
+		}
+		newValue = clampf(newValue, low, high)
+		if newValue != *v {
+			*v = newValue
+			changed = true
+		}
+	}
+	moveHandle := func(newValue float32) {
+		if state.DragHigh {
+			set(hi, newValue, *lo, maxVal)
+		} else {
+			set(lo, newValue, minVal, *hi)
+		}
+	}
+
+	ratioOf := func(v float32) float32 {
+		if maxVal <= minVal {
+			return 0
+		}
+		return (v - minVal) / (maxVal - minVal)
+	}
+	valueAt := func(mouseX float32) float32 {
+		ratio := clampf((mouseX-trackX-grabWidth/2)/(sliderWidth-grabWidth), 0, 1)
+		return minVal + ratio*(maxVal-minVal)
+	}
+
+	if input := ctx.Input; input != nil {
+		// A press grabs the nearer handle (the high one when past both)
+		if hovered && input.MouseClicked(MouseButtonLeft) {
+			v := valueAt(input.MouseX)
+			state.DragHigh = v > *hi || v > *lo && v-*lo > *hi-v
+			state.Dragging = true
+		}
+		if state.Dragging {
+			if input.MouseDown(MouseButtonLeft) {
+				moveHandle(valueAt(input.MouseX))
+			} else {
+				state.Dragging = false
+			}
+		}
+		if isFocused {
+			step := GetOpt(o, OptStep)
+			if step == 0 {
+				step = (maxVal - minVal) / 100 // Default 1% step
+			}
+			current := *lo
+			if state.DragHigh {
+				current = *hi
+			}
+			if input.KeyRepeated(KeyLeft) {
+				moveHandle(current - step)
+			}
+			if input.KeyRepeated(KeyRight) {
+				moveHandle(current + step)
+			}
+		}
+	}
+
+	// Track, the filled range and both handles
+	loX := trackX + ratioOf(*lo)*(sliderWidth-grabWidth)
+	hiX := trackX + ratioOf(*hi)*(sliderWidth-grabWidth)
+	ctx.DrawList.AddRect(trackX, trackY, sliderWidth, trackHeight, ctx.style.SliderTrackColor)
+	if hiX > loX {
+		ctx.DrawList.AddRect(loX+grabWidth/2, trackY, hiX-loX, trackHeight, ctx.style.SliderFillColor)
+	}
+	for _, high := range []bool{false, true} {
+		x := loX
+		if high {
+			x = hiX
+		}
+		color := ctx.style.SliderGrabColor
+		switch {
+		case state.Dragging && state.DragHigh == high:
+			color = ctx.style.SliderGrabActive
+		case (hovered || isFocused) && state.DragHigh == high:
+			color = ctx.style.SliderGrabHovered
+		}
+		ctx.DrawList.AddRect(x, pos.Y, grabWidth, h, color)
+		ctx.DrawList.AddRectOutline(x, pos.Y, grabWidth, h, ctx.style.InputBorderColor, ctx.style.BorderSize)
+	}
+
+	valueText := rangeText(*lo, *hi)
+	ctx.addText(trackX+sliderWidth+ctx.style.ItemSpacing, pos.Y, valueText, ctx.style.TextColor)
+
+	ctx.notifyChange(id, o, BuiltinComponents.SliderRange, label, [2]float32{*lo, *hi}, changed, state.Dragging)
+	h += ctx.drawFieldError(o, rect)
+	ctx.advanceCursor(Vec2{labelWidth + sliderWidth + ctx.style.ItemSpacing + ctx.MeasureText(valueText).X, h})
+	return changed
 }
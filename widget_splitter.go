@@ -0,0 +1,127 @@
+package gui
+
+// SplitterSize is the thickness of a Splitter's divider, which sits
+// between the two regions.
+const SplitterSize = float32(6)
+
+// splitterKeyStep is how far one arrow key press moves a focused Splitter;
+// splitterKeyStepLarge is the step with Shift held.
+const (
+	splitterKeyStep      = float32(10)
+	splitterKeyStepLarge = float32(50)
+)
+
+// Splitter draws a draggable divider between two regions and adjusts
+// *size1, the size of the first, as the user drags it. With vertical set
+// the divider is a vertical bar between a left and a right region,
+// otherwise a horizontal bar between a top and a bottom one. The region
+// is the space left in the current layout (WithWidth/WithHeight override
+// it); *size1 is clamped so both regions keep their minimum size, and the
+// second region gets the rest minus SplitterSize. Left/Right (or Up/Down)
+// move the divider when focused, in larger steps with Shift held; focus
+// shows the standard focus ring. Returns true if *size1 changed.
+//
+// The splitter doesn't advance the cursor: call it first, then lay out the
+// regions. Nothing is stored besides *size1.
+//
+// Usage:
+//
+//	ctx.Splitter("editor_split", true, &treeWidth, 120, 200)
+//	ctx.HStack(gui.Gap(gui.SplitterSize))(func() {
+//	    ctx.VStack(gui.Width(treeWidth))(drawTree)
+//	    ctx.VStack()(drawDetails)
+//	})
+func (ctx *Context) Splitter(id string, vertical bool, size1 *float32, minSize1, minSize2 float32, opts ...Option) bool {
+	o := applyOptions(opts)
+	saved := ctx.cursor
+	pos := ctx.ItemPos()
+	avail := ctx.ContentRegionAvail()
+	ctx.cursor = saved
+	if w := GetOpt(o, OptWidth); w > 0 {
+		avail.X = w
+	}
+	if h := GetOpt(o, OptHeight); h > 0 {
+		avail.Y = h
+	}
+
+	splitID := ctx.GetID(id)
+	if optID := GetOpt(o, OptID); optID != "" {
+		splitID = ctx.GetID(optID)
+	}
+
+	total, along := avail.X, pos.X
+	if !vertical {
+		total, along = avail.Y, pos.Y
+	}
+	clampSize := func(v float32) float32 {
+		return maxf(minSize1, minf(v, total-SplitterSize-minSize2))
+	}
+
+	changed := false
+	setSize := func(v float32) {
+		v = clampSize(v)
+		if v != *size1 {
+			*size1 = v
+			changed = true
+		}
+	}
+	setSize(*size1)
+
+	rect := Rect{X: pos.X + *size1, Y: pos.Y, W: SplitterSize, H: avail.Y}
+	if !vertical {
+		rect = Rect{X: pos.X, Y: pos.Y + *size1, W: avail.X, H: SplitterSize}
+	}
+
+	focusable := ctx.RegisterFocusable(splitID, id, rect, FocusTypeLeaf)
+	isFocused := focusable != nil && focusable.IsFocused()
+	hovered, _, _ := ctx.buttonBehavior(splitID, rect, false, false)
+	dragging := ctx.activeID == splitID && ctx.Input != nil && ctx.Input.MouseDown(MouseButtonLeft)
+
+	if dragging {
+		mouse := ctx.Input.MouseX
+		if !vertical {
+			mouse = ctx.Input.MouseY
+		}
+		setSize(mouse - along - SplitterSize/2)
+	}
+	if isFocused && ctx.Input != nil {
+		less, more := KeyLeft, KeyRight
+		if !vertical {
+			less, more = KeyUp, KeyDown
+		}
+		step := splitterKeyStep
+		if ctx.Input.ModShift {
+			step = splitterKeyStepLarge
+		}
+		if ctx.Input.KeyRepeated(less) {
+			setSize(*size1 - step)
+		}
+		if ctx.Input.KeyRepeated(more) {
+			setSize(*size1 + step)
+		}
+	}
+
+	// Draw: a thin line, widened to the whole handle when hovered or
+	// dragged, and ringed when focused
+	if vertical {
+		rect.X = pos.X + *size1
+	} else {
+		rect.Y = pos.Y + *size1
+	}
+	switch {
+	case dragging:
+		ctx.DrawList.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.SliderGrabActive)
+	case hovered:
+		ctx.DrawList.AddRect(rect.X, rect.Y, rect.W, rect.H, ctx.style.SliderGrabHovered)
+	case vertical:
+		ctx.DrawList.AddRect(rect.X+(SplitterSize-1)/2, rect.Y, 1, rect.H, ctx.style.BorderColor)
+	default:
+		ctx.DrawList.AddRect(rect.X, rect.Y+(SplitterSize-1)/2, rect.W, 1, ctx.style.BorderColor)
+	}
+	if isFocused {
+		DrawFocusRing(ctx.DrawList, rect.X, rect.Y, rect.W, rect.H, ctx.style)
+	}
+
+	ctx.notifyChange(splitID, o, BuiltinComponents.Splitter, id, *size1, changed, dragging)
+	return changed
+}
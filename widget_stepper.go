@@ -0,0 +1,116 @@
+package gui
+
+import "fmt"
+
+// stepCircleSegments is the number of sides used to draw a step's circle.
+const stepCircleSegments = 24
+
+// StepIndicator draws a wizard header: one numbered circle per step, spread
+// evenly across the layout width (or WithWidth) and joined by connector
+// lines, with each step's name centered beneath its circle. Steps before
+// current are completed and show a check mark, the current step is
+// highlighted, and later steps are dimmed.
+//
+// With WithStepNavigation() completed steps can be clicked to go back;
+// StepIndicator then returns the clicked step. It returns -1 when nothing
+// was clicked. Later steps are never clickable.
+//
+// Usage:
+//
+//	steps := []string{"Account", "Profile", "Confirm"}
+//	if i := ctx.StepIndicator(steps, wizard.Step, gui.WithStepNavigation()); i >= 0 {
+//	    wizard.Step = i
+//	}
+func (ctx *Context) StepIndicator(steps []string, current int, opts ...Option) int {
+	pos := ctx.ItemPos()
+	o := applyOptions(opts)
+
+	idStr := "##steps"
+	if optID := GetOpt(o, OptID); optID != "" {
+		idStr = optID
+	}
+
+	w := ctx.currentLayoutWidth()
+	if optWidth := GetOpt(o, OptWidth); optWidth > 0 {
+		w = optWidth
+	}
+	lh := ctx.lineHeight()
+	radius := lh/2 + SpaceXS
+	h := radius*2 + SpaceXS + lh
+	if len(steps) == 0 {
+		ctx.advanceCursor(Vec2{X: w, Y: h})
+		return -1
+	}
+
+	// Each step owns an equal slice of the width, its circle centered in it
+	segW := w / float32(len(steps))
+	centerY := pos.Y + radius
+	center := func(i int) Vec2 {
+		return Vec2{X: pos.X + segW*(float32(i)+0.5), Y: centerY}
+	}
+
+	doneColor := ctx.style.SliderFillColor
+	todoColor := ctx.style.SliderTrackColor
+
+	// Connectors first so the circles are drawn over their ends
+	for i := 0; i+1 < len(steps); i++ {
+		color := todoColor
+		if i < current {
+			color = doneColor
+		}
+		a, b := center(i), center(i+1)
+		ctx.DrawList.AddLine(a.X+radius, centerY, b.X-radius, centerY, color, 2)
+	}
+
+	navigate := GetOpt(o, OptStepNavigation) && !GetOpt(o, OptDisabled)
+	clickedStep := -1
+	for i, name := range steps {
+		c := center(i)
+		id := ctx.GetID(fmt.Sprintf("%s_%d", idStr, i))
+		rect := Rect{X: c.X - segW/2, Y: pos.Y, W: segW, H: h}
+
+		hovered := false
+		if navigate && i < current {
+			ctx.RegisterFocusable(id, name, rect, FocusTypeLeaf)
+			var clicked bool
+			hovered, _, clicked = ctx.buttonBehavior(id, rect, false, false)
+			hovered = hovered || ctx.IsRegistryFocused(id)
+			if clicked {
+				clickedStep = i
+			}
+		}
+
+		fill, textColor := todoColor, ctx.style.TextDisabledColor
+		switch {
+		case i < current:
+			fill, textColor = doneColor, ctx.style.TextColor
+			if hovered {
+				fill = ctx.style.SliderGrabHovered
+			}
+		case i == current:
+			fill, textColor = ctx.style.SliderGrabColor, ctx.style.TextColor
+		}
+		ctx.DrawList.AddCircleFilled(c.X, c.Y, radius, fill, stepCircleSegments)
+		if i == current {
+			ctx.DrawList.AddCircleOutline(c.X, c.Y, radius+3, ctx.style.FocusColor, stepCircleSegments, 2)
+		}
+
+		// Check mark for completed steps, the step number otherwise
+		if i < current {
+			s := radius * 0.5
+			ctx.DrawList.AddLine(c.X-s, c.Y, c.X-s*0.3, c.Y+s*0.7, ctx.style.TextColor, 2)
+			ctx.DrawList.AddLine(c.X-s*0.3, c.Y+s*0.7, c.X+s, c.Y-s*0.6, ctx.style.TextColor, 2)
+		} else {
+			num := fmt.Sprint(i + 1)
+			size := ctx.MeasureText(num)
+			ctx.addText(c.X-size.X/2, c.Y-size.Y/2, num, textColor)
+		}
+
+		label := TruncateText(ctx, name, segW-SpaceXS)
+		labelW := ctx.MeasureText(label).X
+		ctx.addText(c.X-labelW/2, pos.Y+radius*2+SpaceXS, label, textColor)
+	}
+
+	ctx.advanceCursor(Vec2{X: w, Y: h})
+	return clickedStep
+}
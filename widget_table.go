@@ -1,5 +1,10 @@
 package gui
 
+import (
+	"slices"
+	"strconv"
+)
+
 // tableStore is the type-safe store for table state.
 // Uses the new FrameStore pattern instead of the old GetState/SetState.
 var tableStore = NewFrameStore[TableState]()
@@ -12,11 +17,12 @@ const (
 
 	// Features
 	TableFlagsResizable       TableFlags = 1 << 0 // Enable column resizing
-	TableFlagsSortable        TableFlags = 1 << 1 // Enable sorting (shows sort indicators)
+	TableFlagsSortable        TableFlags = 1 << 1 // Click headers to sort (see SortSpecs)
 	TableFlagsRowSelect       TableFlags = 1 << 2 // Enable row selection
 	TableFlagsScrollY         TableFlags = 1 << 3 // Enable vertical scrolling (requires height)
 	TableFlagsStickyHeader    TableFlags = 1 << 4 // Keep header visible when scrolling
 	TableFlagsAutoSizeColumns TableFlags = 1 << 5 // Auto-size columns to fit content
+	TableFlagsAutoRowHeight   TableFlags = 1 << 6 // Rows grow to fit their tallest cell
 
 	// Borders
 	TableFlagsBordersInnerH TableFlags = 1 << 8  // Horizontal borders between rows
@@ -57,6 +63,7 @@ type TableColumn struct {
 	InitWidth float32 // Initial/fixed width (0 = auto)
 	MinWidth  float32 // Minimum width when resizing
 	MaxWidth  float32 // Maximum width when resizing (0 = unlimited)
+	Hidden    bool    // Initially hidden (toggle with SetColumnHidden or the header menu)
 
 	// Runtime state (managed by table)
 	width float32 // Current computed width
@@ -70,11 +77,25 @@ type TableState struct {
 	SortAscending    bool      // Sort direction
 	SelectedRow      int       // Selected row index (-1 = none)
 	ScrollOffset     float32   // Vertical scroll position
+	HiddenColumns    []bool    // Per-column visibility (true = hidden)
+	ColumnMenuOpen   bool      // True while the header's column menu is shown
+	ColumnMenuPos    Vec2      // Top-left of the column menu
+	RowHeights       []float32 // Measured row heights (TableFlagsAutoRowHeight)
+	TabbedTo         ID        // Cell focused by Tab, to start editing if it is a text cell
 }
 
 // TableOptions configures table behavior.
 type TableOptions struct {
 	MaxVisibleRows int // Maximum visible rows before scrolling (0 = unlimited)
+	ScrollLines    int // Rows scrolled per mouse wheel notch (0 = 3)
+
+	// Cell padding overrides (0 = Style.CellPaddingX/CellPaddingY)
+	CellPaddingX float32
+	CellPaddingY float32
+
+	// CaptureText records the text drawn in each cell so ExportCSV and
+	// ExportTSV can return it
+	CaptureText bool
 }
 
 // Table manages table drawing state for the current frame.
@@ -85,15 +106,29 @@ type Table struct {
 	columns []TableColumn
 	options TableOptions
 
+	// Column definitions as passed in (for relayout when visibility changes)
+	srcColumns []TableColumn
+	autoSize   bool
+
 	// Layout
 	startX, startY float32 // Table origin
 	width, height  float32 // Table dimensions
 	rowHeight      float32 // Height of each row
+	padX, padY     float32 // Cell padding
 
 	// Current state
 	currentRow    int
 	currentColumn int
 	rowStartY     float32
+	rowY          float32       // Top of the current row (before scrolling)
+	curRowHeight  float32       // Height of the current row
+	cellHeight    float32       // Tallest cell content reported in the current row
+	rowOpen       bool          // A row was started and not yet measured
+	sortDirty     bool          // The sort changed this frame
+	editCells     []ID          // Editable cells drawn this frame, in Tab order
+	captured      []capturedRow // Cell text per row drawn (TableOptions.CaptureText)
+	rowSelected   bool          // The current row is selected (TableFlagsRowSelect)
+	selectedRows  []int         // Selected rows drawn this frame
 
 	// Persistent state
 	state *TableState
@@ -125,7 +160,7 @@ func (ctx *Context) BeginTableEx(id string, columns []TableColumn, flags TableFl
 	tableID := ctx.GetID(id)
 
 	// Get or create persistent state using the new type-safe store
-	state := tableStore.Get(tableID, TableState{
+	state := tableStore.GetFor(ctx, tableID, TableState{
 		SortColumn:       -1,
 		SelectedRow:      -1,
 		ColumnWidths:     make([]float32, len(columns)),
@@ -139,6 +174,12 @@ func (ctx *Context) BeginTableEx(id string, columns []TableColumn, flags TableFl
 	if len(state.MaxContentWidths) != len(columns) {
 		state.MaxContentWidths = make([]float32, len(columns))
 	}
+	if len(state.HiddenColumns) != len(columns) {
+		state.HiddenColumns = make([]bool, len(columns))
+		for i, col := range columns {
+			state.HiddenColumns[i] = col.Hidden
+		}
+	}
 
 	// Reset max content widths for this frame (will be updated during rendering)
 	newMaxWidths := make([]float32, len(columns))
@@ -153,7 +194,8 @@ func (ctx *Context) BeginTableEx(id string, columns []TableColumn, flags TableFl
 
 	// Calculate column widths (use previous frame's content widths for auto-sizing)
 	autoSize := flags&TableFlagsAutoSizeColumns != 0
-	computedColumns := computeColumnWidths(columns, state.ColumnWidths, state.MaxContentWidths, width, ctx, autoSize)
+	padX, padY := ctx.tableCellPadding(opts)
+	computedColumns := computeColumnWidths(columns, state.ColumnWidths, state.MaxContentWidths, state.HiddenColumns, width, padX, ctx, autoSize)
 
 	t := &Table{
 		id:             tableID,
@@ -161,11 +203,15 @@ func (ctx *Context) BeginTableEx(id string, columns []TableColumn, flags TableFl
 		flags:          flags,
 		columns:        computedColumns,
 		options:        opts,
+		srcColumns:     columns,
+		autoSize:       autoSize,
 		startX:         pos.X,
 		startY:         pos.Y,
 		width:          width,
 		height:         height,
-		rowHeight:      ctx.lineHeight(),
+		rowHeight:      ctx.lineHeight() + padY*2,
+		padX:           padX,
+		padY:           padY,
 		currentRow:     -1, // Will be 0 after first TableNextRow
 		state:          state,
 		frameMaxWidths: make([]float32, len(columns)),
@@ -183,8 +229,26 @@ func (ctx *Context) BeginTableEx(id string, columns []TableColumn, flags TableFl
 	return t
 }
 
+// tableCellPadding returns the cell padding for a table: the TableOptions
+// override if set, else the style's.
+func (ctx *Context) tableCellPadding(opts TableOptions) (x, y float32) {
+	x, y = ctx.style.CellPaddingX, ctx.style.CellPaddingY
+	if x <= 0 {
+		x = ctx.style.ItemSpacing
+	}
+	if opts.CellPaddingX > 0 {
+		x = opts.CellPaddingX
+	}
+	if opts.CellPaddingY > 0 {
+		y = opts.CellPaddingY
+	}
+	return x, y
+}
+
 // computeColumnWidths calculates actual column widths based on flags and constraints.
-func computeColumnWidths(columns []TableColumn, savedWidths, maxContentWidths []float32, totalWidth float32, ctx *Context, autoSize bool) []TableColumn {
+// Hidden columns get zero width; the width they would have taken is shared
+// among the visible columns in proportion to their widths.
+func computeColumnWidths(columns []TableColumn, savedWidths, maxContentWidths []float32, hidden []bool, totalWidth, padX float32, ctx *Context, autoSize bool) []TableColumn {
 	result := make([]TableColumn, len(columns))
 	copy(result, columns)
 
@@ -218,13 +282,13 @@ func computeColumnWidths(columns []TableColumn, savedWidths, maxContentWidths []
 		} else {
 			// Auto-size (default or explicit TableColumnFlagsWidthAuto)
 			// Use content width if available, otherwise label width
-			labelWidth := ctx.MeasureText(col.Label).X + ctx.style.ItemSpacing*2
+			labelWidth := ctx.MeasureText(col.Label).X + padX*2
 
 			// Check if auto-sizing is enabled (per-column flag or table-wide flag)
 			useContentWidth := autoSize || col.Flags&TableColumnFlagsWidthAuto != 0
 			if useContentWidth && len(maxContentWidths) > i && maxContentWidths[i] > 0 {
 				// Use max of label and content width
-				contentWidth := maxContentWidths[i] + ctx.style.ItemSpacing*2
+				contentWidth := maxContentWidths[i] + padX*2
 				col.width = labelWidth
 				if contentWidth > col.width {
 					col.width = contentWidth
@@ -265,26 +329,95 @@ func computeColumnWidths(columns []TableColumn, savedWidths, maxContentWidths []
 		}
 	}
 
+	// Third pass: give hidden columns' width to the visible ones
+	hiddenWidth, visibleWidth := float32(0), float32(0)
+	for i := range result {
+		result[i].Hidden = i < len(hidden) && hidden[i]
+		if result[i].Hidden {
+			hiddenWidth += result[i].width
+		} else {
+			visibleWidth += result[i].width
+		}
+	}
+	if hiddenWidth > 0 && visibleWidth > 0 {
+		scale := (visibleWidth + hiddenWidth) / visibleWidth
+		for i := range result {
+			if result[i].Hidden {
+				result[i].width = 0
+			} else {
+				result[i].width *= scale
+			}
+		}
+	} else if hiddenWidth > 0 {
+		for i := range result {
+			result[i].width = 0
+		}
+	}
+
 	return result
 }
 
+// SetColumnHidden shows or hides column i. Hidden columns are skipped when
+// drawing but keep their logical index: TableNextColumn and the TableText
+// functions still step through them, drawing nothing. Visibility persists
+// in TableState.
+func (t *Table) SetColumnHidden(i int, hidden bool) {
+	if i < 0 || i >= len(t.columns) || t.state.HiddenColumns[i] == hidden {
+		return
+	}
+	t.state.HiddenColumns[i] = hidden
+	t.columns = computeColumnWidths(t.srcColumns, t.state.ColumnWidths, t.state.MaxContentWidths, t.state.HiddenColumns, t.width, t.padX, t.ctx, t.autoSize)
+}
+
+// IsColumnHidden reports whether column i is hidden.
+func (t *Table) IsColumnHidden(i int) bool {
+	return i >= 0 && i < len(t.columns) && t.columns[i].Hidden
+}
+
+// TableColumnVisible reports whether the current column is shown. Check it
+// before drawing custom content at TableNextColumn's position (TableCell
+// checks it for you).
+func (t *Table) TableColumnVisible() bool {
+	return !t.IsColumnHidden(t.currentColumn)
+}
+
 // TableHeadersRow renders the header row with column labels.
+// Right-clicking the header opens a menu for showing and hiding columns.
 func (t *Table) TableHeadersRow() {
 	ctx := t.ctx
 	y := t.startY
 
+	t.columnMenu()
+
 	// Draw header background
-	ctx.DrawList.AddRect(t.startX, y, t.width, t.rowHeight, ctx.style.HeaderBgColor)
+	ctx.fillRectV(t.startX, y, t.width, t.rowHeight, ctx.style.HeaderBgColor, ctx.style.HeaderGradient)
 
 	// Draw column headers
+	lastVisible := -1
+	for i, col := range t.columns {
+		if !col.Hidden {
+			lastVisible = i
+		}
+	}
 	x := t.startX
 	for i, col := range t.columns {
+		if col.Hidden {
+			continue
+		}
 		// Header text
 		textColor := ctx.style.HeaderTextColor
 		if textColor == 0 {
 			textColor = ctx.style.TextColor
 		}
-		ctx.addText(x+ctx.style.ItemSpacing, y, col.Label, textColor)
+		ctx.addText(x+t.padX, y+t.padY, col.Label, textColor)
+
+		// Click a sortable header to cycle ascending, descending, unsorted
+		if t.flags&TableFlagsSortable != 0 && col.Flags&TableColumnFlagsNoSort == 0 {
+			headerID := ctx.GetID("##sort_" + col.Label)
+			if _, _, clicked := ctx.buttonBehavior(headerID, Rect{X: x, Y: y, W: col.width, H: t.rowHeight}, false, false); clicked {
+				t.cycleSort(i)
+			}
+		}
 
 		// Sort indicator if sortable
 		if t.flags&TableFlagsSortable != 0 && t.state.SortColumn == i {
@@ -292,12 +425,12 @@ func (t *Table) TableHeadersRow() {
 			if !t.state.SortAscending {
 				indicator = "▼"
 			}
-			indicatorX := x + col.width - ctx.MeasureText(indicator).X - ctx.style.ItemSpacing
-			ctx.addText(indicatorX, y, indicator, textColor)
+			indicatorX := x + col.width - ctx.MeasureText(indicator).X - t.padX
+			ctx.addText(indicatorX, y+t.padY, indicator, textColor)
 		}
 
 		// Vertical border between columns
-		if t.flags&TableFlagsBordersInnerV != 0 && i < len(t.columns)-1 {
+		if t.flags&TableFlagsBordersInnerV != 0 && i < lastVisible {
 			borderX := x + col.width
 			ctx.DrawList.AddLine(borderX, y, borderX, y+t.rowHeight, ctx.style.BorderColor, 1)
 		}
@@ -313,20 +446,160 @@ func (t *Table) TableHeadersRow() {
 	t.rowStartY = y + t.rowHeight
 }
 
-// TableNextRow starts a new row.
+// cycleSort advances column i's sort: ascending, descending, then none.
+func (t *Table) cycleSort(i int) {
+	switch {
+	case t.state.SortColumn != i:
+		t.state.SortColumn, t.state.SortAscending = i, true
+	case t.state.SortAscending:
+		t.state.SortAscending = false
+	default:
+		t.state.SortColumn = -1
+	}
+	t.sortDirty = true
+}
+
+// SortSpecs returns the column the table is sorted by (-1 = unsorted),
+// the direction, and whether the sort changed this frame. Clicking a header
+// of a TableFlagsSortable table cycles its column through ascending,
+// descending and unsorted. Check it after TableHeadersRow and re-sort the
+// rows once when dirty is true.
+//
+// Usage:
+//
+//	t.TableHeadersRow()
+//	if col, asc, dirty := t.SortSpecs(); dirty {
+//	    sortVehicles(vehicles, col, asc)
+//	}
+func (t *Table) SortSpecs() (column int, ascending bool, dirty bool) {
+	return t.state.SortColumn, t.state.SortAscending, t.sortDirty
+}
+
+// SortSpecsDirty reports whether the sort changed this frame; it is the
+// dirty result of SortSpecs.
+func (t *Table) SortSpecsDirty() bool {
+	return t.sortDirty
+}
+
+// columnMenu handles the header's right-click menu listing the columns
+// with checkboxes. The last visible column can't be hidden.
+func (t *Table) columnMenu() {
+	ctx := t.ctx
+	if ctx.Input == nil {
+		return
+	}
+	menuID := ctx.GetID("##columns")
+	headerRect := Rect{X: t.startX, Y: t.startY, W: t.width, H: t.rowHeight}
+	if ctx.Input.MouseClicked(MouseButtonRight) && ctx.isHovered(menuID, headerRect) {
+		t.state.ColumnMenuOpen = true
+		t.state.ColumnMenuPos = Vec2{X: ctx.Input.MouseX, Y: ctx.Input.MouseY}
+	}
+	if !t.state.ColumnMenuOpen {
+		if ctx.ActivePopupID() == menuID {
+			ctx.SetActivePopup(0)
+		}
+		return
+	}
+
+	ctx.SetActivePopup(menuID)
+	savedInPopup := ctx.inPopup
+	ctx.inPopup = true
+	dl := ctx.ForegroundDrawList
+	if dl == nil {
+		dl = ctx.DrawList
+	}
+
+	pad := ctx.style.ItemSpacing
+	rowH := ctx.lineHeight()
+	box := rowH - SpaceSM*2
+	menuW := float32(0)
+	for _, col := range t.srcColumns {
+		menuW = maxf(menuW, ctx.MeasureText(col.Label).X)
+	}
+	menuW += box + pad*3
+	menuRect := Rect{X: t.state.ColumnMenuPos.X, Y: t.state.ColumnMenuPos.Y, W: menuW, H: float32(len(t.columns))*rowH + pad*2}
+
+	visible := 0
+	for _, col := range t.columns {
+		if !col.Hidden {
+			visible++
+		}
+	}
+
+	dl.AddRect(menuRect.X, menuRect.Y, menuRect.W, menuRect.H, ctx.style.PanelColor)
+	dl.AddRectOutline(menuRect.X, menuRect.Y, menuRect.W, menuRect.H, ctx.style.BorderColor, ctx.style.BorderSize)
+	clickedItem := false
+	for i, col := range t.srcColumns {
+		itemRect := Rect{X: menuRect.X, Y: menuRect.Y + pad + float32(i)*rowH, W: menuRect.W, H: rowH}
+		hidden := t.state.HiddenColumns[i]
+		if ctx.isHovered(menuID, itemRect) {
+			dl.AddRect(itemRect.X, itemRect.Y, itemRect.W, itemRect.H, ctx.style.ButtonHoveredColor)
+			if ctx.Input.MouseClicked(MouseButtonLeft) {
+				clickedItem = true
+				if hidden || visible > 1 {
+					t.SetColumnHidden(i, !hidden)
+					hidden = !hidden
+				}
+			}
+		}
+		boxX, boxY := itemRect.X+pad, itemRect.Y+SpaceSM
+		dl.AddRectOutline(boxX, boxY, box, box, ctx.style.InputBorderColor, ctx.style.BorderSize)
+		if !hidden {
+			dl.AddRect(boxX+SpaceXS, boxY+SpaceXS, box-SpaceXS*2, box-SpaceXS*2, ctx.style.TextColor)
+		}
+		ctx.addTextTo(dl, boxX+box+pad, itemRect.Y, col.Label, ctx.style.TextColor)
+	}
+
+	// Close on click outside or Escape
+	if (ctx.Input.MouseClicked(MouseButtonLeft) && !clickedItem && !ctx.isHovered(menuID, menuRect)) ||
+		ctx.escapeFor(escapePopup, menuID) {
+		t.state.ColumnMenuOpen = false
+		ctx.SetActivePopup(0)
+	} else {
+		ctx.claimEscape(escapePopup, menuID)
+	}
+
+	ctx.registerPopupRect(menuID, menuRect)
+	ctx.inPopup = savedInPopup
+}
+
+// TableNextRow starts a new row. Rows are one line tall, or with
+// TableFlagsAutoRowHeight as tall as their tallest cell was last frame.
 func (t *Table) TableNextRow() {
+	t.nextRow(0)
+}
+
+// TableNextRowHeight starts a new row h pixels tall, for cells holding
+// several lines or custom widgets. Later rows are placed below it.
+func (t *Table) TableNextRowHeight(h float32) {
+	t.nextRow(h)
+}
+
+// nextRow starts the next row, h pixels tall (0 = automatic).
+func (t *Table) nextRow(h float32) {
+	t.finishRow()
+	if t.currentRow < 0 {
+		t.rowY = t.rowStartY
+	} else {
+		t.rowY += t.curRowHeight
+	}
 	t.currentRow++
 	t.currentColumn = -1
+	t.curRowHeight = h
+	if h <= 0 {
+		t.curRowHeight = t.autoRowHeight(t.currentRow)
+	}
+	t.rowOpen = true
 
 	ctx := t.ctx
-	y := t.rowStartY + float32(t.currentRow)*t.rowHeight
+	y := t.rowY
 
 	// Create row rect
-	rowRect := Rect{X: t.startX, Y: y, W: t.width, H: t.rowHeight}
+	rowRect := Rect{X: t.startX, Y: y, W: t.width, H: t.curRowHeight}
 
 	// Alternate row background
 	if t.flags&TableFlagsRowBg != 0 && t.currentRow%2 == 1 {
-		ctx.DrawList.AddRect(t.startX, y, t.width, t.rowHeight, ctx.style.RowBgAltColor)
+		ctx.DrawList.AddRect(t.startX, y, t.width, t.curRowHeight, ctx.style.RowBgAltColor)
 	}
 
 	// Register row as focusable if row selection is enabled
@@ -337,16 +610,17 @@ func (t *Table) TableNextRow() {
 
 		// Check if this row has registry focus (set by click or keyboard nav)
 		isSelected := ctx.IsRegistryFocused(rowID)
+		t.selectRow(isSelected)
 
 		if isSelected {
-			ctx.DrawList.AddRect(t.startX, y, t.width, t.rowHeight, ctx.style.SelectedBgColor)
-			ctx.DrawDebugFocusRect(t.startX, y, t.width, t.rowHeight)
+			ctx.DrawList.AddRect(t.startX, y, t.width, t.curRowHeight, ctx.style.SelectedBgColor)
+			ctx.DrawDebugFocusRect(t.startX, y, t.width, t.curRowHeight)
 
 			// Auto-scroll: tell parent Scrollable to keep this row visible
-			ctx.ScrollTo(y, t.rowHeight)
+			ctx.ScrollTo(y, t.curRowHeight)
 
 			// Report focus to parent via the new hierarchical focus system
-			ctx.ReportChildFocus(y, t.rowHeight)
+			ctx.ReportChildFocus(y, t.curRowHeight)
 			ctx.SetFocusChildIdx(t.currentRow)
 		}
 	}
@@ -357,6 +631,40 @@ func (t *Table) TableNextRow() {
 	}
 }
 
+// autoRowHeight returns a row's height before it is drawn: one line, or with
+// TableFlagsAutoRowHeight the height measured for it last frame.
+func (t *Table) autoRowHeight(row int) float32 {
+	if t.flags&TableFlagsAutoRowHeight != 0 && row < len(t.state.RowHeights) {
+		return maxf(t.rowHeight, t.state.RowHeights[row])
+	}
+	return t.rowHeight
+}
+
+// finishRow records the height the current row's cells needed, used to size
+// it next frame with TableFlagsAutoRowHeight.
+func (t *Table) finishRow() {
+	if !t.rowOpen {
+		return
+	}
+	t.rowOpen = false
+	cellHeight := t.cellHeight
+	t.cellHeight = 0
+	if t.flags&TableFlagsAutoRowHeight == 0 {
+		return
+	}
+	if n := t.currentRow + 1; len(t.state.RowHeights) < n {
+		t.state.RowHeights = append(t.state.RowHeights, make([]float32, n-len(t.state.RowHeights))...)
+	}
+	t.state.RowHeights[t.currentRow] = maxf(t.rowHeight, cellHeight+t.padY*2)
+}
+
+// TableCellHeight reports the height of custom content drawn in the current
+// cell, so TableFlagsAutoRowHeight can fit the row to it. TableText and
+// TableTextWrapped report their own height.
+func (t *Table) TableCellHeight(h float32) {
+	t.cellHeight = maxf(t.cellHeight, h)
+}
+
 // TableNextColumn moves to the next column and returns the draw position.
 // Returns the position where content should be drawn.
 func (t *Table) TableNextColumn() Vec2 {
@@ -380,14 +688,13 @@ func (t *Table) TableGetColumnPos() Vec2 {
 	for i := 0; i < t.currentColumn && i < len(t.columns); i++ {
 		x += t.columns[i].width
 	}
-	y := t.rowStartY + float32(t.currentRow)*t.rowHeight
-	return Vec2{X: x + t.ctx.style.ItemSpacing, Y: y}
+	return Vec2{X: x + t.padX, Y: t.rowY + t.padY}
 }
 
 // TableGetColumnWidth returns the width of the current column.
 func (t *Table) TableGetColumnWidth() float32 {
 	if t.currentColumn >= 0 && t.currentColumn < len(t.columns) {
-		return t.columns[t.currentColumn].width - t.ctx.style.ItemSpacing*2
+		return t.columns[t.currentColumn].width - t.padX*2
 	}
 	return 0
 }
@@ -395,33 +702,194 @@ func (t *Table) TableGetColumnWidth() float32 {
 // TableText draws text in the current column.
 func (t *Table) TableText(text string) {
 	pos := t.TableNextColumn()
+	t.captureCell(text)
 	col := t.columns[t.currentColumn]
+	if col.Hidden {
+		return
+	}
 
 	// Track content width for auto-sizing
 	t.trackContentWidth(text)
+	t.TableCellHeight(t.ctx.lineHeight())
 
 	// Truncate text if too wide
-	maxWidth := col.width - t.ctx.style.ItemSpacing*2
+	maxWidth := col.width - t.padX*2
 	displayText := t.truncateText(text, maxWidth)
 
 	t.ctx.addText(pos.X, pos.Y, displayText, t.ctx.style.TextColor)
 }
 
+// TableTextWrapped draws text in the current column, word-wrapped to the
+// column width instead of truncated. Pair it with TableFlagsAutoRowHeight
+// (or TableNextRowHeight) so the row is tall enough for every line.
+func (t *Table) TableTextWrapped(text string) {
+	pos := t.TableNextColumn()
+	t.captureCell(text)
+	if t.clipper != nil {
+		pos.Y -= t.state.ScrollOffset
+	}
+	col := t.columns[t.currentColumn]
+	if col.Hidden {
+		return
+	}
+
+	lh := t.ctx.lineHeight()
+	lines := wrapByWord(t.ctx, text, maxf(col.width-t.padX*2, 1))
+	for i, line := range lines {
+		t.ctx.addText(pos.X, pos.Y+float32(i)*lh, line, t.ctx.style.TextColor)
+	}
+	t.TableCellHeight(float32(len(lines)) * lh)
+}
+
 // TableTextColored draws colored text in the current column.
 func (t *Table) TableTextColored(text string, color uint32) {
 	pos := t.TableNextColumn()
+	t.captureCell(text)
 	col := t.columns[t.currentColumn]
+	if col.Hidden {
+		return
+	}
 
 	// Track content width for auto-sizing
 	t.trackContentWidth(text)
+	t.TableCellHeight(t.ctx.lineHeight())
 
 	// Truncate text if too wide
-	maxWidth := col.width - t.ctx.style.ItemSpacing*2
+	maxWidth := col.width - t.padX*2
 	displayText := t.truncateText(text, maxWidth)
 
 	t.ctx.addText(pos.X, pos.Y, displayText, color)
 }
 
+// TableInputText draws an editable text field filling the current cell,
+// clipped to the column. Returns true if the text changed. The field's ID
+// comes from the table and the cell's row and column, so its state stays
+// with the cell, and it joins the focus order so Tab moves between
+// editable cells.
+//
+// Usage:
+//
+//	for i := range rows {
+//	    t.TableNextRow()
+//	    t.TableInputText(&rows[i].Name)
+//	    t.TableCheckbox(&rows[i].Enabled)
+//	}
+func (t *Table) TableInputText(value *string) bool {
+	changed := false
+	t.cellWidget(true, func(id ID, w float32) {
+		opts := []Option{WithWidth(w)}
+		// Tabbing into a text cell starts editing it
+		if t.state.TabbedTo == id && t.ctx.IsRegistryFocused(id) {
+			t.state.TabbedTo = 0
+			opts = append(opts, ForceFocus())
+		}
+		changed = t.ctx.inputText(id, "", value, applyOptions(opts))
+	})
+	t.captureCell(*value)
+	return changed
+}
+
+// TableCheckbox draws a checkbox in the current cell, clipped to the
+// column. Returns true if toggled. Its ID and focus order work like
+// TableInputText's.
+func (t *Table) TableCheckbox(value *bool) bool {
+	changed := false
+	t.cellWidget(false, func(id ID, w float32) {
+		changed = t.ctx.checkbox(id, "", value, applyOptions(nil))
+	})
+	t.captureCell(strconv.FormatBool(*value))
+	return changed
+}
+
+// TableCell moves to the next column and calls draw with the cursor at the
+// cell's content area, clipped to the cell, so any widgets can fill it.
+// draw isn't called at all for a hidden column, so custom content needs no
+// TableColumnVisible check. Report the content's height with
+// TableCellHeight for TableFlagsAutoRowHeight.
+//
+// Usage:
+//
+//	t.TableNextRow()
+//	t.TableText(car.Name)
+//	t.TableCell(func() { ctx.ProgressBar(car.Damage) })
+func (t *Table) TableCell(draw func()) {
+	pos := t.TableNextColumn()
+	if t.clipper != nil {
+		pos.Y -= t.state.ScrollOffset
+	}
+	col := t.columns[t.currentColumn]
+	if col.Hidden {
+		return
+	}
+	ctx := t.ctx
+	ctx.pushClipRect(Rect{X: pos.X - t.padX, Y: pos.Y - t.padY, W: col.width, H: t.curRowHeight})
+	content := Rect{X: pos.X, Y: pos.Y, W: maxf(col.width-t.padX*2, 1), H: maxf(t.curRowHeight-t.padY*2, 1)}
+	ctx.drawCustomContent(ctx.DrawList, content, draw)
+	ctx.popClipRect()
+}
+
+// cellID returns the ID of the current cell, derived like the row IDs of
+// TableFlagsRowSelect so it doesn't depend on call order.
+func (t *Table) cellID() ID {
+	return t.id + ID(t.currentRow+1)*1000 + ID(t.currentColumn+1)
+}
+
+// cellWidget moves to the next column and runs draw with the cursor at its
+// content area, clipped to the cell. With fill the cursor starts at the
+// top of the row instead, and inputs are padded like the cell so a field
+// fills the row height.
+func (t *Table) cellWidget(fill bool, draw func(id ID, w float32)) {
+	pos := t.TableNextColumn()
+	if t.clipper != nil {
+		pos.Y -= t.state.ScrollOffset
+	}
+	col := t.columns[t.currentColumn]
+	if col.Hidden {
+		return
+	}
+	ctx := t.ctx
+	w := maxf(col.width-t.padX*2, 1)
+	lh := ctx.lineHeight()
+	t.TableCellHeight(lh)
+
+	savedPadding := ctx.style.InputPadding
+	ctx.style.InputPadding = t.padY
+	ctx.pushClipRect(Rect{X: pos.X - t.padX, Y: pos.Y - t.padY, W: col.width, H: t.curRowHeight})
+	content := Rect{X: pos.X, Y: pos.Y, W: w, H: lh}
+	if fill {
+		content.Y, content.H = pos.Y-t.padY, lh+t.padY*2
+	}
+	id := t.cellID()
+	t.editCells = append(t.editCells, id)
+	ctx.drawCustomContent(ctx.DrawList, content, func() {
+		draw(id, w)
+	})
+	ctx.popClipRect()
+	ctx.style.InputPadding = savedPadding
+}
+
+// navigateCells moves focus to the next (Shift+Tab: previous) editable
+// cell, wrapping around, when Tab is pressed while one of them is focused.
+func (t *Table) navigateCells() {
+	ctx := t.ctx
+	if ctx.Input == nil || ctx.focusRegistry == nil || !ctx.Input.KeyPressed(KeyTab) || ctx.tabConsumed {
+		return
+	}
+	i := slices.Index(t.editCells, ctx.focusRegistry.CurrentFocusID())
+	if i < 0 {
+		return
+	}
+	n := len(t.editCells)
+	if ctx.Input.ModShift {
+		i = (i + n - 1) % n
+	} else {
+		i = (i + 1) % n
+	}
+	ctx.focusRegistry.SetFocus(t.editCells[i])
+	t.state.TabbedTo = t.editCells[i]
+	ctx.tabConsumed = true
+}
+
 // trackContentWidth updates the max content width for the current column.
 func (t *Table) trackContentWidth(text string) {
 	if t.currentColumn >= 0 && t.currentColumn < len(t.frameMaxWidths) {
@@ -459,8 +927,11 @@ func (t *Table) TableIsRowHovered() bool {
 		return false
 	}
 
-	y := t.rowStartY + float32(t.currentRow)*t.rowHeight
-	rect := Rect{X: t.startX, Y: y, W: t.width, H: t.rowHeight}
+	y := t.rowY
+	if t.clipper != nil {
+		y -= t.state.ScrollOffset
+	}
+	rect := Rect{X: t.startX, Y: y, W: t.width, H: t.curRowHeight}
 	return rect.Contains(Vec2{t.ctx.Input.MouseX, t.ctx.Input.MouseY})
 }
 
@@ -471,10 +942,23 @@ func (t *Table) TableIsRowClicked() bool {
 
 // EndTable finishes the table and advances the cursor.
 func (t *Table) EndTable() {
+	t.finishRow()
+	t.navigateCells()
+
+	// Ctrl+C copies the selected rows unless a widget has the keyboard
+	if input := t.ctx.Input; input != nil && input.ModCtrl && input.KeyPressed(KeyC) && !t.ctx.WantCaptureKeyboard {
+		t.CopySelectedRows()
+	}
+
 	// Calculate total height
 	totalHeight := t.rowHeight // Header
-	if t.currentRow >= 0 {
-		totalHeight += float32(t.currentRow+1) * t.rowHeight // Data rows
+	if t.clipper == nil && t.currentRow >= 0 {
+		totalHeight += t.rowY + t.curRowHeight - t.rowStartY // Data rows
+		if t.flags&TableFlagsAutoRowHeight != 0 && len(t.state.RowHeights) > t.currentRow+1 {
+			t.state.RowHeights = t.state.RowHeights[:t.currentRow+1]
+		}
+	} else if t.currentRow >= 0 {
+		totalHeight += float32(t.currentRow+1) * t.rowHeight
 	}
 
 	// Draw bottom border if requested
@@ -485,6 +969,12 @@ func (t *Table) EndTable() {
 
 	// Save content widths for next frame's auto-sizing
 	// Always save - individual columns may use auto-sizing even without table flag
+	// (hidden columns drew nothing, so keep their last measured width)
+	for i, col := range t.columns {
+		if col.Hidden && i < len(t.state.MaxContentWidths) {
+			t.frameMaxWidths[i] = t.state.MaxContentWidths[i]
+		}
+	}
 	t.state.MaxContentWidths = t.frameMaxWidths
 
 	// State is automatically saved via pointer (no need to call SetState)
@@ -546,8 +1036,17 @@ func (ctx *Context) BeginTableVirtualized(id string, columns []TableColumn, flag
 		visibleHeight = height
 	}
 
-	// Create clipper for virtualization
-	t.clipper = NewListClipper(totalRows, t.rowHeight, visibleHeight, t.state.ScrollOffset)
+	// Create clipper for virtualization; with auto row heights rows are
+	// placed by their measured heights (unmeasured rows are one line)
+	if flags&TableFlagsAutoRowHeight != 0 {
+		offsets := make([]float32, totalRows+1)
+		for i := range totalRows {
+			offsets[i+1] = offsets[i] + t.autoRowHeight(i)
+		}
+		t.clipper = NewListClipperOffsets(offsets, visibleHeight, t.state.ScrollOffset)
+	} else {
+		t.clipper = NewListClipper(totalRows, t.rowHeight, visibleHeight, t.state.ScrollOffset)
+	}
 	t.totalRows = totalRows
 	t.visibleTop = t.rowStartY
 
@@ -581,26 +1080,34 @@ func (t *Table) TableNextRowVirtualized(rowIdx int) bool {
 		return false
 	}
 
+	t.finishRow()
 	t.currentRow = rowIdx
 	t.currentColumn = -1
+	t.rowY = t.rowStartY + float32(rowIdx)*t.rowHeight
+	t.curRowHeight = t.rowHeight
+	if t.clipper != nil {
+		t.rowY = t.rowStartY + t.clipper.ItemTop(rowIdx)
+		t.curRowHeight = t.clipper.ItemSize(rowIdx)
+	}
 
 	ctx := t.ctx
-	y := t.rowStartY + float32(rowIdx)*t.rowHeight - t.state.ScrollOffset
+	y := t.rowY - t.state.ScrollOffset
 
 	// Skip if row is outside visible area (belt and suspenders check)
 	if t.clipper != nil {
-		if y+t.rowHeight < t.visibleTop || y > t.visibleTop+t.height-t.rowHeight {
+		if y+t.curRowHeight < t.visibleTop || y > t.visibleTop+t.height-t.rowHeight {
 			return false
 		}
 	}
+	t.rowOpen = true
 
 	// Alternate row background
 	if t.flags&TableFlagsRowBg != 0 && rowIdx%2 == 1 {
-		ctx.DrawList.AddRect(t.startX, y, t.width, t.rowHeight, ctx.style.RowBgAltColor)
+		ctx.DrawList.AddRect(t.startX, y, t.width, t.curRowHeight, ctx.style.RowBgAltColor)
 	}
 
 	// Register row as focusable if row selection is enabled
-	rowRect := Rect{X: t.startX, Y: y, W: t.width, H: t.rowHeight}
+	rowRect := Rect{X: t.startX, Y: y, W: t.width, H: t.curRowHeight}
 	if t.flags&TableFlagsRowSelect != 0 {
 		rowID := t.id + ID(rowIdx+1)*1000 // Generate unique ID per row
 		ctx.RegisterFocusable(rowID, "row", rowRect, FocusTypeLeaf)
@@ -612,10 +1119,11 @@ func (t *Table) TableNextRowVirtualized(rowIdx int) bool {
 
 		// Check if this row is selected
 		isSelected := rowIdx == t.state.SelectedRow
+		t.selectRow(isSelected)
 
 		if isSelected {
-			ctx.DrawList.AddRect(t.startX, y, t.width, t.rowHeight, ctx.style.SelectedBgColor)
-			ctx.DrawDebugFocusRect(t.startX, y, t.width, t.rowHeight)
+			ctx.DrawList.AddRect(t.startX, y, t.width, t.curRowHeight, ctx.style.SelectedBgColor)
+			ctx.DrawDebugFocusRect(t.startX, y, t.width, t.curRowHeight)
 		}
 	}
 
@@ -634,8 +1142,7 @@ func (t *Table) TableGetColumnPosVirtualized() Vec2 {
 	for i := 0; i < t.currentColumn && i < len(t.columns); i++ {
 		x += t.columns[i].width
 	}
-	y := t.rowStartY + float32(t.currentRow)*t.rowHeight - t.state.ScrollOffset
-	return Vec2{X: x + t.ctx.style.ItemSpacing, Y: y}
+	return Vec2{X: x + t.padX, Y: t.rowY - t.state.ScrollOffset + t.padY}
 }
 
 // TableTextVirtualized draws text in the current column for virtualized tables.
@@ -644,15 +1151,20 @@ func (t *Table) TableTextVirtualized(text string) {
 	if t.currentColumn >= len(t.columns) {
 		t.currentColumn = 0
 	}
+	t.captureCell(text)
 
 	pos := t.TableGetColumnPosVirtualized()
 	col := t.columns[t.currentColumn]
+	if col.Hidden {
+		return
+	}
 
 	// Track content width for auto-sizing
 	t.trackContentWidth(text)
+	t.TableCellHeight(t.ctx.lineHeight())
 
 	// Truncate text if too wide
-	maxWidth := col.width - t.ctx.style.ItemSpacing*2
+	maxWidth := col.width - t.padX*2
 	displayText := t.truncateText(text, maxWidth)
 
 	t.ctx.addText(pos.X, pos.Y, displayText, t.ctx.style.TextColor)
@@ -664,15 +1176,20 @@ func (t *Table) TableTextColoredVirtualized(text string, color uint32) {
 	if t.currentColumn >= len(t.columns) {
 		t.currentColumn = 0
 	}
+	t.captureCell(text)
 
 	pos := t.TableGetColumnPosVirtualized()
 	col := t.columns[t.currentColumn]
+	if col.Hidden {
+		return
+	}
 
 	// Track content width for auto-sizing
 	t.trackContentWidth(text)
+	t.TableCellHeight(t.ctx.lineHeight())
 
 	// Truncate text if too wide
-	maxWidth := col.width - t.ctx.style.ItemSpacing*2
+	maxWidth := col.width - t.padX*2
 	displayText := t.truncateText(text, maxWidth)
 
 	t.ctx.addText(pos.X, pos.Y, displayText, color)
@@ -718,7 +1235,12 @@ func (t *Table) HandleScrollInput() {
 	if t.ctx.Input.MouseWheelY != 0 {
 		visibleHeight := t.height - t.rowHeight
 		maxScroll := t.clipper.MaxScroll(visibleHeight)
-		newScroll := t.state.ScrollOffset - t.ctx.Input.MouseWheelY*t.rowHeight*3
+		lines := t.options.ScrollLines
+		if lines <= 0 {
+			lines = 3
+		}
+		scroll, _ := t.ctx.wheelScrollLines(t.ctx.Input.MouseWheelY, lines, t.rowHeight)
+		newScroll := t.state.ScrollOffset - scroll
 		t.state.ScrollOffset = clampf(newScroll, 0, maxScroll)
 	}
 }
@@ -0,0 +1,27 @@
+package gui
+
+// fieldErrorGap is the space between a field and its error message.
+const fieldErrorGap = SpaceXS
+
+// drawFieldError draws the WithError validation state for an input field:
+// an error-colored border over rect and the message on a line beneath it.
+// Returns the extra height taken by the message, to add to the height the
+// widget advances the cursor by (0 when there is no error).
+func (ctx *Context) drawFieldError(o options, rect Rect) float32 {
+	msg := GetOpt(o, OptError)
+	if msg == "" {
+		return 0
+	}
+	color := ctx.errorColor()
+	ctx.DrawList.AddRectOutline(rect.X, rect.Y, rect.W, rect.H, color, ctx.style.BorderSize)
+	ctx.addText(rect.X, rect.Y+rect.H+fieldErrorGap, msg, color)
+	return fieldErrorGap + ctx.lineHeight()
+}
+
+// errorColor returns Style.ErrorColor, falling back to red when unset.
+func (ctx *Context) errorColor() uint32 {
+	if ctx.style.ErrorColor == 0 {
+		return ColorRed
+	}
+	return ctx.style.ErrorColor
+}
@@ -0,0 +1,282 @@
+package gui
+
+// virtualTreeStore is the type-safe store for virtual tree state.
+var virtualTreeStore = NewFrameStore[VirtualTreeState]()
+
+// TreeRow describes one visible row of a TreeModel.
+type TreeRow struct {
+	Label       string // Row text
+	Depth       int    // Nesting level (0 = root)
+	HasChildren bool   // True if the node can be expanded
+	Expanded    bool   // True if the node's children are visible
+}
+
+// TreeModel exposes a hierarchy as a flat list of its visible rows, in
+// depth-first order with collapsed subtrees left out. VirtualTree asks the
+// model for the row count every frame, so expanding or collapsing a node
+// must update VisibleCount immediately.
+type TreeModel interface {
+	// VisibleCount returns the number of visible rows.
+	VisibleCount() int
+	// Row returns the visible row at index i.
+	Row(i int) TreeRow
+	// SetExpanded expands or collapses the node at visible row i.
+	SetExpanded(i int, expanded bool)
+}
+
+// TreeItem is a node of a TreeItemModel.
+type TreeItem struct {
+	Label    string
+	Children []*TreeItem
+	Expanded bool
+}
+
+// treeItemRow is a flattened TreeItemModel row.
+type treeItemRow struct {
+	item  *TreeItem
+	depth int
+}
+
+// TreeItemModel is a TreeModel over an in-memory TreeItem hierarchy.
+// The flattened row list is rebuilt lazily after expansion changes; call
+// Invalidate after editing the hierarchy directly.
+type TreeItemModel struct {
+	Roots []*TreeItem
+	rows  []treeItemRow
+	dirty bool
+}
+
+// NewTreeItemModel creates a TreeItemModel over the given root items.
+func NewTreeItemModel(roots ...*TreeItem) *TreeItemModel {
+	return &TreeItemModel{Roots: roots, dirty: true}
+}
+
+// Invalidate marks the flattened rows stale so they are rebuilt on next use.
+func (m *TreeItemModel) Invalidate() {
+	m.dirty = true
+}
+
+// Item returns the TreeItem at visible row i.
+func (m *TreeItemModel) Item(i int) *TreeItem {
+	m.flatten()
+	return m.rows[i].item
+}
+
+// VisibleCount implements TreeModel.
+func (m *TreeItemModel) VisibleCount() int {
+	m.flatten()
+	return len(m.rows)
+}
+
+// Row implements TreeModel.
+func (m *TreeItemModel) Row(i int) TreeRow {
+	m.flatten()
+	r := m.rows[i]
+	return TreeRow{
+		Label:       r.item.Label,
+		Depth:       r.depth,
+		HasChildren: len(r.item.Children) > 0,
+		Expanded:    r.item.Expanded,
+	}
+}
+
+// SetExpanded implements TreeModel.
+func (m *TreeItemModel) SetExpanded(i int, expanded bool) {
+	m.flatten()
+	if item := m.rows[i].item; item.Expanded != expanded {
+		item.Expanded = expanded
+		m.dirty = true
+	}
+}
+
+// flatten rebuilds the visible row list if it is stale.
+func (m *TreeItemModel) flatten() {
+	if !m.dirty {
+		return
+	}
+	m.rows = m.rows[:0]
+	var walk func(items []*TreeItem, depth int)
+	walk = func(items []*TreeItem, depth int) {
+		for _, item := range items {
+			m.rows = append(m.rows, treeItemRow{item: item, depth: depth})
+			if item.Expanded {
+				walk(item.Children, depth+1)
+			}
+		}
+	}
+	walk(m.Roots, 0)
+	m.dirty = false
+}
+
+// VirtualTree draws a scrollable tree of fixed-height rows, rendering only
+// the rows inside the viewport so hierarchies with many thousands of nodes
+// stay cheap. Clicking a row's arrow toggles it; clicking elsewhere selects.
+// While focused, Up/Down move the selection, Left collapses the node (or
+// moves to its parent) and Right expands it (or moves to its first child).
+// Returns the selected visible row index, or -1 if none.
+//
+// Usage:
+//
+//	model := gui.NewTreeItemModel(roots...)
+//	if sel := ctx.VirtualTree("scene", 300, model); sel >= 0 {
+//	    inspect(model.Item(sel))
+//	}
+func (ctx *Context) VirtualTree(id string, height float32, model TreeModel, opts ...Option) int {
+	o := applyOptions(opts)
+	pos := ctx.ItemPos()
+	w := ctx.itemWidth(o, ctx.currentLayoutWidth(), 0)
+
+	treeID := ctx.GetID(id)
+	state := virtualTreeStore.GetFor(ctx, treeID, VirtualTreeState{Selected: -1})
+
+	rect := Rect{X: pos.X, Y: pos.Y, W: w, H: height}
+	rowH := ctx.lineHeight() + ctx.style.ItemSpacing
+	indent := ctx.style.ItemSpacing * 2
+	arrowW := ctx.MeasureText("►").X + 4
+
+	focusable := ctx.RegisterFocusable(treeID, id, rect, FocusTypeLeaf)
+	focused := focusable != nil && focusable.IsFocused()
+	hovered, _, clicked := ctx.buttonBehavior(treeID, rect, false, false)
+
+	count := model.VisibleCount()
+	if state.Selected >= count {
+		state.Selected = count - 1
+	}
+
+	// toggle flips row i and keeps the selection on the same node while rows
+	// appear or disappear below i; a selection hidden by a collapse moves to i
+	toggle := func(i int) {
+		row := model.Row(i)
+		if !row.HasChildren {
+			return
+		}
+		model.SetExpanded(i, !row.Expanded)
+		newCount := model.VisibleCount()
+		delta := newCount - count
+		switch {
+		case state.Selected <= i:
+		case delta < 0 && state.Selected <= i-delta:
+			state.Selected = i
+		default:
+			state.Selected += delta
+		}
+		count = newCount
+	}
+
+	hoverRow := -1
+	if hovered {
+		if row := int((ctx.Input.MouseY - pos.Y + state.ScrollY) / rowH); row >= 0 && row < count {
+			hoverRow = row
+		}
+	}
+	if clicked && hoverRow >= 0 {
+		row := model.Row(hoverRow)
+		arrowX := pos.X + 2 + float32(row.Depth)*indent
+		if row.HasChildren && ctx.Input.MouseX >= arrowX && ctx.Input.MouseX < arrowX+arrowW {
+			toggle(hoverRow)
+		}
+		state.Selected = hoverRow
+	}
+
+	moved := false
+	if focused && ctx.Input != nil && count > 0 {
+		prev := state.Selected
+		switch {
+		case ctx.Input.KeyRepeated(KeyUp):
+			state.Selected = max(0, state.Selected-1)
+		case ctx.Input.KeyRepeated(KeyDown):
+			state.Selected = min(count-1, state.Selected+1)
+		case ctx.Input.KeyPressed(KeyHome):
+			state.Selected = 0
+		case ctx.Input.KeyPressed(KeyEnd):
+			state.Selected = count - 1
+		case ctx.Input.KeyRepeated(KeyLeft) && state.Selected >= 0:
+			row := model.Row(state.Selected)
+			if row.HasChildren && row.Expanded {
+				toggle(state.Selected)
+				break
+			}
+			// Move to the parent: the nearest row above with a smaller depth
+			for i := state.Selected - 1; i >= 0; i-- {
+				if model.Row(i).Depth < row.Depth {
+					state.Selected = i
+					break
+				}
+			}
+		case ctx.Input.KeyRepeated(KeyRight) && state.Selected >= 0:
+			row := model.Row(state.Selected)
+			if !row.HasChildren {
+				break
+			}
+			if !row.Expanded {
+				toggle(state.Selected)
+			} else if state.Selected+1 < count {
+				state.Selected++
+			}
+		}
+		moved = state.Selected != prev
+	}
+
+	if hovered && ctx.Input.MouseWheelY != 0 {
+		scroll, _ := ctx.wheelScrollLines(ctx.Input.MouseWheelY, GetOpt(o, OptScrollLines), rowH)
+		state.ScrollY -= scroll
+	}
+
+	// Clamp after input: collapsing can shrink the content under the scroll
+	clipper := NewListClipper(count, rowH, height, state.ScrollY)
+	if moved {
+		state.ScrollY = clipper.ScrollToItem(state.Selected, state.ScrollY, height)
+	}
+	state.ScrollY = clampf(state.ScrollY, 0, clipper.MaxScroll(height))
+	clipper = NewListClipper(count, rowH, height, state.ScrollY)
+
+	// Draw background
+	ctx.DrawList.AddRect(pos.X, pos.Y, w, height, ctx.style.InputBgColor)
+	ctx.DrawList.AddRectOutline(pos.X, pos.Y, w, height, ctx.style.InputBorderColor, ctx.style.BorderSize)
+
+	contentHeight := clipper.ContentHeight()
+	rowW := w
+	if contentHeight > height {
+		rowW -= ctx.style.ScrollbarSize + 2
+	}
+
+	// Draw only the rows the clipper reports as visible
+	ctx.pushClipRect(rect)
+	for i := clipper.StartIdx; i < clipper.EndIdx; i++ {
+		row := model.Row(i)
+		y := clipper.ItemY(i, pos.Y, state.ScrollY)
+
+		textColor := ctx.style.TextColor
+		if i == state.Selected {
+			ctx.DrawList.AddRect(pos.X, y, rowW, rowH, ctx.style.SelectedBgColor)
+			textColor = ctx.style.SelectedTextColor
+		} else if i == hoverRow {
+			ctx.DrawList.AddRect(pos.X, y, rowW, rowH, ctx.style.HoveredBgColor)
+		}
+
+		x := pos.X + 2 + float32(row.Depth)*indent
+		textY := y + ctx.style.ItemSpacing/2
+		if row.HasChildren {
+			arrow := "►"
+			if row.Expanded {
+				arrow = "▼"
+			}
+			ctx.addText(x, textY, arrow, textColor)
+		}
+		ctx.addText(x+arrowW, textY, row.Label, textColor)
+	}
+	ctx.popClipRect()
+
+	// Draw scrollbar
+	if contentHeight > height {
+		scrollbarX := pos.X + w - ctx.style.ScrollbarSize - 2
+		thumbHeight := maxf(20, height*height/contentHeight)
+		thumbPos := state.ScrollY / clipper.MaxScroll(height) * (height - thumbHeight)
+		ctx.DrawList.AddRect(scrollbarX, pos.Y, ctx.style.ScrollbarSize, height, ctx.style.ScrollbarBgColor)
+		ctx.DrawList.AddRect(scrollbarX, pos.Y+thumbPos, ctx.style.ScrollbarSize, thumbHeight, ctx.style.ScrollbarGrabColor)
+	}
+
+	ctx.advanceCursor(Vec2{w, height})
+
+	return state.Selected
+}